@@ -20,13 +20,30 @@ package main
 // @description Type "Bearer" followed by a space and JWT token.
 
 import (
+	"context"
 	"log"
 
 	"golang-tes/config"
 	"golang-tes/internal/delivery/http/attendance"
+	"golang-tes/internal/delivery/http/correction"
+	"golang-tes/internal/delivery/http/holiday"
+	"golang-tes/internal/delivery/http/leave"
+	"golang-tes/internal/delivery/http/mfa"
+	"golang-tes/internal/delivery/http/organization"
+	"golang-tes/internal/delivery/http/payroll"
+	"golang-tes/internal/delivery/http/report"
+	"golang-tes/internal/delivery/http/role"
+	"golang-tes/internal/delivery/http/schedule"
+	"golang-tes/internal/delivery/http/shift"
 	"golang-tes/internal/delivery/http/user"
+	"golang-tes/internal/domain"
+	"golang-tes/internal/mailer"
+	"golang-tes/internal/middleware"
+	"golang-tes/internal/payrollformat"
 	"golang-tes/internal/repository"
+	"golang-tes/internal/scheduler"
 	"golang-tes/internal/usecase"
+	"golang-tes/internal/utils/validator"
 	"golang-tes/pkg/db"
 
 	_ "golang-tes/docs" // This will be auto-generated
@@ -51,21 +68,93 @@ func main() {
 	// Initialize repositories
 	userRepo := repository.NewMySQLUserRepository(database)
 	attendanceRepo := repository.NewMySQLAttendanceRepository(database)
+	breakRepo := repository.NewMySQLAttendanceBreakRepository(database)
+	scheduleRepo := repository.NewMySQLWorkScheduleRepository(database)
+	shiftRepo := repository.NewMySQLShiftRepository(database)
+	leaveRepo := repository.NewMySQLLeaveRepository(database)
+	holidayRepo := repository.NewMySQLHolidayRepository(database)
+	correctionRepo := repository.NewMySQLAttendanceCorrectionRepository(database)
+	tokenRepo := repository.NewMySQLTokenRepository(database)
+	mfaRepo := repository.NewMySQLMFARepository(database)
+	loginAttemptRepo := repository.NewMySQLLoginAttemptRepository(database)
+	roleRepo := repository.NewMySQLRoleRepository(database)
+	departmentRepo := repository.NewMySQLDepartmentRepository(database)
+	teamRepo := repository.NewMySQLTeamRepository(database)
+	reportRepo := repository.NewMySQLReportRepository(database)
+	payrollExportRepo := repository.NewMySQLPayrollExportRepository(database)
 
 	// Initialize usecases
-	userUsecase := usecase.NewUserUsecase(userRepo, cfg.JWTSecret)
-	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, userRepo)
+	userUsecase := usecase.NewUserUsecase(userRepo, tokenRepo, mfaRepo, loginAttemptRepo, roleRepo, newMailer(cfg), authConfig(cfg))
+	mfaUsecase := usecase.NewMFAUsecase(userRepo, tokenRepo, mfaRepo, roleRepo, cfg.MFAIssuer)
+	roleUsecase := usecase.NewRoleUsecase(roleRepo, userRepo)
+	organizationUsecase := usecase.NewOrganizationUsecase(departmentRepo, teamRepo, userRepo, roleRepo)
+	overtimePolicy := domain.OvertimePolicy{
+		DailyMinutes:  int(cfg.OvertimeDailyThreshold.Minutes()),
+		WeeklyMinutes: int(cfg.OvertimeWeeklyThreshold.Minutes()),
+	}
+	breakRules, err := domain.ParseBreakRules(cfg.BreakRules)
+	if err != nil {
+		log.Fatalf("Invalid BREAK_RULES: %v", err)
+	}
+	attendanceUsecase := usecase.NewAttendanceUsecase(attendanceRepo, breakRepo, userRepo, scheduleRepo, shiftRepo, holidayRepo, roleRepo, overtimePolicy, breakRules)
+	reportUsecase := usecase.NewReportUsecase(reportRepo, userRepo, teamRepo, roleRepo)
+	timesheetUsecase := usecase.NewTimesheetUsecase(attendanceRepo, userRepo, teamRepo, holidayRepo, leaveRepo, roleRepo)
+	scheduleUsecase := usecase.NewWorkScheduleUsecase(scheduleRepo, userRepo)
+	shiftUsecase := usecase.NewShiftUsecase(shiftRepo, userRepo)
+	leaveUsecase := usecase.NewLeaveUsecase(leaveRepo, attendanceRepo, userRepo, scheduleRepo, holidayRepo, roleRepo, map[string]int{
+		domain.LeaveTypeAnnual: cfg.AnnualLeaveDays,
+		domain.LeaveTypeSick:   cfg.SickLeaveDays,
+	})
+	holidayUsecase := usecase.NewHolidayUsecase(holidayRepo, userRepo)
+	correctionUsecase := usecase.NewAttendanceCorrectionUsecase(correctionRepo, attendanceRepo, userRepo, roleRepo, overtimePolicy, breakRules)
+	payrollColumns, err := payrollformat.ParseColumns(cfg.PayrollColumns)
+	if err != nil {
+		log.Fatalf("Invalid PAYROLL_COLUMNS: %v", err)
+	}
+	payrollUsecase := usecase.NewPayrollUsecase(payrollExportRepo, attendanceRepo, leaveRepo, map[string]domain.PayrollFormatter{
+		domain.PayrollFormatCSV:        payrollformat.NewCSVFormatter(),
+		domain.PayrollFormatFixedWidth: payrollformat.NewFixedWidthFormatter(),
+	}, payrollColumns)
+
+	// Start background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create the first admin on a fresh install
+	if cfg.BootstrapAdminEmail != "" {
+		bootstrapAdmin(ctx, cfg, userUsecase)
+	}
+	if cfg.AbsenceJobEnabled {
+		scheduler.NewAbsenceScheduler(attendanceUsecase, cfg.AbsenceCutoff, cfg.AbsenceJobInterval).Start(ctx)
+	}
 
 	// Initialize handlers
-	userHandler := user.NewUserHandler(userUsecase)
+	userHandler := user.NewUserHandler(userUsecase, cfg.RegistrationEnabled)
+	mfaHandler := mfa.NewMFAHandler(mfaUsecase)
+	roleHandler := role.NewRoleHandler(roleUsecase)
+	organizationHandler := organization.NewOrganizationHandler(organizationUsecase)
 	attendanceHandler := attendance.NewAttendanceHandler(attendanceUsecase)
+	reportHandler := report.NewReportHandler(reportUsecase, timesheetUsecase)
+	scheduleHandler := schedule.NewScheduleHandler(scheduleUsecase)
+	shiftHandler := shift.NewShiftHandler(shiftUsecase)
+	leaveHandler := leave.NewLeaveHandler(leaveUsecase)
+	holidayHandler := holiday.NewHolidayHandler(holidayUsecase)
+	correctionHandler := correction.NewCorrectionHandler(correctionUsecase)
+	payrollHandler := payroll.NewPayrollHandler(payrollUsecase)
+
+	// Initialize middleware
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret, userRepo, tokenRepo, mfaRepo, roleRepo)
 
 	// Initialize Gin router with CORS middleware
 	router := gin.Default()
+	// Lockouts are tracked per client IP, which must not come from spoofed headers
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 	router.Use(corsMiddleware())
 
 	// Setup routes
-	setupRoutes(router, cfg, userHandler, attendanceHandler)
+	setupRoutes(router, authMiddleware, userHandler, mfaHandler, roleHandler, organizationHandler, attendanceHandler, reportHandler, scheduleHandler, shiftHandler, leaveHandler, holidayHandler, correctionHandler, payrollHandler)
 
 	// Start server
 	log.Printf("Server starting on %s", cfg.ServerAddress)
@@ -74,6 +163,62 @@ func main() {
 	}
 }
 
+// newMailer returns the mailer selected by MAIL_DRIVER
+func newMailer(cfg *config.Config) domain.Mailer {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
+	case "file":
+		return mailer.NewFileMailer(cfg.MailFilePath, cfg.MailFrom)
+	case "log":
+		return mailer.NewLogMailer()
+	default:
+		log.Fatalf("Unknown MAIL_DRIVER %q", cfg.MailDriver)
+		return nil
+	}
+}
+
+func authConfig(cfg *config.Config) usecase.AuthConfig {
+	return usecase.AuthConfig{
+		JWTSecret:       cfg.JWTSecret,
+		AccessTokenTTL:  cfg.AccessTokenTTL,
+		RefreshTokenTTL: cfg.RefreshTokenTTL,
+		ResetTokenTTL:   cfg.ResetTokenTTL,
+		VerifyTokenTTL:  cfg.VerifyTokenTTL,
+		MFATokenTTL:     cfg.MFATokenTTL,
+		AppURL:          cfg.AppURL,
+		Lockout: usecase.LockoutPolicy{
+			MaxAccountFailures: cfg.LoginMaxFailures,
+			MaxIPFailures:      cfg.LoginIPMaxFailures,
+			BaseLockout:        cfg.LoginLockoutBase,
+			MaxLockout:         cfg.LoginLockoutMax,
+			Window:             cfg.LoginFailureWindow,
+		},
+	}
+}
+
+// bootstrapAdmin creates the configured admin account when no admin exists yet
+func bootstrapAdmin(ctx context.Context, cfg *config.Config, userUsecase domain.UserUsecase) {
+	if err := validator.ValidateEmail(cfg.BootstrapAdminEmail); err != nil {
+		log.Fatalf("Invalid BOOTSTRAP_ADMIN_EMAIL: %v", err)
+	}
+	if err := validator.ValidatePassword(cfg.BootstrapAdminPassword); err != nil {
+		log.Fatalf("Invalid BOOTSTRAP_ADMIN_PASSWORD: %v", err)
+	}
+
+	created, err := userUsecase.BootstrapAdmin(ctx, &domain.User{
+		Name:     cfg.BootstrapAdminName,
+		Email:    cfg.BootstrapAdminEmail,
+		Password: cfg.BootstrapAdminPassword,
+	})
+	if err != nil {
+		log.Fatalf("Failed to bootstrap admin: %v", err)
+	}
+	if created {
+		log.Printf("Created bootstrap admin %s", cfg.BootstrapAdminEmail)
+	}
+}
+
 // CORS middleware
 func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
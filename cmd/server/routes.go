@@ -1,9 +1,19 @@
 package main
 
 import (
-	"golang-tes/config"
 	"golang-tes/internal/delivery/http/attendance"
+	"golang-tes/internal/delivery/http/correction"
+	"golang-tes/internal/delivery/http/holiday"
+	"golang-tes/internal/delivery/http/leave"
+	"golang-tes/internal/delivery/http/mfa"
+	"golang-tes/internal/delivery/http/organization"
+	"golang-tes/internal/delivery/http/payroll"
+	"golang-tes/internal/delivery/http/report"
+	"golang-tes/internal/delivery/http/role"
+	"golang-tes/internal/delivery/http/schedule"
+	"golang-tes/internal/delivery/http/shift"
 	"golang-tes/internal/delivery/http/user"
+	"golang-tes/internal/domain"
 	"golang-tes/internal/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -11,28 +21,211 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func setupRoutes(router *gin.Engine, cfg *config.Config, userHandler *user.UserHandler, attendanceHandler *attendance.AttendanceHandler) {
-	// Create middleware
-	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret)
-
+func setupRoutes(router *gin.Engine, authMiddleware *middleware.AuthMiddleware, userHandler *user.UserHandler, mfaHandler *mfa.MFAHandler, roleHandler *role.RoleHandler, organizationHandler *organization.OrganizationHandler, attendanceHandler *attendance.AttendanceHandler, reportHandler *report.ReportHandler, scheduleHandler *schedule.ScheduleHandler, shiftHandler *shift.ShiftHandler, leaveHandler *leave.LeaveHandler, holidayHandler *holiday.HolidayHandler, correctionHandler *correction.CorrectionHandler, payrollHandler *payroll.PayrollHandler) {
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Public routes
 	router.POST("/api/users/register", userHandler.Register)
 	router.POST("/api/users/login", userHandler.Login)
+	router.POST("/api/users/login/mfa", userHandler.VerifyMFA)
+	router.POST("/api/users/refresh", userHandler.Refresh)
+	router.POST("/api/users/forgot-password", userHandler.ForgotPassword)
+	router.POST("/api/users/reset-password", userHandler.ResetPassword)
+	router.POST("/api/users/verify-email", userHandler.VerifyEmail)
+
+	// Account routes that stay available to users who still have to enroll
+	// in two-factor authentication as required by the MFA policy
+	account := router.Group("/api/users")
+	account.Use(authMiddleware.AuthRequired())
+	{
+		account.GET("/profile", userHandler.GetProfile)
+		account.POST("/logout", userHandler.Logout)
+		account.GET("/mfa", mfaHandler.GetStatus)
+		account.POST("/mfa/setup", mfaHandler.Setup)
+		account.POST("/mfa/enable", mfaHandler.Enable)
+		account.POST("/mfa/disable", mfaHandler.Disable)
+		account.POST("/mfa/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+	}
 
 	// Protected routes
 	protected := router.Group("/api")
-	protected.Use(authMiddleware.AuthRequired())
+	protected.Use(authMiddleware.AuthRequired(), authMiddleware.MFAEnrollmentRequired())
 	{
 		// User routes
-		protected.GET("/users/profile", userHandler.GetProfile)
 		protected.PUT("/users/profile", userHandler.UpdateProfile)
+		protected.POST("/users/verify-email/resend", userHandler.ResendVerification)
+		protected.GET("/users/permissions", roleHandler.GetMyPermissions)
+		protected.GET("/users/reports", organizationHandler.GetMyReports)
+		protected.GET("/users/schedule", scheduleHandler.GetMySchedule)
+		protected.GET("/users/roster", shiftHandler.GetMyRoster)
+
+		// Holiday routes
+		protected.GET("/holidays", holidayHandler.GetMyHolidays)
+
+		// Leave routes
+		protected.GET("/leaves", leaveHandler.GetMyLeaves)
+		protected.GET("/leaves/balances", leaveHandler.GetMyBalances)
+	}
+
+	// Own attendance and correction routes
+	ownAttendanceRead := protected.Group("", authMiddleware.RequirePermission(domain.PermAttendanceReadOwn))
+	{
+		ownAttendanceRead.GET("/attendance", attendanceHandler.GetAttendance)
+		ownAttendanceRead.GET("/attendance/user", attendanceHandler.GetUserAttendance)
+		ownAttendanceRead.GET("/attendance/export", attendanceHandler.ExportAttendance)
+		ownAttendanceRead.GET("/attendance/:id/history", correctionHandler.GetMyAttendanceHistory)
+		ownAttendanceRead.GET("/corrections", correctionHandler.GetMyCorrections)
+		ownAttendanceRead.GET("/reports/monthly/user", reportHandler.GetUserMonthlyReport)
+		ownAttendanceRead.GET("/reports/monthly/export", reportHandler.ExportMonthlySummaries)
+		ownAttendanceRead.GET("/reports/timesheets/user", reportHandler.GetTimesheet)
+		// Managers review their reports' overtime; the usecase checks the scope
+		ownAttendanceRead.POST("/attendance/:id/overtime/approve", attendanceHandler.ApproveOvertime)
+		ownAttendanceRead.POST("/attendance/:id/overtime/reject", attendanceHandler.RejectOvertime)
+	}
+	ownAttendanceWrite := protected.Group("", authMiddleware.RequirePermission(domain.PermAttendanceWriteOwn))
+	{
+		ownAttendanceWrite.POST("/attendance", attendanceHandler.MarkAttendance)
+		ownAttendanceWrite.POST("/attendance/clock-in", attendanceHandler.ClockIn)
+		ownAttendanceWrite.POST("/attendance/clock-out", attendanceHandler.ClockOut)
+		ownAttendanceWrite.POST("/attendance/breaks/start", attendanceHandler.StartBreak)
+		ownAttendanceWrite.POST("/attendance/breaks/end", attendanceHandler.EndBreak)
+		ownAttendanceWrite.POST("/attendance/:id/breaks", attendanceHandler.AddBreak)
+		ownAttendanceWrite.DELETE("/attendance/:id/breaks/:break_id", attendanceHandler.DeleteBreak)
+		ownAttendanceWrite.POST("/attendance/:id/corrections", correctionHandler.RequestCorrection)
+		ownAttendanceWrite.POST("/corrections/:id/cancel", correctionHandler.CancelCorrection)
+	}
+
+	// Own leave routes
+	ownLeavesWrite := protected.Group("", authMiddleware.RequirePermission(domain.PermLeavesWriteOwn))
+	{
+		ownLeavesWrite.POST("/leaves", leaveHandler.RequestLeave)
+		ownLeavesWrite.POST("/leaves/:id/cancel", leaveHandler.CancelLeave)
+	}
+
+	// Management routes, each group guarded by the permission it needs
+	admin := protected.Group("/admin")
+
+	usersRead := admin.Group("", authMiddleware.RequirePermission(domain.PermUsersRead))
+	{
+		usersRead.GET("/users", userHandler.ListUsers)
+		usersRead.GET("/users/:id", userHandler.GetUser)
+		usersRead.GET("/roles", roleHandler.ListRoles)
+		usersRead.GET("/roles/:name", roleHandler.GetRole)
+	}
+
+	usersManage := admin.Group("", authMiddleware.RequirePermission(domain.PermUsersManage))
+	{
+		usersManage.POST("/users", userHandler.CreateUser)
+		usersManage.POST("/users/:id/deactivate", userHandler.DeactivateUser)
+		usersManage.POST("/users/:id/activate", userHandler.ActivateUser)
+		usersManage.POST("/users/:id/unlock", userHandler.UnlockUser)
+		usersManage.DELETE("/users/:id", userHandler.DeleteUser)
+		usersManage.PUT("/users/:id/location", userHandler.SetLocation)
+		usersManage.DELETE("/users/:id/mfa", mfaHandler.ResetUserMFA)
+	}
+
+	rolesManage := admin.Group("", authMiddleware.RequirePermission(domain.PermRolesManage))
+	{
+		rolesManage.PUT("/users/:id/role", userHandler.UpdateRole)
+		rolesManage.GET("/permissions", roleHandler.ListPermissions)
+		rolesManage.POST("/roles", roleHandler.CreateRole)
+		rolesManage.PUT("/roles/:name", roleHandler.UpdateRole)
+		rolesManage.DELETE("/roles/:name", roleHandler.DeleteRole)
+		rolesManage.GET("/mfa/policy", mfaHandler.GetPolicy)
+		rolesManage.PUT("/mfa/policy", mfaHandler.SetPolicy)
+	}
+
+	organizationManage := admin.Group("", authMiddleware.RequirePermission(domain.PermOrganizationManage))
+	{
+		organizationManage.POST("/departments", organizationHandler.CreateDepartment)
+		organizationManage.GET("/departments", organizationHandler.ListDepartments)
+		organizationManage.GET("/departments/:id", organizationHandler.GetDepartment)
+		organizationManage.PUT("/departments/:id", organizationHandler.UpdateDepartment)
+		organizationManage.DELETE("/departments/:id", organizationHandler.DeleteDepartment)
+		organizationManage.POST("/teams", organizationHandler.CreateTeam)
+		organizationManage.GET("/teams", organizationHandler.ListTeams)
+		organizationManage.GET("/teams/:id", organizationHandler.GetTeam)
+		organizationManage.PUT("/teams/:id", organizationHandler.UpdateTeam)
+		organizationManage.DELETE("/teams/:id", organizationHandler.DeleteTeam)
+		organizationManage.PUT("/users/:id/placement", organizationHandler.SetPlacement)
+	}
+
+	attendanceRead := admin.Group("", authMiddleware.RequirePermission(domain.PermAttendanceReadAll))
+	{
+		attendanceRead.GET("/attendance", attendanceHandler.ListAttendance)
+		attendanceRead.GET("/users/:id/attendance", attendanceHandler.ListUserAttendance)
+		attendanceRead.GET("/attendance/:id/history", correctionHandler.GetAttendanceHistory)
+	}
+
+	reportsRead := admin.Group("", authMiddleware.RequirePermission(domain.PermReportsRead))
+	{
+		reportsRead.GET("/reports/monthly", reportHandler.GetCompanyMonthlyReport)
+		reportsRead.GET("/reports/monthly/teams/:id", reportHandler.GetTeamMonthlyReport)
+		reportsRead.GET("/reports/timesheets", reportHandler.ExportTimesheets)
+	}
 
-		// Attendance routes
-		protected.POST("/attendance", attendanceHandler.MarkAttendance)
-		protected.GET("/attendance", attendanceHandler.GetAttendance)
-		protected.GET("/attendance/user", attendanceHandler.GetUserAttendance)
+	payrollExport := admin.Group("", authMiddleware.RequirePermission(domain.PermPayrollExport))
+	{
+		payrollExport.POST("/payroll/exports", payrollHandler.ExportPayroll)
+		payrollExport.GET("/payroll/exports", payrollHandler.ListExports)
+	}
+
+	attendanceWrite := admin.Group("", authMiddleware.RequirePermission(domain.PermAttendanceWriteAll))
+	{
+		attendanceWrite.POST("/attendance", attendanceHandler.CreateAttendance)
+		attendanceWrite.PUT("/attendance/:id", attendanceHandler.UpdateAttendance)
+		attendanceWrite.DELETE("/attendance/:id", attendanceHandler.DeleteAttendance)
+		attendanceWrite.POST("/attendance/absences", attendanceHandler.BackfillAbsences)
+	}
+
+	corrections := admin.Group("", authMiddleware.RequirePermission(domain.PermCorrectionsApprove))
+	{
+		corrections.GET("/corrections", correctionHandler.ListCorrections)
+		corrections.POST("/corrections/:id/approve", correctionHandler.ApproveCorrection)
+		corrections.POST("/corrections/:id/reject", correctionHandler.RejectCorrection)
+	}
+
+	schedules := admin.Group("", authMiddleware.RequirePermission(domain.PermSchedulesManage))
+	{
+		schedules.POST("/schedules", scheduleHandler.CreateSchedule)
+		schedules.GET("/schedules", scheduleHandler.ListSchedules)
+		schedules.GET("/schedules/:id", scheduleHandler.GetSchedule)
+		schedules.PUT("/schedules/:id", scheduleHandler.UpdateSchedule)
+		schedules.DELETE("/schedules/:id", scheduleHandler.DeleteSchedule)
+		schedules.PUT("/schedules/:id/users", scheduleHandler.AssignUsers)
+		schedules.DELETE("/schedules/users/:user_id", scheduleHandler.UnassignUser)
+
+		schedules.POST("/shifts", shiftHandler.CreateShift)
+		schedules.GET("/shifts", shiftHandler.ListShifts)
+		schedules.GET("/shifts/:id", shiftHandler.GetShift)
+		schedules.PUT("/shifts/:id", shiftHandler.UpdateShift)
+		schedules.DELETE("/shifts/:id", shiftHandler.DeleteShift)
+		schedules.POST("/roster", shiftHandler.RosterUsers)
+		schedules.GET("/roster", shiftHandler.GetRoster)
+		schedules.DELETE("/roster/:id", shiftHandler.DeleteRosterEntry)
+	}
+
+	leavesApprove := admin.Group("", authMiddleware.RequirePermission(domain.PermLeavesApprove))
+	{
+		leavesApprove.GET("/leaves", leaveHandler.ListLeaves)
+		leavesApprove.POST("/leaves/:id/approve", leaveHandler.ApproveLeave)
+		leavesApprove.POST("/leaves/:id/reject", leaveHandler.RejectLeave)
+		leavesApprove.GET("/leaves/balances/:user_id", leaveHandler.GetUserBalances)
+	}
+
+	leavesManage := admin.Group("", authMiddleware.RequirePermission(domain.PermLeavesManage))
+	{
+		leavesManage.PUT("/leaves/balances/:user_id", leaveHandler.SetEntitlement)
+	}
+
+	holidays := admin.Group("", authMiddleware.RequirePermission(domain.PermHolidaysManage))
+	{
+		holidays.POST("/holidays", holidayHandler.CreateHoliday)
+		holidays.GET("/holidays", holidayHandler.ListHolidays)
+		holidays.POST("/holidays/import", holidayHandler.ImportHolidays)
+		holidays.GET("/holidays/:id", holidayHandler.GetHoliday)
+		holidays.PUT("/holidays/:id", holidayHandler.UpdateHoliday)
+		holidays.DELETE("/holidays/:id", holidayHandler.DeleteHoliday)
 	}
 }
@@ -2,6 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +14,66 @@ type Config struct {
 	DBSource      string
 	ServerAddress string
 	JWTSecret     string
+
+	// Token lifetimes
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	ResetTokenTTL   time.Duration
+	VerifyTokenTTL  time.Duration
+	MFATokenTTL     time.Duration
+
+	// MFAIssuer names the account in authenticator apps
+	MFAIssuer string
+
+	// Login lockout. After the allowed failures per account or client IP,
+	// logins are locked for LoginLockoutBase, doubling up to LoginLockoutMax.
+	LoginMaxFailures   int
+	LoginIPMaxFailures int
+	LoginLockoutBase   time.Duration
+	LoginLockoutMax    time.Duration
+	LoginFailureWindow time.Duration
+
+	// TrustedProxies may set X-Forwarded-For; the client IP is taken from
+	// the connection otherwise
+	TrustedProxies []string
+
+	// AppURL is the frontend base URL used in emailed links
+	AppURL string
+
+	// Mail delivery. MailDriver is "log", "file" or "smtp".
+	MailDriver   string
+	MailFrom     string
+	MailFilePath string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// Onboarding. Public registration always creates regular users; the
+	// bootstrap admin is created at startup when no admin exists yet.
+	RegistrationEnabled    bool
+	BootstrapAdminName     string
+	BootstrapAdminEmail    string
+	BootstrapAdminPassword string
+
+	// Absence job
+	AbsenceJobEnabled  bool
+	AbsenceCutoff      string
+	AbsenceJobInterval time.Duration
+
+	// Default yearly leave entitlements in days
+	AnnualLeaveDays int
+	SickLeaveDays   int
+
+	// Worked time after which work counts as overtime; zero disables a threshold
+	OvertimeDailyThreshold  time.Duration
+	OvertimeWeeklyThreshold time.Duration
+
+	// BreakRules lists the mandatory breaks as comma-separated after:minimum durations
+	BreakRules string
+
+	// PayrollColumns maps payroll fields to the columns of payroll exports
+	PayrollColumns string
 }
 
 func LoadConfig() (*Config, error) {
@@ -19,11 +82,147 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	accessTokenTTL, err := time.ParseDuration(getEnv("ACCESS_TOKEN_TTL", "15m"))
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenTTL, err := time.ParseDuration(getEnv("REFRESH_TOKEN_TTL", "720h"))
+	if err != nil {
+		return nil, err
+	}
+
+	resetTokenTTL, err := time.ParseDuration(getEnv("RESET_TOKEN_TTL", "1h"))
+	if err != nil {
+		return nil, err
+	}
+	verifyTokenTTL, err := time.ParseDuration(getEnv("VERIFY_TOKEN_TTL", "48h"))
+	if err != nil {
+		return nil, err
+	}
+	mfaTokenTTL, err := time.ParseDuration(getEnv("MFA_TOKEN_TTL", "5m"))
+	if err != nil {
+		return nil, err
+	}
+	loginMaxFailures, err := strconv.Atoi(getEnv("LOGIN_MAX_FAILURES", "5"))
+	if err != nil {
+		return nil, err
+	}
+	loginIPMaxFailures, err := strconv.Atoi(getEnv("LOGIN_IP_MAX_FAILURES", "20"))
+	if err != nil {
+		return nil, err
+	}
+	loginLockoutBase, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_BASE", "1m"))
+	if err != nil {
+		return nil, err
+	}
+	loginLockoutMax, err := time.ParseDuration(getEnv("LOGIN_LOCKOUT_MAX", "1h"))
+	if err != nil {
+		return nil, err
+	}
+	loginFailureWindow, err := time.ParseDuration(getEnv("LOGIN_FAILURE_WINDOW", "24h"))
+	if err != nil {
+		return nil, err
+	}
+
+	var trustedProxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			trustedProxies = append(trustedProxies, proxy)
+		}
+	}
+
+	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	if err != nil {
+		return nil, err
+	}
+
+	registrationEnabled, err := strconv.ParseBool(getEnv("REGISTRATION_ENABLED", "true"))
+	if err != nil {
+		return nil, err
+	}
+
+	absenceJobEnabled, err := strconv.ParseBool(getEnv("ABSENCE_JOB_ENABLED", "true"))
+	if err != nil {
+		return nil, err
+	}
+	absenceJobInterval, err := time.ParseDuration(getEnv("ABSENCE_JOB_INTERVAL", "5m"))
+	if err != nil {
+		return nil, err
+	}
+
+	annualLeaveDays, err := strconv.Atoi(getEnv("LEAVE_ANNUAL_DAYS", "12"))
+	if err != nil {
+		return nil, err
+	}
+	sickLeaveDays, err := strconv.Atoi(getEnv("LEAVE_SICK_DAYS", "12"))
+	if err != nil {
+		return nil, err
+	}
+
+	overtimeDailyThreshold, err := time.ParseDuration(getEnv("OVERTIME_DAILY_THRESHOLD", "8h"))
+	if err != nil {
+		return nil, err
+	}
+	overtimeWeeklyThreshold, err := time.ParseDuration(getEnv("OVERTIME_WEEKLY_THRESHOLD", "40h"))
+	if err != nil {
+		return nil, err
+	}
+
+	absenceCutoff := getEnv("ABSENCE_CUTOFF", "18:00")
+	if _, err := time.Parse("15:04", absenceCutoff); err != nil {
+		return nil, err
+	}
+
 	config := &Config{
 		DBDriver:      getEnv("DB_DRIVER", "mysql"),
 		DBSource:      getEnv("DB_SOURCE", "root:password@tcp(localhost:3306)/attendance_db?parseTime=true"),
 		ServerAddress: getEnv("SERVER_ADDRESS", ":8080"),
 		JWTSecret:     getEnv("JWT_SECRET", "your-secret-key"),
+
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+		ResetTokenTTL:   resetTokenTTL,
+		VerifyTokenTTL:  verifyTokenTTL,
+		MFATokenTTL:     mfaTokenTTL,
+
+		MFAIssuer: getEnv("MFA_ISSUER", "Attendance"),
+
+		LoginMaxFailures:   loginMaxFailures,
+		LoginIPMaxFailures: loginIPMaxFailures,
+		LoginLockoutBase:   loginLockoutBase,
+		LoginLockoutMax:    loginLockoutMax,
+		LoginFailureWindow: loginFailureWindow,
+
+		TrustedProxies: trustedProxies,
+
+		AppURL: getEnv("APP_URL", "http://localhost:3000"),
+
+		MailDriver:   getEnv("MAIL_DRIVER", "log"),
+		MailFrom:     getEnv("MAIL_FROM", "no-reply@attendance.local"),
+		MailFilePath: getEnv("MAIL_FILE_PATH", "mail.log"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     smtpPort,
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+
+		RegistrationEnabled:    registrationEnabled,
+		BootstrapAdminName:     getEnv("BOOTSTRAP_ADMIN_NAME", "Administrator"),
+		BootstrapAdminEmail:    os.Getenv("BOOTSTRAP_ADMIN_EMAIL"),
+		BootstrapAdminPassword: os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"),
+
+		AbsenceJobEnabled:  absenceJobEnabled,
+		AbsenceCutoff:      absenceCutoff,
+		AbsenceJobInterval: absenceJobInterval,
+
+		AnnualLeaveDays: annualLeaveDays,
+		SickLeaveDays:   sickLeaveDays,
+
+		OvertimeDailyThreshold:  overtimeDailyThreshold,
+		OvertimeWeeklyThreshold: overtimeWeeklyThreshold,
+
+		BreakRules: os.Getenv("BREAK_RULES"),
+
+		PayrollColumns: os.Getenv("PAYROLL_COLUMNS"),
 	}
 
 	return config, nil
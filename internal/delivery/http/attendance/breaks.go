@@ -0,0 +1,170 @@
+package attendance
+
+import (
+	"net/http"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type startBreakRequest struct {
+	Type string `json:"type" binding:"required" example:"lunch"` // lunch, rest or other
+}
+
+type addBreakRequest struct {
+	Type      string    `json:"type" binding:"required" example:"lunch"` // lunch, rest or other
+	StartTime time.Time `json:"start_time" binding:"required" example:"2024-07-01T12:00:00+07:00"`
+	EndTime   time.Time `json:"end_time" binding:"required" example:"2024-07-01T12:30:00+07:00"`
+}
+
+// isBreakError reports whether err rejects the break
+func isBreakError(err error) bool {
+	return err == domain.ErrInvalidBreakType || err == domain.ErrInvalidBreakTimes || err == domain.ErrBreakOutsideWorkday
+}
+
+// StartBreak godoc
+// @Summary Start a break
+// @Description Start a break of the authenticated user's current workday. A break still in progress ends at clock-out.
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body startBreakRequest true "Break type"
+// @Success 201 {object} utils.Response{data=domain.AttendanceBreak} "Break started successfully"
+// @Failure 400 {object} utils.Response "Invalid request or break type, or not clocked in"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Already clocked out or a break is already in progress"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/breaks/start [post]
+func (h *AttendanceHandler) StartBreak(c *gin.Context) {
+	var req startBreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendanceBreak, err := h.attendanceUsecase.StartBreak(c.Request.Context(), c.GetString("user_id"), req.Type)
+	if err == domain.ErrInvalidBreakType || err == domain.ErrNotClockedIn {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to start break", err.Error())
+		return
+	}
+	if err == domain.ErrAlreadyClockedOut || err == domain.ErrBreakInProgress {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to start break", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to start break", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Break started successfully", attendanceBreak)
+}
+
+// EndBreak godoc
+// @Summary End a break
+// @Description End the break in progress of the authenticated user's current workday
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.AttendanceBreak} "Break ended successfully"
+// @Failure 400 {object} utils.Response "Not clocked in"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Already clocked out or no break in progress"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/breaks/end [post]
+func (h *AttendanceHandler) EndBreak(c *gin.Context) {
+	attendanceBreak, err := h.attendanceUsecase.EndBreak(c.Request.Context(), c.GetString("user_id"))
+	if err == domain.ErrNotClockedIn {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to end break", err.Error())
+		return
+	}
+	if err == domain.ErrAlreadyClockedOut || err == domain.ErrNoBreakInProgress {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to end break", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to end break", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Break ended successfully", attendanceBreak)
+}
+
+// AddBreak godoc
+// @Summary Record a break
+// @Description Record a finished break on one of the authenticated user's attendance records. It must fall between clock-in and clock-out and not overlap another break; the worked time, break rule check and overtime are recomputed.
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param request body addBreakRequest true "Break"
+// @Success 201 {object} utils.Response{data=domain.Attendance} "Break recorded successfully"
+// @Failure 400 {object} utils.Response "Invalid request, break type or times, or break outside the workday"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 409 {object} utils.Response "Break overlaps another break"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/breaks [post]
+func (h *AttendanceHandler) AddBreak(c *gin.Context) {
+	var req addBreakRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendance, err := h.attendanceUsecase.AddBreak(c.Request.Context(), c.GetString("user_id"), &domain.AttendanceBreak{
+		AttendanceID: c.Param("id"),
+		Type:         req.Type,
+		StartTime:    req.StartTime,
+		EndTime:      &req.EndTime,
+	})
+	if isBreakError(err) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to record break", err.Error())
+		return
+	}
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to record break", err.Error())
+		return
+	}
+	if err == domain.ErrBreakOverlap {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to record break", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to record break", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Break recorded successfully", attendance)
+}
+
+// DeleteBreak godoc
+// @Summary Delete a break
+// @Description Delete a break of one of the authenticated user's attendance records and recompute the worked time, break rule check and overtime
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param break_id path string true "Break ID"
+// @Success 200 {object} utils.Response{data=domain.Attendance} "Break deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Attendance or break not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/breaks/{break_id} [delete]
+func (h *AttendanceHandler) DeleteBreak(c *gin.Context) {
+	attendance, err := h.attendanceUsecase.DeleteBreak(c.Request.Context(), c.GetString("user_id"), c.Param("id"), c.Param("break_id"))
+	if err == domain.ErrAttendanceNotFound || err == domain.ErrBreakNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete break", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete break", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Break deleted successfully", attendance)
+}
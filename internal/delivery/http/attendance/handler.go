@@ -1,12 +1,12 @@
 package attendance
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"golang-tes/internal/domain"
 	"golang-tes/internal/utils"
-	"golang-tes/internal/utils/validator"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,26 +21,92 @@ func NewAttendanceHandler(attendanceUsecase domain.AttendanceUsecase) *Attendanc
 	}
 }
 
-type markAttendanceRequest struct {
-	Status string `json:"status" binding:"omitempty,oneof=present absent late"`
+// attendanceQuery filters, sorts and pages the attendance listings
+type attendanceQuery struct {
+	Date           string `form:"date"`
+	From           string `form:"from"`
+	To             string `form:"to"`
+	Status         string `form:"status"`
+	OvertimeStatus string `form:"overtime_status"`
+	BreakViolation bool   `form:"break_violation"`
+	Sort           string `form:"sort"`
+	Page           int    `form:"page" binding:"omitempty,min=1"`
+	PageSize       int    `form:"page_size" binding:"omitempty,min=1"`
 }
 
-type getAttendanceRequest struct {
-	Date string `form:"date" binding:"required" time_format:"2006-01-02"`
+// toFilter parses the dates of the query. A single date selects that day.
+func (q *attendanceQuery) toFilter() (domain.AttendanceFilter, error) {
+	filter := domain.AttendanceFilter{
+		Status:         q.Status,
+		OvertimeStatus: q.OvertimeStatus,
+		BreakViolation: q.BreakViolation,
+		Sort:           q.Sort,
+		Page:           q.Page,
+		PageSize:       q.PageSize,
+	}
+	from, to := q.From, q.To
+	if q.Date != "" {
+		from, to = q.Date, q.Date
+	}
+	var err error
+	if from != "" {
+		if filter.From, err = time.Parse(domain.DateFormat, from); err != nil {
+			return filter, err
+		}
+	}
+	if to != "" {
+		if filter.To, err = time.Parse(domain.DateFormat, to); err != nil {
+			return filter, err
+		}
+	}
+	filter.Normalize()
+	return filter, nil
+}
+
+// isFilterError reports whether err rejects the listing's filter
+func isFilterError(err error) bool {
+	return err == domain.ErrInvalidAttendanceStatus || err == domain.ErrInvalidOvertimeStatus ||
+		err == domain.ErrInvalidAttendanceSort || err == domain.ErrInvalidDateRange
+}
+
+type reviewOvertimeRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type listAttendanceRequest struct {
+	From string `form:"from" binding:"required"`
+	To   string `form:"to" binding:"required"`
+}
+
+type createAttendanceRequest struct {
+	UserID   string     `json:"user_id" binding:"required"`
+	Date     string     `json:"date" binding:"required" example:"2024-07-01"`
+	Status   string     `json:"status" binding:"required,oneof=present absent late leave"`
+	ClockIn  *time.Time `json:"clock_in" example:"2024-07-01T09:00:00+07:00"`
+	ClockOut *time.Time `json:"clock_out" example:"2024-07-01T17:00:00+07:00"`
+}
+
+type updateAttendanceRequest struct {
+	Status   string     `json:"status" binding:"required,oneof=present absent late leave"`
+	ClockIn  *time.Time `json:"clock_in" example:"2024-07-01T09:00:00+07:00"`
+	ClockOut *time.Time `json:"clock_out" example:"2024-07-01T17:00:00+07:00"`
+}
+
+type backfillAbsencesRequest struct {
+	From string `json:"from" binding:"required" example:"2024-01-01"`
+	To   string `json:"to" binding:"required" example:"2024-01-31"`
 }
 
 // MarkAttendance godoc
 // @Summary Mark attendance
-// @Description Mark attendance for the authenticated user
+// @Description Mark attendance for the authenticated user. The status (present or late) is derived from the rostered shift the user arrives for or, without one, from the user's work schedule.
 // @Tags attendance
-// @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body markAttendanceRequest true "Attendance status"
-// @Success 201 {object} utils.Response "Attendance marked successfully"
-// @Failure 400 {object} utils.Response "Invalid request"
+// @Success 201 {object} utils.Response{data=domain.Attendance} "Attendance marked successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 409 {object} utils.Response "Attendance already marked"
+// @Failure 403 {object} utils.Response "Email address is not verified"
+// @Failure 409 {object} utils.Response "Attendance already marked or today is a holiday"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /attendance [post]
 func (h *AttendanceHandler) MarkAttendance(c *gin.Context) {
@@ -50,78 +116,165 @@ func (h *AttendanceHandler) MarkAttendance(c *gin.Context) {
 		return
 	}
 
-	var req markAttendanceRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
-		return
-	}
-
-	if err := validator.ValidateAttendanceStatus(req.Status); err != nil {
-		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid attendance status", err.Error())
-		return
-	}
-
 	attendance := &domain.Attendance{
 		UserID: userID,
 		Date:   time.Now(),
-		Status: req.Status,
 	}
 
 	err := h.attendanceUsecase.MarkAttendance(c.Request.Context(), attendance)
-	if err == domain.ErrAttendanceAlreadyMarked {
+	if err == domain.ErrAttendanceAlreadyMarked || err == domain.ErrHoliday {
 		utils.ErrorResponse(c, http.StatusConflict, "Failed to mark attendance", err.Error())
 		return
 	}
+	if err == domain.ErrEmailNotVerified {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to mark attendance", err.Error())
+		return
+	}
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to mark attendance", err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusCreated, "Attendance marked successfully", nil)
+	utils.SuccessResponse(c, http.StatusCreated, "Attendance marked successfully", attendance)
+}
+
+// ClockIn godoc
+// @Summary Clock in
+// @Description Record the clock-in time of the authenticated user for today or, from two hours before a rostered shift until its end, for the shift
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} utils.Response{data=domain.Attendance} "Clocked in successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Email address is not verified"
+// @Failure 409 {object} utils.Response "Already clocked in, or today is a holiday or a day of leave"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/clock-in [post]
+func (h *AttendanceHandler) ClockIn(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	attendance, err := h.attendanceUsecase.ClockIn(c.Request.Context(), userID)
+	if err == domain.ErrAlreadyClockedIn || err == domain.ErrHoliday || err == domain.ErrOnLeave {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to clock in", err.Error())
+		return
+	}
+	if err == domain.ErrEmailNotVerified {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to clock in", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to clock in", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Clocked in successfully", attendance)
+}
+
+// ClockOut godoc
+// @Summary Clock out
+// @Description Record the clock-out time of the authenticated user for today, or for an overnight shift begun yesterday, and compute the worked duration
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.Attendance} "Clocked out successfully"
+// @Failure 400 {object} utils.Response "Not clocked in"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Already clocked out"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/clock-out [post]
+func (h *AttendanceHandler) ClockOut(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	attendance, err := h.attendanceUsecase.ClockOut(c.Request.Context(), userID)
+	if err == domain.ErrNotClockedIn {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to clock out", err.Error())
+		return
+	}
+	if err == domain.ErrAlreadyClockedOut {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to clock out", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to clock out", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Clocked out successfully", attendance)
 }
 
 // GetAttendance godoc
-// @Summary Get all attendance records by date
-// @Description Get attendance records for all users on a specific date
+// @Summary List attendance records
+// @Description List a page of the attendance records of the users the caller may see: their own and their reports', or everyone's with attendance:read:all
 // @Tags attendance
 // @Produce json
 // @Security BearerAuth
-// @Param date query string true "Date in YYYY-MM-DD format" Format(date)
-// @Success 200 {object} utils.Response{data=[]domain.Attendance} "Attendance records retrieved successfully"
-// @Failure 400 {object} utils.Response "Invalid request"
+// @Param date query string false "Single date in YYYY-MM-DD format, instead of from and to" Format(date)
+// @Param from query string false "Start date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param to query string false "End date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param status query string false "Status filter" Enums(present, absent, late, leave)
+// @Param overtime_status query string false "Overtime review status filter" Enums(pending, approved, rejected)
+// @Param break_violation query bool false "Only records short of the mandatory break rules"
+// @Param sort query string false "Sort field: date, status, clock_in or worked_minutes, prefixed with - for descending order (default -date)"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Records per page (default 20, max 100)"
+// @Success 200 {object} utils.Response{data=[]domain.Attendance,meta=utils.Pagination} "Attendance records retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request, date range, status, overtime status or sort field"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /attendance [get]
 func (h *AttendanceHandler) GetAttendance(c *gin.Context) {
-	var req getAttendanceRequest
+	var req attendanceQuery
 	if err := c.ShouldBindQuery(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
 		return
 	}
-
-	date, err := time.Parse(domain.DateFormat, req.Date)
+	filter, err := req.toFilter()
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
 		return
 	}
 
-	attendances, err := h.attendanceUsecase.GetAttendanceByDate(c.Request.Context(), date)
+	attendances, total, err := h.attendanceUsecase.ListAttendance(c.Request.Context(), c.GetString("user_id"), filter)
+	if isFilterError(err) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get attendance records", err.Error())
+		return
+	}
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get attendance records", err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Attendance records retrieved successfully", attendances)
+	utils.PaginatedResponse(c, http.StatusOK, "Attendance records retrieved successfully", attendances, utils.NewPagination(filter.Page, filter.PageSize, total))
 }
 
 // GetUserAttendance godoc
-// @Summary Get user attendance records
-// @Description Get all attendance records for the authenticated user
+// @Summary List user attendance records
+// @Description List a page of the attendance records of the authenticated user, or with user_id of one of their direct or indirect reports, with their breaks
 // @Tags attendance
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} utils.Response{data=[]domain.Attendance} "User attendance records retrieved successfully"
+// @Param user_id query string false "User ID, defaults to the authenticated user"
+// @Param from query string false "Start date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param to query string false "End date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param status query string false "Status filter" Enums(present, absent, late, leave)
+// @Param overtime_status query string false "Overtime review status filter" Enums(pending, approved, rejected)
+// @Param break_violation query bool false "Only records short of the mandatory break rules"
+// @Param sort query string false "Sort field: date, status, clock_in or worked_minutes, prefixed with - for descending order (default -date)"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Records per page (default 20, max 100)"
+// @Success 200 {object} utils.Response{data=[]domain.Attendance,meta=utils.Pagination} "User attendance records retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request, date range, status, overtime status or sort field"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
 // @Failure 404 {object} utils.Response "User not found"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /attendance/user [get]
@@ -132,7 +285,456 @@ func (h *AttendanceHandler) GetUserAttendance(c *gin.Context) {
 		return
 	}
 
-	attendances, err := h.attendanceUsecase.GetUserAttendance(c.Request.Context(), userID)
+	var req attendanceQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	filter, err := req.toFilter()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	targetID := userID
+	if value := c.Query("user_id"); value != "" {
+		targetID = value
+	}
+
+	attendances, total, err := h.attendanceUsecase.GetUserAttendance(c.Request.Context(), userID, targetID, filter)
+	if isFilterError(err) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get user attendance records", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to get user attendance records", err.Error())
+		return
+	}
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get user attendance records", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get user attendance records", err.Error())
+		return
+	}
+
+	utils.PaginatedResponse(c, http.StatusOK, "User attendance records retrieved successfully", attendances, utils.NewPagination(filter.Page, filter.PageSize, total))
+}
+
+// ExportAttendance godoc
+// @Summary Export attendance records
+// @Description Download the attendance records of the users the caller may see, with their names and emails, as CSV or XLSX. Takes the filters of GET /attendance; all matching records are exported.
+// @Tags attendance
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "File format (default csv)" Enums(csv, xlsx)
+// @Param date query string false "Single date in YYYY-MM-DD format, instead of from and to" Format(date)
+// @Param from query string false "Start date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param to query string false "End date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param status query string false "Status filter" Enums(present, absent, late, leave)
+// @Param overtime_status query string false "Overtime review status filter" Enums(pending, approved, rejected)
+// @Param break_violation query bool false "Only records short of the mandatory break rules"
+// @Param sort query string false "Sort field: date, status, clock_in or worked_minutes, prefixed with - for descending order (default -date)"
+// @Success 200 {file} file "Attendance export"
+// @Failure 400 {object} utils.Response "Invalid request, format, date range, status, overtime status or sort field"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/export [get]
+func (h *AttendanceHandler) ExportAttendance(c *gin.Context) {
+	var req attendanceQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	filter, err := req.toFilter()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	export, err := utils.NewTableExport(c, c.DefaultQuery("format", domain.ExportFormatCSV), "attendance",
+		"date", "user_id", "name", "email", "status", "clock_in", "clock_out", "worked_minutes", "break_minutes", "missing_break_minutes", "overtime_minutes", "overtime_status")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to export attendance records", err.Error())
+		return
+	}
+
+	err = h.attendanceUsecase.ExportAttendance(c.Request.Context(), c.GetString("user_id"), filter, func(row domain.AttendanceExportRow) error {
+		return export.WriteRow(
+			row.Date.Format(domain.DateFormat),
+			row.UserID,
+			row.UserName,
+			row.UserEmail,
+			row.Status,
+			formatClockTime(row.ClockIn),
+			formatClockTime(row.ClockOut),
+			row.WorkedMinutes,
+			row.BreakMinutes,
+			row.MissingBreakMinutes,
+			row.OvertimeMinutes,
+			row.OvertimeStatus,
+		)
+	})
+	if err == nil {
+		err = export.Close()
+	}
+	if export.Interrupted(err) {
+		return
+	}
+	if isFilterError(err) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to export attendance records", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export attendance records", err.Error())
+		return
+	}
+}
+
+// ApproveOvertime godoc
+// @Summary Approve overtime
+// @Description Approve the pending overtime of an attendance record of one of the caller's direct or indirect reports, or of anyone with overtime:approve. Only approved overtime is paid.
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param request body reviewOvertimeRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.Attendance} "Overtime approved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own overtime"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 409 {object} utils.Response "No overtime pending review"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/overtime/approve [post]
+func (h *AttendanceHandler) ApproveOvertime(c *gin.Context) {
+	h.reviewOvertime(c, h.attendanceUsecase.ApproveOvertime, "Overtime approved successfully", "Failed to approve overtime")
+}
+
+// RejectOvertime godoc
+// @Summary Reject overtime
+// @Description Reject the pending overtime of an attendance record of one of the caller's direct or indirect reports, or of anyone with overtime:approve
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param request body reviewOvertimeRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.Attendance} "Overtime rejected successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own overtime"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 409 {object} utils.Response "No overtime pending review"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/overtime/reject [post]
+func (h *AttendanceHandler) RejectOvertime(c *gin.Context) {
+	h.reviewOvertime(c, h.attendanceUsecase.RejectOvertime, "Overtime rejected successfully", "Failed to reject overtime")
+}
+
+func (h *AttendanceHandler) reviewOvertime(c *gin.Context, review func(ctx context.Context, reviewerID, id, note string) (*domain.Attendance, error), successMessage, failureMessage string) {
+	var req reviewOvertimeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+			return
+		}
+	}
+
+	attendance, err := review(c.Request.Context(), c.GetString("user_id"), c.Param("id"), req.Note)
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied || err == domain.ErrOwnOvertime {
+		utils.ErrorResponse(c, http.StatusForbidden, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrOvertimeNotPending {
+		utils.ErrorResponse(c, http.StatusConflict, failureMessage, err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, failureMessage, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, successMessage, attendance)
+}
+
+// formatClockTime formats an optional clock time for exports, nil when unset
+func formatClockTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(domain.DateTimeFormat)
+}
+
+// BackfillAbsences godoc
+// @Summary Backfill absences
+// @Description Mark users without an attendance record as absent for every working day in a date range (requires attendance:write:all)
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body backfillAbsencesRequest true "Date range in YYYY-MM-DD format"
+// @Success 200 {object} utils.Response{data=map[string]int{marked=int}} "Absences marked successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance/absences [post]
+func (h *AttendanceHandler) BackfillAbsences(c *gin.Context) {
+	var req backfillAbsencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	from, err := time.Parse(domain.DateFormat, req.From)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	to, err := time.Parse(domain.DateFormat, req.To)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	marked, err := h.attendanceUsecase.BackfillAbsences(c.Request.Context(), from, to)
+	if err == domain.ErrInvalidDateRange {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to backfill absences", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to backfill absences", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Absences marked successfully", gin.H{"marked": marked})
+}
+
+// CreateAttendance godoc
+// @Summary Create an attendance record
+// @Description Create an attendance record for any user and date (requires attendance:write:all)
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createAttendanceRequest true "Attendance details"
+// @Success 201 {object} utils.Response{data=domain.Attendance} "Attendance created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 409 {object} utils.Response "Attendance already exists for the date"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance [post]
+func (h *AttendanceHandler) CreateAttendance(c *gin.Context) {
+	var req createAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	date, err := time.Parse(domain.DateFormat, req.Date)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendance := &domain.Attendance{
+		UserID:   req.UserID,
+		Date:     date,
+		Status:   req.Status,
+		ClockIn:  req.ClockIn,
+		ClockOut: req.ClockOut,
+	}
+
+	err = h.attendanceUsecase.CreateAttendance(c.Request.Context(), attendance)
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to create attendance", err.Error())
+		return
+	}
+	if err == domain.ErrInvalidAttendanceStatus || err == domain.ErrInvalidClockTimes {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create attendance", err.Error())
+		return
+	}
+	if err == domain.ErrAttendanceAlreadyMarked {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create attendance", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create attendance", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Attendance created successfully", attendance)
+}
+
+// UpdateAttendance godoc
+// @Summary Update an attendance record
+// @Description Replace the status and clock times of any attendance record. The previous values are kept in the record's history (requires attendance:write:all)
+// @Tags attendance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param request body updateAttendanceRequest true "Attendance details"
+// @Success 200 {object} utils.Response{data=domain.Attendance} "Attendance updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance/{id} [put]
+func (h *AttendanceHandler) UpdateAttendance(c *gin.Context) {
+	var req updateAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendance := &domain.Attendance{
+		ID:       c.Param("id"),
+		Status:   req.Status,
+		ClockIn:  req.ClockIn,
+		ClockOut: req.ClockOut,
+	}
+
+	err := h.attendanceUsecase.UpdateAttendance(c.Request.Context(), attendance, c.GetString("user_id"))
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update attendance", err.Error())
+		return
+	}
+	if err == domain.ErrInvalidAttendanceStatus || err == domain.ErrInvalidClockTimes {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update attendance", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update attendance", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attendance updated successfully", attendance)
+}
+
+// DeleteAttendance godoc
+// @Summary Delete an attendance record
+// @Description Delete any attendance record. Its last values are kept in the attendance history (requires attendance:write:all)
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} utils.Response "Attendance deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance/{id} [delete]
+func (h *AttendanceHandler) DeleteAttendance(c *gin.Context) {
+	err := h.attendanceUsecase.DeleteAttendance(c.Request.Context(), c.Param("id"), c.GetString("user_id"))
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete attendance", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete attendance", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attendance deleted successfully", nil)
+}
+
+// ListAttendance godoc
+// @Summary List attendance in a date range
+// @Description List the attendance records of all users between two dates, inclusive (requires attendance:read:all)
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "Start date in YYYY-MM-DD format" Format(date)
+// @Param to query string true "End date in YYYY-MM-DD format" Format(date)
+// @Success 200 {object} utils.Response{data=[]domain.Attendance} "Attendance records retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance [get]
+func (h *AttendanceHandler) ListAttendance(c *gin.Context) {
+	var req listAttendanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	from, err := time.Parse(domain.DateFormat, req.From)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	to, err := time.Parse(domain.DateFormat, req.To)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendances, err := h.attendanceUsecase.ListAttendanceInRange(c.Request.Context(), from, to)
+	if err == domain.ErrInvalidDateRange {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get attendance records", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get attendance records", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attendance records retrieved successfully", attendances)
+}
+
+// ListUserAttendance godoc
+// @Summary List a user's attendance
+// @Description List a page of the attendance records of any user (requires attendance:read:all)
+// @Tags attendance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param from query string false "Start date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param to query string false "End date in YYYY-MM-DD format, inclusive" Format(date)
+// @Param status query string false "Status filter" Enums(present, absent, late, leave)
+// @Param overtime_status query string false "Overtime review status filter" Enums(pending, approved, rejected)
+// @Param break_violation query bool false "Only records short of the mandatory break rules"
+// @Param sort query string false "Sort field: date, status, clock_in or worked_minutes, prefixed with - for descending order (default -date)"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Records per page (default 20, max 100)"
+// @Success 200 {object} utils.Response{data=[]domain.Attendance,meta=utils.Pagination} "User attendance records retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request, date range, status, overtime status or sort field"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/attendance [get]
+func (h *AttendanceHandler) ListUserAttendance(c *gin.Context) {
+	var req attendanceQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	filter, err := req.toFilter()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	attendances, total, err := h.attendanceUsecase.ListUserAttendance(c.Request.Context(), c.Param("id"), filter)
+	if isFilterError(err) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get user attendance records", err.Error())
+		return
+	}
 	if err == domain.ErrUserNotFound {
 		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get user attendance records", err.Error())
 		return
@@ -142,5 +744,5 @@ func (h *AttendanceHandler) GetUserAttendance(c *gin.Context) {
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "User attendance records retrieved successfully", attendances)
+	utils.PaginatedResponse(c, http.StatusOK, "User attendance records retrieved successfully", attendances, utils.NewPagination(filter.Page, filter.PageSize, total))
 }
@@ -0,0 +1,307 @@
+package correction
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CorrectionHandler struct {
+	correctionUsecase domain.AttendanceCorrectionUsecase
+}
+
+func NewCorrectionHandler(correctionUsecase domain.AttendanceCorrectionUsecase) *CorrectionHandler {
+	return &CorrectionHandler{
+		correctionUsecase: correctionUsecase,
+	}
+}
+
+type correctionRequest struct {
+	Status   string     `json:"status" binding:"omitempty,oneof=present absent late"`
+	ClockIn  *time.Time `json:"clock_in" example:"2024-07-01T09:00:00+07:00"`
+	ClockOut *time.Time `json:"clock_out" example:"2024-07-01T17:00:00+07:00"`
+	Reason   string     `json:"reason" binding:"required,max=1000"`
+}
+
+type reviewCorrectionRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// RequestCorrection godoc
+// @Summary Request an attendance correction
+// @Description File a correction request against one of the authenticated user's attendance records. Omitted fields keep their current value
+// @Tags corrections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Param request body correctionRequest true "Proposed values and reason"
+// @Success 201 {object} utils.Response{data=domain.AttendanceCorrection} "Correction requested successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 409 {object} utils.Response "A correction is already pending for this record"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/corrections [post]
+func (h *CorrectionHandler) RequestCorrection(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	var req correctionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	correction := &domain.AttendanceCorrection{
+		AttendanceID:     c.Param("id"),
+		UserID:           userID,
+		ProposedStatus:   req.Status,
+		ProposedClockIn:  req.ClockIn,
+		ProposedClockOut: req.ClockOut,
+		Reason:           req.Reason,
+	}
+
+	err := h.correctionUsecase.RequestCorrection(c.Request.Context(), correction)
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to request correction", err.Error())
+		return
+	}
+	if err == domain.ErrEmptyCorrection || err == domain.ErrInvalidAttendanceStatus || err == domain.ErrInvalidClockTimes {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to request correction", err.Error())
+		return
+	}
+	if err == domain.ErrCorrectionPending {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to request correction", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to request correction", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Correction requested successfully", correction)
+}
+
+// GetMyCorrections godoc
+// @Summary Get my correction requests
+// @Description Get all attendance correction requests of the authenticated user
+// @Tags corrections
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.AttendanceCorrection} "Correction requests retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /corrections [get]
+func (h *CorrectionHandler) GetMyCorrections(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	corrections, err := h.correctionUsecase.GetUserCorrections(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get correction requests", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Correction requests retrieved successfully", corrections)
+}
+
+// CancelCorrection godoc
+// @Summary Cancel a correction request
+// @Description Cancel a pending correction request of the authenticated user
+// @Tags corrections
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Correction request ID"
+// @Success 200 {object} utils.Response "Correction request cancelled successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Correction request not found"
+// @Failure 409 {object} utils.Response "Correction request is not pending"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /corrections/{id}/cancel [post]
+func (h *CorrectionHandler) CancelCorrection(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	err := h.correctionUsecase.CancelCorrection(c.Request.Context(), userID, c.Param("id"))
+	if err == domain.ErrCorrectionNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to cancel correction request", err.Error())
+		return
+	}
+	if err == domain.ErrCorrectionNotPending {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to cancel correction request", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel correction request", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Correction request cancelled successfully", nil)
+}
+
+// GetMyAttendanceHistory godoc
+// @Summary Get the history of my attendance record
+// @Description Get the previous values of one of the authenticated user's attendance records, newest first
+// @Tags corrections
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} utils.Response{data=[]domain.AttendanceHistory} "Attendance history retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /attendance/{id}/history [get]
+func (h *CorrectionHandler) GetMyAttendanceHistory(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	h.getHistory(c, userID)
+}
+
+// ListCorrections godoc
+// @Summary List correction requests by status
+// @Description List attendance correction requests with the given status, pending by default. Requires corrections:approve; approvers without attendance:write:all only see their reports' requests.
+// @Tags corrections
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Correction status" Enums(pending, approved, rejected, cancelled)
+// @Success 200 {object} utils.Response{data=[]domain.AttendanceCorrection} "Correction requests retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/corrections [get]
+func (h *CorrectionHandler) ListCorrections(c *gin.Context) {
+	status := c.DefaultQuery("status", domain.CorrectionStatusPending)
+
+	corrections, err := h.correctionUsecase.GetCorrectionsByStatus(c.Request.Context(), c.GetString("user_id"), status)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get correction requests", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Correction requests retrieved successfully", corrections)
+}
+
+// ApproveCorrection godoc
+// @Summary Approve a correction request
+// @Description Apply the proposed values to the attendance record, keeping the previous values in its history. Requires corrections:approve; approvers without attendance:write:all may only review their reports' requests, and nobody their own.
+// @Tags corrections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Correction request ID"
+// @Param request body reviewCorrectionRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.AttendanceCorrection} "Correction request approved successfully"
+// @Failure 400 {object} utils.Response "Proposed times are no longer valid"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own correction request"
+// @Failure 404 {object} utils.Response "Correction request not found"
+// @Failure 409 {object} utils.Response "Correction request is not pending"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/corrections/{id}/approve [post]
+func (h *CorrectionHandler) ApproveCorrection(c *gin.Context) {
+	h.reviewCorrection(c, h.correctionUsecase.ApproveCorrection, "Correction request approved successfully", "Failed to approve correction request")
+}
+
+// RejectCorrection godoc
+// @Summary Reject a correction request
+// @Description Reject a pending correction request. Requires corrections:approve; approvers without attendance:write:all may only review their reports' requests, and nobody their own.
+// @Tags corrections
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Correction request ID"
+// @Param request body reviewCorrectionRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.AttendanceCorrection} "Correction request rejected successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own correction request"
+// @Failure 404 {object} utils.Response "Correction request not found"
+// @Failure 409 {object} utils.Response "Correction request is not pending"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/corrections/{id}/reject [post]
+func (h *CorrectionHandler) RejectCorrection(c *gin.Context) {
+	h.reviewCorrection(c, h.correctionUsecase.RejectCorrection, "Correction request rejected successfully", "Failed to reject correction request")
+}
+
+// GetAttendanceHistory godoc
+// @Summary Get the history of an attendance record
+// @Description Get the previous values of any attendance record, newest first (requires attendance:read:all)
+// @Tags corrections
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Attendance ID"
+// @Success 200 {object} utils.Response{data=[]domain.AttendanceHistory} "Attendance history retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Attendance not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/attendance/{id}/history [get]
+func (h *CorrectionHandler) GetAttendanceHistory(c *gin.Context) {
+	h.getHistory(c, "")
+}
+
+func (h *CorrectionHandler) getHistory(c *gin.Context, ownerID string) {
+	history, err := h.correctionUsecase.GetHistory(c.Request.Context(), c.Param("id"), ownerID)
+	if err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get attendance history", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get attendance history", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Attendance history retrieved successfully", history)
+}
+
+func (h *CorrectionHandler) reviewCorrection(c *gin.Context, review func(ctx context.Context, id, reviewerID, note string) (*domain.AttendanceCorrection, error), successMessage, failureMessage string) {
+	var req reviewCorrectionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+			return
+		}
+	}
+
+	correction, err := review(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req.Note)
+	if err == domain.ErrCorrectionNotFound || err == domain.ErrAttendanceNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied || err == domain.ErrOwnCorrection {
+		utils.ErrorResponse(c, http.StatusForbidden, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrCorrectionNotPending {
+		utils.ErrorResponse(c, http.StatusConflict, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrInvalidClockTimes {
+		utils.ErrorResponse(c, http.StatusBadRequest, failureMessage, err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, failureMessage, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, successMessage, correction)
+}
@@ -0,0 +1,311 @@
+package holiday
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCalendarSize limits the size of an uploaded .ics file
+const maxCalendarSize = 1 << 20
+
+type HolidayHandler struct {
+	holidayUsecase domain.HolidayUsecase
+}
+
+func NewHolidayHandler(holidayUsecase domain.HolidayUsecase) *HolidayHandler {
+	return &HolidayHandler{
+		holidayUsecase: holidayUsecase,
+	}
+}
+
+type holidayRequest struct {
+	Date     string `json:"date" binding:"required" example:"2024-12-25"`
+	Name     string `json:"name" binding:"required,max=255"`
+	Location string `json:"location" binding:"max=100"`
+}
+
+func (r *holidayRequest) toHoliday() (*domain.Holiday, error) {
+	date, err := time.Parse(domain.DateFormat, r.Date)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Holiday{
+		Date:     date,
+		Name:     strings.TrimSpace(r.Name),
+		Location: strings.TrimSpace(r.Location),
+	}, nil
+}
+
+// CreateHoliday godoc
+// @Summary Create a holiday
+// @Description Add a public holiday or company closure day. Leave location empty for a company-wide holiday (requires holidays:manage)
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body holidayRequest true "Holiday details"
+// @Success 201 {object} utils.Response{data=domain.Holiday} "Holiday created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 409 {object} utils.Response "Holiday already exists on this date"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays [post]
+func (h *HolidayHandler) CreateHoliday(c *gin.Context) {
+	var req holidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	holiday, err := req.toHoliday()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	err = h.holidayUsecase.CreateHoliday(c.Request.Context(), holiday)
+	if err == domain.ErrHolidayExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create holiday", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Holiday created successfully", holiday)
+}
+
+// ListHolidays godoc
+// @Summary List holidays
+// @Description List the holidays of a year, optionally only those observed at a location (requires holidays:manage)
+// @Tags holidays
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year, defaults to the current year"
+// @Param location query string false "Location; includes company-wide holidays"
+// @Success 200 {object} utils.Response{data=[]domain.Holiday} "Holidays retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays [get]
+func (h *HolidayHandler) ListHolidays(c *gin.Context) {
+	year, ok := yearQuery(c)
+	if !ok {
+		return
+	}
+
+	holidays, err := h.holidayUsecase.ListHolidays(c.Request.Context(), year, c.Query("location"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get holidays", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays retrieved successfully", holidays)
+}
+
+// GetMyHolidays godoc
+// @Summary Get my holidays
+// @Description List the holidays of a year observed at the authenticated user's location
+// @Tags holidays
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} utils.Response{data=[]domain.Holiday} "Holidays retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /holidays [get]
+func (h *HolidayHandler) GetMyHolidays(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	year, ok := yearQuery(c)
+	if !ok {
+		return
+	}
+
+	holidays, err := h.holidayUsecase.GetUserHolidays(c.Request.Context(), userID, year)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get holidays", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays retrieved successfully", holidays)
+}
+
+// GetHoliday godoc
+// @Summary Get a holiday
+// @Description Get a holiday by ID (requires holidays:manage)
+// @Tags holidays
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID"
+// @Success 200 {object} utils.Response{data=domain.Holiday} "Holiday retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Holiday not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays/{id} [get]
+func (h *HolidayHandler) GetHoliday(c *gin.Context) {
+	holiday, err := h.holidayUsecase.GetHoliday(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrHolidayNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get holiday", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday retrieved successfully", holiday)
+}
+
+// UpdateHoliday godoc
+// @Summary Update a holiday
+// @Description Replace the details of a holiday (requires holidays:manage)
+// @Tags holidays
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID"
+// @Param request body holidayRequest true "Holiday details"
+// @Success 200 {object} utils.Response{data=domain.Holiday} "Holiday updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Holiday not found"
+// @Failure 409 {object} utils.Response "Holiday already exists on this date"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays/{id} [put]
+func (h *HolidayHandler) UpdateHoliday(c *gin.Context) {
+	var req holidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	holiday, err := req.toHoliday()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	holiday.ID = c.Param("id")
+
+	err = h.holidayUsecase.UpdateHoliday(c.Request.Context(), holiday)
+	if err == domain.ErrHolidayNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update holiday", err.Error())
+		return
+	}
+	if err == domain.ErrHolidayExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to update holiday", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday updated successfully", holiday)
+}
+
+// DeleteHoliday godoc
+// @Summary Delete a holiday
+// @Description Delete a holiday (requires holidays:manage)
+// @Tags holidays
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Holiday ID"
+// @Success 200 {object} utils.Response "Holiday deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Holiday not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays/{id} [delete]
+func (h *HolidayHandler) DeleteHoliday(c *gin.Context) {
+	err := h.holidayUsecase.DeleteHoliday(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrHolidayNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete holiday", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete holiday", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holiday deleted successfully", nil)
+}
+
+// ImportHolidays godoc
+// @Summary Import holidays from an iCalendar file
+// @Description Create a holiday for every day covered by the events of an .ics file. Days that already have a holiday at the location are skipped (requires holidays:manage)
+// @Tags holidays
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "iCalendar (.ics) file, at most 1 MB"
+// @Param location formData string false "Location the holidays apply to; empty for company-wide"
+// @Success 200 {object} utils.Response{data=domain.HolidayImportResult} "Holidays imported successfully"
+// @Failure 400 {object} utils.Response "Invalid calendar file"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/holidays/import [post]
+func (h *HolidayHandler) ImportHolidays(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	if header.Size > maxCalendarSize {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Calendar file too large", domain.ErrInvalidCalendar.Error())
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	defer file.Close()
+
+	location := strings.TrimSpace(c.PostForm("location"))
+	result, err := h.holidayUsecase.ImportHolidays(c.Request.Context(), file, location)
+	if errors.Is(err, domain.ErrInvalidCalendar) {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid calendar file", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to import holidays", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Holidays imported successfully", result)
+}
+
+// yearQuery reads the optional year query parameter, writing an error response if it is invalid
+func yearQuery(c *gin.Context) (int, bool) {
+	value := c.Query("year")
+	if value == "" {
+		return time.Now().Year(), true
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", domain.ErrInvalidInput.Error())
+		return 0, false
+	}
+	return year, true
+}
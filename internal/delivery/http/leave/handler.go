@@ -0,0 +1,364 @@
+package leave
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LeaveHandler struct {
+	leaveUsecase domain.LeaveUsecase
+}
+
+func NewLeaveHandler(leaveUsecase domain.LeaveUsecase) *LeaveHandler {
+	return &LeaveHandler{
+		leaveUsecase: leaveUsecase,
+	}
+}
+
+type requestLeaveRequest struct {
+	Type      string `json:"type" binding:"required,oneof=annual sick unpaid"`
+	StartDate string `json:"start_date" binding:"required" example:"2024-07-01"`
+	EndDate   string `json:"end_date" binding:"required" example:"2024-07-05"`
+	Reason    string `json:"reason" binding:"max=1000"`
+}
+
+type reviewLeaveRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type setEntitlementRequest struct {
+	Year         int    `json:"year" binding:"required,min=2000,max=9999"`
+	Type         string `json:"type" binding:"required,oneof=annual sick"`
+	EntitledDays int    `json:"entitled_days" binding:"min=0"`
+}
+
+// RequestLeave godoc
+// @Summary Request leave
+// @Description Submit a leave request for the authenticated user
+// @Tags leaves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body requestLeaveRequest true "Leave request details"
+// @Success 201 {object} utils.Response{data=domain.LeaveRequest} "Leave requested successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Overlapping request or insufficient balance"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /leaves [post]
+func (h *LeaveHandler) RequestLeave(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	var req requestLeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	startDate, err := time.Parse(domain.DateFormat, req.StartDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	endDate, err := time.Parse(domain.DateFormat, req.EndDate)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	leave := &domain.LeaveRequest{
+		UserID:    userID,
+		Type:      req.Type,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Reason:    req.Reason,
+	}
+
+	err = h.leaveUsecase.RequestLeave(c.Request.Context(), leave)
+	if err == domain.ErrInvalidLeaveType || err == domain.ErrInvalidDateRange || err == domain.ErrNoWorkingDays {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to request leave", err.Error())
+		return
+	}
+	if err == domain.ErrLeaveOverlap || err == domain.ErrInsufficientLeaveBalance {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to request leave", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to request leave", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Leave requested successfully", leave)
+}
+
+// GetMyLeaves godoc
+// @Summary Get my leave requests
+// @Description Get all leave requests of the authenticated user
+// @Tags leaves
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.LeaveRequest} "Leave requests retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /leaves [get]
+func (h *LeaveHandler) GetMyLeaves(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	leaves, err := h.leaveUsecase.GetUserLeaves(c.Request.Context(), userID)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave requests", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave requests retrieved successfully", leaves)
+}
+
+// CancelLeave godoc
+// @Summary Cancel a leave request
+// @Description Cancel a pending leave request of the authenticated user
+// @Tags leaves
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave request ID"
+// @Success 200 {object} utils.Response "Leave request cancelled successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Leave request not found"
+// @Failure 409 {object} utils.Response "Leave request is not pending"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /leaves/{id}/cancel [post]
+func (h *LeaveHandler) CancelLeave(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	err := h.leaveUsecase.CancelLeave(c.Request.Context(), userID, c.Param("id"))
+	if err == domain.ErrLeaveNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to cancel leave request", err.Error())
+		return
+	}
+	if err == domain.ErrLeaveNotPending {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to cancel leave request", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel leave request", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave request cancelled successfully", nil)
+}
+
+// GetMyBalances godoc
+// @Summary Get my leave balances
+// @Description Get the yearly leave balances of the authenticated user
+// @Tags leaves
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} utils.Response{data=[]domain.LeaveBalance} "Leave balances retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /leaves/balances [get]
+func (h *LeaveHandler) GetMyBalances(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	h.getBalances(c, userID)
+}
+
+// ListLeaves godoc
+// @Summary List leave requests by status
+// @Description List leave requests with the given status, pending by default. Requires leaves:approve; approvers without leaves:manage only see their reports' requests.
+// @Tags leaves
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Leave status" Enums(pending, approved, rejected, cancelled)
+// @Success 200 {object} utils.Response{data=[]domain.LeaveRequest} "Leave requests retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/leaves [get]
+func (h *LeaveHandler) ListLeaves(c *gin.Context) {
+	status := c.DefaultQuery("status", domain.LeaveStatusPending)
+
+	leaves, err := h.leaveUsecase.GetLeavesByStatus(c.Request.Context(), c.GetString("user_id"), status)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave requests", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave requests retrieved successfully", leaves)
+}
+
+// ApproveLeave godoc
+// @Summary Approve a leave request
+// @Description Approve a pending leave request, deduct the working days it holds now from the balance and record leave attendance. Requires leaves:approve; approvers without leaves:manage may only review their reports' requests, and nobody their own.
+// @Tags leaves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave request ID"
+// @Param request body reviewLeaveRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.LeaveRequest} "Leave request approved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own leave request"
+// @Failure 404 {object} utils.Response "Leave request not found"
+// @Failure 409 {object} utils.Response "Leave request is not pending, has no working days left or balance is insufficient"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/leaves/{id}/approve [post]
+func (h *LeaveHandler) ApproveLeave(c *gin.Context) {
+	h.reviewLeave(c, h.leaveUsecase.ApproveLeave, "Leave request approved successfully", "Failed to approve leave request")
+}
+
+// RejectLeave godoc
+// @Summary Reject a leave request
+// @Description Reject a pending leave request. Requires leaves:approve; approvers without leaves:manage may only review their reports' requests, and nobody their own.
+// @Tags leaves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Leave request ID"
+// @Param request body reviewLeaveRequest false "Review note"
+// @Success 200 {object} utils.Response{data=domain.LeaveRequest} "Leave request rejected successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied or own leave request"
+// @Failure 404 {object} utils.Response "Leave request not found"
+// @Failure 409 {object} utils.Response "Leave request is not pending"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/leaves/{id}/reject [post]
+func (h *LeaveHandler) RejectLeave(c *gin.Context) {
+	h.reviewLeave(c, h.leaveUsecase.RejectLeave, "Leave request rejected successfully", "Failed to reject leave request")
+}
+
+// GetUserBalances godoc
+// @Summary Get a user's leave balances
+// @Description Get the yearly leave balances of a user. Requires leaves:approve; approvers without leaves:manage may only see their reports' balances.
+// @Tags leaves
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param year query int false "Year, defaults to the current year"
+// @Success 200 {object} utils.Response{data=[]domain.LeaveBalance} "Leave balances retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/leaves/balances/{user_id} [get]
+func (h *LeaveHandler) GetUserBalances(c *gin.Context) {
+	h.getBalances(c, c.Param("user_id"))
+}
+
+// SetEntitlement godoc
+// @Summary Set a user's leave entitlement
+// @Description Set the yearly entitled days of a balance-tracked leave type for a user (requires leaves:manage)
+// @Tags leaves
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Param request body setEntitlementRequest true "Entitlement details"
+// @Success 200 {object} utils.Response{data=domain.LeaveBalance} "Leave entitlement updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/leaves/balances/{user_id} [put]
+func (h *LeaveHandler) SetEntitlement(c *gin.Context) {
+	var req setEntitlementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	balance, err := h.leaveUsecase.SetEntitlement(c.Request.Context(), c.Param("user_id"), req.Year, req.Type, req.EntitledDays)
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update leave entitlement", err.Error())
+		return
+	}
+	if err == domain.ErrInvalidLeaveType || err == domain.ErrInvalidInput {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update leave entitlement", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update leave entitlement", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave entitlement updated successfully", balance)
+}
+
+func (h *LeaveHandler) reviewLeave(c *gin.Context, review func(ctx context.Context, id, reviewerID, note string) (*domain.LeaveRequest, error), successMessage, failureMessage string) {
+	var req reviewLeaveRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+			return
+		}
+	}
+
+	leave, err := review(c.Request.Context(), c.Param("id"), c.GetString("user_id"), req.Note)
+	if err == domain.ErrLeaveNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied || err == domain.ErrOwnLeave {
+		utils.ErrorResponse(c, http.StatusForbidden, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrLeaveNotPending || err == domain.ErrInsufficientLeaveBalance || err == domain.ErrNoWorkingDays {
+		utils.ErrorResponse(c, http.StatusConflict, failureMessage, err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, failureMessage, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, successMessage, leave)
+}
+
+func (h *LeaveHandler) getBalances(c *gin.Context, userID string) {
+	year := time.Now().Year()
+	if value := c.Query("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid year", domain.ErrInvalidInput.Error())
+			return
+		}
+		year = parsed
+	}
+
+	balances, err := h.leaveUsecase.GetBalances(c.Request.Context(), c.GetString("user_id"), userID, year)
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to get leave balances", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get leave balances", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Leave balances retrieved successfully", balances)
+}
@@ -0,0 +1,273 @@
+package mfa
+
+import (
+	"net/http"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MFAHandler struct {
+	mfaUsecase domain.MFAUsecase
+}
+
+func NewMFAHandler(mfaUsecase domain.MFAUsecase) *MFAHandler {
+	return &MFAHandler{
+		mfaUsecase: mfaUsecase,
+	}
+}
+
+type codeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k7p2m-x9qrt"`
+}
+
+// GetStatus godoc
+// @Summary Get two-factor authentication status
+// @Description Whether the authenticated user has two-factor authentication enabled, whether their role requires it and how many recovery codes are left
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.MFAStatus} "MFA status retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/mfa [get]
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	status, err := h.mfaUsecase.GetStatus(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get MFA status", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA status retrieved successfully", status)
+}
+
+// Setup godoc
+// @Summary Start two-factor authentication setup
+// @Description Generate a new TOTP secret and its otpauth URI for display as a QR code. The setup takes effect once confirmed with a code at /users/mfa/enable
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.MFASetup} "MFA setup started"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "MFA already enabled"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/mfa/setup [post]
+func (h *MFAHandler) Setup(c *gin.Context) {
+	setup, err := h.mfaUsecase.Setup(c.Request.Context(), c.GetString("user_id"))
+	if err == domain.ErrMFAAlreadyEnabled {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to set up MFA", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to set up MFA", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA setup started", setup)
+}
+
+// Enable godoc
+// @Summary Enable two-factor authentication
+// @Description Confirm the setup with a code from the authenticator app. Returns recovery codes, which are only shown once
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body codeRequest true "TOTP code"
+// @Success 200 {object} utils.Response{data=recoveryCodesResponse} "MFA enabled"
+// @Failure 400 {object} utils.Response "Invalid request, invalid code or setup not started"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "MFA already enabled"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/mfa/enable [post]
+func (h *MFAHandler) Enable(c *gin.Context) {
+	var req codeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	codes, err := h.mfaUsecase.Enable(c.Request.Context(), c.GetString("user_id"), req.Code)
+	if err == domain.ErrInvalidMFACode || err == domain.ErrMFANotSetUp {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to enable MFA", err.Error())
+		return
+	}
+	if err == domain.ErrMFAAlreadyEnabled {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to enable MFA", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to enable MFA", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA enabled", recoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable godoc
+// @Summary Disable two-factor authentication
+// @Description Remove the authenticator and recovery codes after checking a TOTP or recovery code. Not allowed when the user's role requires MFA
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body codeRequest true "TOTP or recovery code"
+// @Success 200 {object} utils.Response "MFA disabled"
+// @Failure 400 {object} utils.Response "Invalid request, invalid code or MFA not enabled"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "MFA required for the user's role"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req codeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	err := h.mfaUsecase.Disable(c.Request.Context(), c.GetString("user_id"), req.Code)
+	if err == domain.ErrInvalidMFACode || err == domain.ErrMFANotEnabled {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to disable MFA", err.Error())
+		return
+	}
+	if err == domain.ErrMFARequired {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to disable MFA", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to disable MFA", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA disabled", nil)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes after checking a TOTP code. The new codes are only shown once
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body codeRequest true "TOTP code"
+// @Success 200 {object} utils.Response{data=recoveryCodesResponse} "Recovery codes regenerated"
+// @Failure 400 {object} utils.Response "Invalid request, invalid code or MFA not enabled"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req codeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	codes, err := h.mfaUsecase.RegenerateRecoveryCodes(c.Request.Context(), c.GetString("user_id"), req.Code)
+	if err == domain.ErrInvalidMFACode || err == domain.ErrMFANotEnabled {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to regenerate recovery codes", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to regenerate recovery codes", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Recovery codes regenerated", recoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// ResetUserMFA godoc
+// @Summary Reset a user's two-factor authentication
+// @Description Remove a user's authenticator and recovery codes, e.g. after they lost their device, and end their sessions. They can then log in with their password and enroll again (requires users:manage)
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response "MFA reset successfully"
+// @Failure 400 {object} utils.Response "Cannot reset own MFA or MFA not set up"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/mfa [delete]
+func (h *MFAHandler) ResetUserMFA(c *gin.Context) {
+	err := h.mfaUsecase.Reset(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to reset MFA", err.Error())
+		return
+	}
+	if err == domain.ErrSelfManagement || err == domain.ErrMFANotEnabled {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to reset MFA", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to reset MFA", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to reset MFA", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA reset successfully", nil)
+}
+
+// GetPolicy godoc
+// @Summary Get the MFA policy
+// @Description List the roles that must use two-factor authentication (requires roles:manage)
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.MFAPolicy} "MFA policy retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/mfa/policy [get]
+func (h *MFAHandler) GetPolicy(c *gin.Context) {
+	policy, err := h.mfaUsecase.GetPolicy(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get MFA policy", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA policy retrieved successfully", policy)
+}
+
+// SetPolicy godoc
+// @Summary Set the MFA policy
+// @Description Set the roles that must use two-factor authentication. Users with such a role who have not enrolled can only reach the enrollment endpoints until they do (requires roles:manage)
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.MFAPolicy true "Roles that require MFA"
+// @Success 200 {object} utils.Response{data=domain.MFAPolicy} "MFA policy updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request or role"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/mfa/policy [put]
+func (h *MFAHandler) SetPolicy(c *gin.Context) {
+	var policy domain.MFAPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	err := h.mfaUsecase.SetPolicy(c.Request.Context(), &policy)
+	if err == domain.ErrInvalidRole {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update MFA policy", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update MFA policy", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "MFA policy updated successfully", policy)
+}
@@ -0,0 +1,445 @@
+package organization
+
+import (
+	"net/http"
+	"strings"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	organizationUsecase domain.OrganizationUsecase
+}
+
+func NewOrganizationHandler(organizationUsecase domain.OrganizationUsecase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUsecase: organizationUsecase,
+	}
+}
+
+type departmentRequest struct {
+	Name        string `json:"name" binding:"required,max=255" example:"Engineering"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+type createTeamRequest struct {
+	DepartmentID string `json:"department_id" binding:"required"`
+	Name         string `json:"name" binding:"required,max=255" example:"Platform"`
+	Description  string `json:"description" binding:"max=255"`
+}
+
+type updateTeamRequest struct {
+	Name        string `json:"name" binding:"required,max=255" example:"Platform"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+type placementRequest struct {
+	DepartmentID string `json:"department_id"`
+	TeamID       string `json:"team_id"`
+	ManagerID    string `json:"manager_id"`
+}
+
+// CreateDepartment godoc
+// @Summary Create a department
+// @Description Create a department (requires organization:manage)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body departmentRequest true "Department details"
+// @Success 201 {object} utils.Response{data=domain.Department} "Department created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 409 {object} utils.Response "Department already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/departments [post]
+func (h *OrganizationHandler) CreateDepartment(c *gin.Context) {
+	var req departmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	department := &domain.Department{
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+	err := h.organizationUsecase.CreateDepartment(c.Request.Context(), department)
+	if err == domain.ErrDepartmentExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create department", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create department", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Department created successfully", department)
+}
+
+// ListDepartments godoc
+// @Summary List departments
+// @Description List all departments (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.Department} "Departments retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/departments [get]
+func (h *OrganizationHandler) ListDepartments(c *gin.Context) {
+	departments, err := h.organizationUsecase.ListDepartments(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get departments", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Departments retrieved successfully", departments)
+}
+
+// GetDepartment godoc
+// @Summary Get a department
+// @Description Get a department by ID (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Department ID"
+// @Success 200 {object} utils.Response{data=domain.Department} "Department retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Department not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/departments/{id} [get]
+func (h *OrganizationHandler) GetDepartment(c *gin.Context) {
+	department, err := h.organizationUsecase.GetDepartment(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrDepartmentNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get department", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get department", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Department retrieved successfully", department)
+}
+
+// UpdateDepartment godoc
+// @Summary Update a department
+// @Description Rename a department or change its description (requires organization:manage)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Department ID"
+// @Param request body departmentRequest true "Department details"
+// @Success 200 {object} utils.Response{data=domain.Department} "Department updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Department not found"
+// @Failure 409 {object} utils.Response "Department already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/departments/{id} [put]
+func (h *OrganizationHandler) UpdateDepartment(c *gin.Context) {
+	var req departmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	department := &domain.Department{
+		ID:          c.Param("id"),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+	err := h.organizationUsecase.UpdateDepartment(c.Request.Context(), department)
+	if err == domain.ErrDepartmentNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update department", err.Error())
+		return
+	}
+	if err == domain.ErrDepartmentExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to update department", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update department", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Department updated successfully", department)
+}
+
+// DeleteDepartment godoc
+// @Summary Delete a department
+// @Description Delete a department without teams; its users are left without a department (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Department ID"
+// @Success 200 {object} utils.Response "Department deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Department not found"
+// @Failure 409 {object} utils.Response "Department still has teams"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/departments/{id} [delete]
+func (h *OrganizationHandler) DeleteDepartment(c *gin.Context) {
+	err := h.organizationUsecase.DeleteDepartment(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrDepartmentNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete department", err.Error())
+		return
+	}
+	if err == domain.ErrDepartmentHasTeams {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to delete department", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete department", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Department deleted successfully", nil)
+}
+
+// CreateTeam godoc
+// @Summary Create a team
+// @Description Create a team in a department (requires organization:manage)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createTeamRequest true "Team details"
+// @Success 201 {object} utils.Response{data=domain.Team} "Team created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Department not found"
+// @Failure 409 {object} utils.Response "Team already exists in the department"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/teams [post]
+func (h *OrganizationHandler) CreateTeam(c *gin.Context) {
+	var req createTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	team := &domain.Team{
+		DepartmentID: req.DepartmentID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  strings.TrimSpace(req.Description),
+	}
+	err := h.organizationUsecase.CreateTeam(c.Request.Context(), team)
+	if err == domain.ErrDepartmentNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to create team", err.Error())
+		return
+	}
+	if err == domain.ErrTeamExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create team", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create team", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Team created successfully", team)
+}
+
+// ListTeams godoc
+// @Summary List teams
+// @Description List all teams, or those of one department (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param department_id query string false "Department ID"
+// @Success 200 {object} utils.Response{data=[]domain.Team} "Teams retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/teams [get]
+func (h *OrganizationHandler) ListTeams(c *gin.Context) {
+	teams, err := h.organizationUsecase.ListTeams(c.Request.Context(), c.Query("department_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get teams", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Teams retrieved successfully", teams)
+}
+
+// GetTeam godoc
+// @Summary Get a team
+// @Description Get a team by ID (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team ID"
+// @Success 200 {object} utils.Response{data=domain.Team} "Team retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Team not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/teams/{id} [get]
+func (h *OrganizationHandler) GetTeam(c *gin.Context) {
+	team, err := h.organizationUsecase.GetTeam(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get team", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get team", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Team retrieved successfully", team)
+}
+
+// UpdateTeam godoc
+// @Summary Update a team
+// @Description Rename a team or change its description. Teams stay in their department (requires organization:manage)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team ID"
+// @Param request body updateTeamRequest true "Team details"
+// @Success 200 {object} utils.Response{data=domain.Team} "Team updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Team not found"
+// @Failure 409 {object} utils.Response "Team already exists in the department"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/teams/{id} [put]
+func (h *OrganizationHandler) UpdateTeam(c *gin.Context) {
+	var req updateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	team := &domain.Team{
+		ID:          c.Param("id"),
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+	}
+	err := h.organizationUsecase.UpdateTeam(c.Request.Context(), team)
+	if err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update team", err.Error())
+		return
+	}
+	if err == domain.ErrTeamExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to update team", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update team", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Team updated successfully", team)
+}
+
+// DeleteTeam godoc
+// @Summary Delete a team
+// @Description Delete a team; its users stay in the department without a team (requires organization:manage)
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team ID"
+// @Success 200 {object} utils.Response "Team deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Team not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/teams/{id} [delete]
+func (h *OrganizationHandler) DeleteTeam(c *gin.Context) {
+	err := h.organizationUsecase.DeleteTeam(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete team", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete team", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Team deleted successfully", nil)
+}
+
+// SetPlacement godoc
+// @Summary Set a user's department, team and manager
+// @Description Place a user in a department and team and under a manager, replacing the previous placement. Empty fields clear it; a team implies its department. The manager sees the user's attendance (requires organization:manage)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body placementRequest true "Placement"
+// @Success 200 {object} utils.Response{data=domain.User} "Placement updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request, team outside the department, unknown manager or circular reporting line"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User, department or team not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/placement [put]
+func (h *OrganizationHandler) SetPlacement(c *gin.Context) {
+	var req placementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	placement := domain.Placement{
+		UserID:       c.Param("id"),
+		DepartmentID: req.DepartmentID,
+		TeamID:       req.TeamID,
+		ManagerID:    req.ManagerID,
+	}
+	user, err := h.organizationUsecase.SetPlacement(c.Request.Context(), c.GetString("user_id"), placement)
+	if err == domain.ErrUserNotFound || err == domain.ErrDepartmentNotFound || err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update placement", err.Error())
+		return
+	}
+	if err == domain.ErrTeamNotInDepartment || err == domain.ErrManagerNotFound || err == domain.ErrManagerCycle {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update placement", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to update placement", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update placement", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Placement updated successfully", user)
+}
+
+// GetMyReports godoc
+// @Summary Get my reports
+// @Description List the users who report to the authenticated user directly or through other managers
+// @Tags organization
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.User} "Reports retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/reports [get]
+func (h *OrganizationHandler) GetMyReports(c *gin.Context) {
+	reports, err := h.organizationUsecase.GetReports(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get reports", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reports retrieved successfully", reports)
+}
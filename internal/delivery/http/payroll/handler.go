@@ -0,0 +1,117 @@
+package payroll
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PayrollHandler struct {
+	payrollUsecase domain.PayrollUsecase
+}
+
+func NewPayrollHandler(payrollUsecase domain.PayrollUsecase) *PayrollHandler {
+	return &PayrollHandler{
+		payrollUsecase: payrollUsecase,
+	}
+}
+
+type exportRequest struct {
+	PeriodStart string `json:"period_start" binding:"required" example:"2024-07-01"`
+	PeriodEnd   string `json:"period_end" binding:"required" example:"2024-07-31"`
+	Format      string `json:"format" example:"csv"` // csv (default) or fixed
+	Force       bool   `json:"force"`                // repeat an export of an overlapping period
+}
+
+// ExportPayroll godoc
+// @Summary Export payroll
+// @Description Convert a pay period's attendance into a payroll import file, one line per user with records in the period: worked and overtime hours, unpaid absences and leave days, in the configured columns. Every export is recorded; periods overlapping an earlier export are refused unless force is set (requires payroll:export)
+// @Tags payroll
+// @Accept json
+// @Produce text/csv
+// @Produce text/plain
+// @Security BearerAuth
+// @Param request body exportRequest true "Pay period and format"
+// @Success 200 {file} file "Payroll file"
+// @Failure 400 {object} utils.Response "Invalid period or format"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 409 {object} utils.Response "Period overlaps an earlier export"
+// @Failure 422 {object} utils.Response "A value does not fit its fixed-width column"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/payroll/exports [post]
+func (h *PayrollHandler) ExportPayroll(c *gin.Context) {
+	var req exportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	periodStart, err := time.Parse(domain.DateFormat, req.PeriodStart)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	periodEnd, err := time.Parse(domain.DateFormat, req.PeriodEnd)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+	if req.Format == "" {
+		req.Format = domain.PayrollFormatCSV
+	}
+
+	file, err := h.payrollUsecase.ExportPayroll(c.Request.Context(), domain.PayrollExportRequest{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Format:      req.Format,
+		Force:       req.Force,
+		ExportedBy:  c.GetString("user_id"),
+	})
+	if err == domain.ErrInvalidPayrollFormat || err == domain.ErrInvalidDateRange {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to export payroll", err.Error())
+		return
+	}
+	if err == domain.ErrPayrollAlreadyExported {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to export payroll", err.Error())
+		return
+	}
+	if errors.Is(err, domain.ErrPayrollValueTooWide) {
+		utils.ErrorResponse(c, http.StatusUnprocessableEntity, "Failed to export payroll", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export payroll", err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.Filename))
+	c.Header("X-Payroll-Export-ID", file.Export.ID)
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// ListExports godoc
+// @Summary List payroll exports
+// @Description List the history of payroll exports, newest first (requires payroll:export)
+// @Tags payroll
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.PayrollExport} "Payroll exports retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/payroll/exports [get]
+func (h *PayrollHandler) ListExports(c *gin.Context) {
+	exports, err := h.payrollUsecase.ListExports(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to list payroll exports", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Payroll exports retrieved successfully", exports)
+}
@@ -0,0 +1,348 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+	"golang-tes/internal/utils/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	reportUsecase    domain.ReportUsecase
+	timesheetUsecase domain.TimesheetUsecase
+}
+
+func NewReportHandler(reportUsecase domain.ReportUsecase, timesheetUsecase domain.TimesheetUsecase) *ReportHandler {
+	return &ReportHandler{
+		reportUsecase:    reportUsecase,
+		timesheetUsecase: timesheetUsecase,
+	}
+}
+
+// GetUserMonthlyReport godoc
+// @Summary Get a user's monthly report
+// @Description Summarize a month of attendance of the authenticated user, or with user_id of one of their direct or indirect reports (anyone with reports:read): days present, late, absent and on leave, worked hours and punctuality rate
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "User ID, defaults to the authenticated user"
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Param compare query bool false "Include the previous month for comparison"
+// @Success 200 {object} utils.Response{data=domain.MonthlyReport} "Report retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid month or compare flag"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /reports/monthly/user [get]
+func (h *ReportHandler) GetUserMonthlyReport(c *gin.Context) {
+	month, compare, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	callerID := c.GetString("user_id")
+	userID := callerID
+	if value := c.Query("user_id"); value != "" {
+		userID = value
+	}
+
+	report, err := h.reportUsecase.GetUserMonthlyReport(c.Request.Context(), callerID, userID, month, compare)
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to get report", err.Error())
+		return
+	}
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get report", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get report", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Report retrieved successfully", report)
+}
+
+// GetTeamMonthlyReport godoc
+// @Summary Get a team's monthly report
+// @Description Summarize a month of attendance of a team, in total and per member (requires reports:read)
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Team ID"
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Param compare query bool false "Include the previous month for comparison"
+// @Success 200 {object} utils.Response{data=domain.MonthlyReport} "Report retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid month or compare flag"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Team not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/reports/monthly/teams/{id} [get]
+func (h *ReportHandler) GetTeamMonthlyReport(c *gin.Context) {
+	month, compare, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetTeamMonthlyReport(c.Request.Context(), c.Param("id"), month, compare)
+	if err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get report", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get report", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Report retrieved successfully", report)
+}
+
+// GetCompanyMonthlyReport godoc
+// @Summary Get the company's monthly report
+// @Description Summarize a month of attendance of the whole company, in total and per user (requires reports:read)
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Param compare query bool false "Include the previous month for comparison"
+// @Success 200 {object} utils.Response{data=domain.MonthlyReport} "Report retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid month or compare flag"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/reports/monthly [get]
+func (h *ReportHandler) GetCompanyMonthlyReport(c *gin.Context) {
+	month, compare, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportUsecase.GetCompanyMonthlyReport(c.Request.Context(), month, compare)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get report", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Report retrieved successfully", report)
+}
+
+// ExportMonthlySummaries godoc
+// @Summary Export monthly summaries
+// @Description Download the monthly summary of every user the caller may see, with their names and emails, as CSV or XLSX: the caller and their direct and indirect reports, or everyone with reports:read
+// @Tags reports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param format query string false "File format (default csv)" Enums(csv, xlsx)
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Success 200 {file} file "Monthly summaries export"
+// @Failure 400 {object} utils.Response "Invalid month or format"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /reports/monthly/export [get]
+func (h *ReportHandler) ExportMonthlySummaries(c *gin.Context) {
+	month, _, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	export, err := utils.NewTableExport(c, c.DefaultQuery("format", domain.ExportFormatCSV), "attendance-summary-"+month.Format(domain.MonthFormat),
+		"month", "user_id", "name", "email", "days_present", "days_late", "days_absent", "days_on_leave", "worked_hours",
+		"overtime_hours", "approved_overtime_hours", "punctuality_rate")
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to export summaries", err.Error())
+		return
+	}
+
+	summaries, err := h.reportUsecase.ListMonthlySummaries(c.Request.Context(), c.GetString("user_id"), month)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export summaries", err.Error())
+		return
+	}
+
+	for _, summary := range summaries {
+		err = export.WriteRow(
+			month.Format(domain.MonthFormat),
+			summary.UserID,
+			summary.Name,
+			summary.Email,
+			summary.DaysPresent,
+			summary.DaysLate,
+			summary.DaysAbsent,
+			summary.DaysOnLeave,
+			summary.WorkedHours,
+			summary.OvertimeHours,
+			summary.ApprovedOvertimeHours,
+			summary.PunctualityRate,
+		)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = export.Close()
+	}
+	export.Interrupted(err)
+}
+
+// GetTimesheet godoc
+// @Summary Download a timesheet
+// @Description Download the monthly timesheet of the authenticated user, or with user_id of one of their direct or indirect reports (anyone with reports:read), as a PDF for sign-off
+// @Tags reports
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param user_id query string false "User ID, defaults to the authenticated user"
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Success 200 {file} file "Timesheet PDF"
+// @Failure 400 {object} utils.Response "Invalid month"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /reports/timesheets/user [get]
+func (h *ReportHandler) GetTimesheet(c *gin.Context) {
+	month, _, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	callerID := c.GetString("user_id")
+	userID := callerID
+	if value := c.Query("user_id"); value != "" {
+		userID = value
+	}
+
+	timesheet, err := h.timesheetUsecase.GetTimesheet(c.Request.Context(), callerID, userID, month)
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to get timesheet", err.Error())
+		return
+	}
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get timesheet", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get timesheet", err.Error())
+		return
+	}
+
+	var body bytes.Buffer
+	if _, err := renderTimesheet(timesheet).WriteTo(&body); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get timesheet", err.Error())
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, timesheetFilename(timesheet)))
+	c.Data(http.StatusOK, "application/pdf", body.Bytes())
+}
+
+// ExportTimesheets godoc
+// @Summary Download timesheets in bulk
+// @Description Download a ZIP with the monthly timesheet PDF of every member of a team, or of every user without team_id (requires reports:read). Deactivated users without records in the month are left out.
+// @Tags reports
+// @Produce application/zip
+// @Security BearerAuth
+// @Param team_id query string false "Team ID, defaults to all users"
+// @Param month query string false "Month in YYYY-MM format, defaults to the current month"
+// @Success 200 {file} file "ZIP of timesheet PDFs"
+// @Failure 400 {object} utils.Response "Invalid month"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Team not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/reports/timesheets [get]
+func (h *ReportHandler) ExportTimesheets(c *gin.Context) {
+	month, _, ok := reportQuery(c)
+	if !ok {
+		return
+	}
+
+	// The archive is streamed, so it is only started with the first timesheet
+	// and errors found before it can still be sent as JSON
+	var archive *zip.Writer
+	start := func() {
+		c.Header("Content-Type", "application/zip")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="timesheets-%s.zip"`, month.Format(domain.MonthFormat)))
+		c.Status(http.StatusOK)
+		archive = zip.NewWriter(c.Writer)
+	}
+
+	err := h.timesheetUsecase.EachTimesheet(c.Request.Context(), c.Query("team_id"), month, func(timesheet *domain.Timesheet) error {
+		if archive == nil {
+			start()
+		}
+		file, err := archive.Create(timesheetFilename(timesheet) + ".pdf")
+		if err != nil {
+			return err
+		}
+		_, err = renderTimesheet(timesheet).WriteTo(file)
+		return err
+	})
+	if err == nil {
+		if archive == nil {
+			start()
+		}
+		err = archive.Close()
+	}
+	if err != nil && archive != nil {
+		logger.Error("Timesheet export interrupted",
+			zap.Error(err),
+			zap.String("path", c.Request.URL.Path))
+		return
+	}
+	if err == domain.ErrTeamNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to export timesheets", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to export timesheets", err.Error())
+		return
+	}
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// timesheetFilename names a timesheet file after the month and the user. The
+// user ID keeps names unique when users share a name.
+func timesheetFilename(timesheet *domain.Timesheet) string {
+	name := strings.Trim(unsafeFilenameChars.ReplaceAllString(timesheet.User.Name, "-"), "-")
+	return fmt.Sprintf("timesheet-%s-%s-%s", timesheet.Month, strings.ToLower(name), timesheet.User.ID)
+}
+
+// reportQuery reads the optional month and compare query parameters, writing
+// an error response if either is invalid
+func reportQuery(c *gin.Context) (time.Time, bool, bool) {
+	month := time.Now()
+	if value := c.Query("month"); value != "" {
+		parsed, err := time.Parse(domain.MonthFormat, value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid month", domain.ErrInvalidInput.Error())
+			return time.Time{}, false, false
+		}
+		month = parsed
+	}
+
+	compare := false
+	if value := c.Query("compare"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid compare flag", domain.ErrInvalidInput.Error())
+			return time.Time{}, false, false
+		}
+		compare = parsed
+	}
+	return month, compare, true
+}
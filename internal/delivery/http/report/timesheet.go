@@ -0,0 +1,109 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/pkg/pdf"
+)
+
+// Timesheet layout in points from the top-left corner of an A4 page
+const (
+	marginLeft   = 40
+	marginRight  = pdf.PageWidth - 40
+	tableTop     = 118
+	rowHeight    = 17
+	fontSize     = 9
+	maxNoteChars = 34
+)
+
+// timesheetColumns are the x positions of the columns of the daily rows
+var timesheetColumns = struct{ date, status, clockIn, clockOut, worked, note float64 }{40, 110, 180, 245, 310, 370}
+
+var leaveTypeNames = map[string]string{
+	domain.LeaveTypeAnnual: "Annual leave",
+	domain.LeaveTypeSick:   "Sick leave",
+	domain.LeaveTypeUnpaid: "Unpaid leave",
+}
+
+// renderTimesheet lays out a timesheet on a single page: a row per day with
+// holiday and leave annotations, the month's totals and signature lines
+func renderTimesheet(timesheet *domain.Timesheet) *pdf.Document {
+	doc := pdf.New()
+	page := doc.AddPage()
+
+	month, _ := time.Parse(domain.MonthFormat, timesheet.Month)
+	page.Text(marginLeft, 50, 16, pdf.Bold, "Timesheet "+month.Format("January 2006"))
+	page.Text(marginLeft, 72, 10, pdf.Regular, "Employee: "+timesheet.User.Name)
+	page.Text(marginLeft, 86, 10, pdf.Regular, "Email: "+timesheet.User.Email)
+
+	columns := timesheetColumns
+	page.Text(columns.date, tableTop-5, fontSize, pdf.Bold, "Date")
+	page.Text(columns.status, tableTop-5, fontSize, pdf.Bold, "Status")
+	page.Text(columns.clockIn, tableTop-5, fontSize, pdf.Bold, "Clock in")
+	page.Text(columns.clockOut, tableTop-5, fontSize, pdf.Bold, "Clock out")
+	page.Text(columns.worked, tableTop-5, fontSize, pdf.Bold, "Worked")
+	page.Text(columns.note, tableTop-5, fontSize, pdf.Bold, "Note")
+	page.Line(marginLeft, tableTop, marginRight, tableTop, 0.8)
+
+	y := float64(tableTop)
+	for _, day := range timesheet.Days {
+		y += rowHeight
+		page.Text(columns.date, y-5, fontSize, pdf.Regular, day.Date.Format("Mon 02"))
+		if attendance := day.Attendance; attendance != nil {
+			page.Text(columns.status, y-5, fontSize, pdf.Regular, attendance.Status)
+			page.Text(columns.clockIn, y-5, fontSize, pdf.Regular, clockTime(attendance.ClockIn))
+			page.Text(columns.clockOut, y-5, fontSize, pdf.Regular, clockTime(attendance.ClockOut))
+			page.Text(columns.worked, y-5, fontSize, pdf.Regular, hoursAndMinutes(attendance.WorkedMinutes))
+		}
+		page.Text(columns.note, y-5, fontSize, pdf.Regular, dayNote(day))
+		page.Line(marginLeft, y, marginRight, y, 0.2)
+	}
+
+	totals := timesheet.Totals
+	y += 22
+	page.Text(marginLeft, y, 10, pdf.Bold, "Totals")
+	page.Text(marginLeft, y+15, fontSize, pdf.Regular, fmt.Sprintf(
+		"Present: %d    Late: %d    Absent: %d    On leave: %d",
+		totals.DaysPresent, totals.DaysLate, totals.DaysAbsent, totals.DaysOnLeave))
+	page.Text(marginLeft, y+29, fontSize, pdf.Regular, fmt.Sprintf(
+		"Worked: %s h    Overtime: %s h (%s h approved)    Punctuality: %.1f%%",
+		hoursAndMinutes(totals.WorkedMinutes), hoursAndMinutes(totals.OvertimeMinutes),
+		hoursAndMinutes(totals.ApprovedOvertimeMinutes), totals.PunctualityRate))
+
+	signatureLine := pdf.PageHeight - 70
+	page.Line(marginLeft, signatureLine, marginLeft+220, signatureLine, 0.6)
+	page.Text(marginLeft, signatureLine+12, fontSize, pdf.Regular, "Employee signature and date")
+	page.Line(marginRight-220, signatureLine, marginRight, signatureLine, 0.6)
+	page.Text(marginRight-220, signatureLine+12, fontSize, pdf.Regular, "Manager signature and date")
+	return doc
+}
+
+// dayNote annotates holidays and approved leave
+func dayNote(day domain.TimesheetDay) string {
+	var notes []string
+	if day.Holiday != "" {
+		notes = append(notes, day.Holiday)
+	}
+	if day.LeaveType != "" {
+		notes = append(notes, leaveTypeNames[day.LeaveType])
+	}
+	note := strings.Join(notes, ", ")
+	if len([]rune(note)) > maxNoteChars {
+		note = string([]rune(note)[:maxNoteChars-3]) + "..."
+	}
+	return note
+}
+
+func clockTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(domain.TimeOfDayFormat)
+}
+
+func hoursAndMinutes(minutes int) string {
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
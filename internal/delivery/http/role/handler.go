@@ -0,0 +1,257 @@
+package role
+
+import (
+	"net/http"
+	"strings"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+	"golang-tes/internal/utils/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleHandler struct {
+	roleUsecase domain.RoleUsecase
+}
+
+func NewRoleHandler(roleUsecase domain.RoleUsecase) *RoleHandler {
+	return &RoleHandler{
+		roleUsecase: roleUsecase,
+	}
+}
+
+type createRoleRequest struct {
+	Name        string   `json:"name" binding:"required" example:"team-lead"`
+	Description string   `json:"description" binding:"max=255" example:"Approves leave and corrections of their team"`
+	Permissions []string `json:"permissions" example:"leaves:approve,corrections:approve"`
+}
+
+type updateRoleRequest struct {
+	Description string   `json:"description" binding:"max=255" example:"Approves leave and corrections of their team"`
+	Permissions []string `json:"permissions" example:"leaves:approve,corrections:approve"`
+}
+
+type permissionsResponse struct {
+	Permissions []string `json:"permissions" example:"attendance:read:own,attendance:write:own"`
+}
+
+// ListPermissions godoc
+// @Summary List permissions
+// @Description List every permission that can be granted to a role (requires roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=permissionsResponse} "Permissions retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Router /admin/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	utils.SuccessResponse(c, http.StatusOK, "Permissions retrieved successfully", permissionsResponse{Permissions: domain.AllPermissions})
+}
+
+// GetMyPermissions godoc
+// @Summary Get my permissions
+// @Description List the permissions of the authenticated user's role, e.g. to decide which parts of the app to show
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=permissionsResponse} "Permissions retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/permissions [get]
+func (h *RoleHandler) GetMyPermissions(c *gin.Context) {
+	permissions, err := h.roleUsecase.GetPermissions(c.Request.Context(), c.GetString("user_id"))
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get permissions", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Permissions retrieved successfully", permissionsResponse{Permissions: permissions})
+}
+
+// ListRoles godoc
+// @Summary List roles
+// @Description List the built-in and custom roles with their permissions (requires users:read)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.Role} "Roles retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleUsecase.ListRoles(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get roles", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roles retrieved successfully", roles)
+}
+
+// GetRole godoc
+// @Summary Get a role
+// @Description Get a role with its permissions (requires users:read)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} utils.Response{data=domain.Role} "Role retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Role not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roles/{name} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	role, err := h.roleUsecase.GetRole(c.Request.Context(), c.Param("name"))
+	if err == domain.ErrRoleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get role", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get role", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role retrieved successfully", role)
+}
+
+// CreateRole godoc
+// @Summary Create a role
+// @Description Define a custom role such as "hr" or "team-lead" as a set of permissions. Only permissions the caller holds can be granted (requires roles:manage)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createRoleRequest true "Role details"
+// @Success 201 {object} utils.Response{data=domain.Role} "Role created successfully"
+// @Failure 400 {object} utils.Response "Invalid request, role name or permission"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 409 {object} utils.Response "Role already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req createRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	if err := validator.ValidateRoleName(req.Name); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role name", err.Error())
+		return
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: strings.TrimSpace(req.Description),
+		Permissions: req.Permissions,
+	}
+	err := h.roleUsecase.CreateRole(c.Request.Context(), c.GetString("user_id"), role)
+	if err == domain.ErrInvalidPermission {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create role", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to create role", err.Error())
+		return
+	}
+	if err == domain.ErrRoleExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create role", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create role", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Role created successfully", role)
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Replace the description and permissions of a role. The admin role cannot be changed, and only roles and permissions the caller holds can be edited (requires roles:manage)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Param request body updateRoleRequest true "Role details"
+// @Success 200 {object} utils.Response{data=domain.Role} "Role updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request or permission, or the admin role"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Role not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roles/{name} [put]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req updateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	role := &domain.Role{
+		Name:        c.Param("name"),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: req.Permissions,
+	}
+	err := h.roleUsecase.UpdateRole(c.Request.Context(), c.GetString("user_id"), role)
+	if err == domain.ErrRoleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update role", err.Error())
+		return
+	}
+	if err == domain.ErrInvalidPermission || err == domain.ErrSystemRole {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update role", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to update role", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update role", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role updated successfully", role)
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Delete a custom role that is not assigned to any user. Built-in roles cannot be deleted (requires roles:manage)
+// @Tags roles
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Role name"
+// @Success 200 {object} utils.Response "Role deleted successfully"
+// @Failure 400 {object} utils.Response "Built-in role"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Role not found"
+// @Failure 409 {object} utils.Response "Role is assigned to users"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roles/{name} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	err := h.roleUsecase.DeleteRole(c.Request.Context(), c.Param("name"))
+	if err == domain.ErrRoleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete role", err.Error())
+		return
+	}
+	if err == domain.ErrSystemRole {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to delete role", err.Error())
+		return
+	}
+	if err == domain.ErrRoleInUse {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to delete role", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete role", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role deleted successfully", nil)
+}
@@ -0,0 +1,286 @@
+package schedule
+
+import (
+	"net/http"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+	"golang-tes/internal/utils/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	scheduleUsecase domain.WorkScheduleUsecase
+}
+
+func NewScheduleHandler(scheduleUsecase domain.WorkScheduleUsecase) *ScheduleHandler {
+	return &ScheduleHandler{
+		scheduleUsecase: scheduleUsecase,
+	}
+}
+
+type scheduleRequest struct {
+	Name               string `json:"name" binding:"required"`
+	StartTime          string `json:"start_time" binding:"required" example:"09:00"`
+	EndTime            string `json:"end_time" binding:"required" example:"17:00"`
+	GracePeriodMinutes int    `json:"grace_period_minutes" binding:"min=0"`
+	WorkDays           []int  `json:"work_days" binding:"required,min=1"`
+	IsDefault          bool   `json:"is_default"`
+}
+
+type assignScheduleRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1"`
+}
+
+func (r *scheduleRequest) toSchedule() *domain.WorkSchedule {
+	return &domain.WorkSchedule{
+		Name:               r.Name,
+		StartTime:          r.StartTime,
+		EndTime:            r.EndTime,
+		GracePeriodMinutes: r.GracePeriodMinutes,
+		WorkDays:           r.WorkDays,
+		IsDefault:          r.IsDefault,
+	}
+}
+
+// CreateSchedule godoc
+// @Summary Create a work schedule
+// @Description Create a work schedule used to derive present/late status (requires schedules:manage)
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body scheduleRequest true "Work schedule details"
+// @Success 201 {object} utils.Response{data=domain.WorkSchedule} "Work schedule created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules [post]
+func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	schedule := req.toSchedule()
+	if err := validator.ValidateWorkSchedule(schedule); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid work schedule", err.Error())
+		return
+	}
+
+	if err := h.scheduleUsecase.CreateSchedule(c.Request.Context(), schedule); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create work schedule", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Work schedule created successfully", schedule)
+}
+
+// ListSchedules godoc
+// @Summary List work schedules
+// @Description List all work schedules (requires schedules:manage)
+// @Tags schedules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.WorkSchedule} "Work schedules retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules [get]
+func (h *ScheduleHandler) ListSchedules(c *gin.Context) {
+	schedules, err := h.scheduleUsecase.ListSchedules(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get work schedules", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedules retrieved successfully", schedules)
+}
+
+// GetSchedule godoc
+// @Summary Get a work schedule
+// @Description Get a work schedule by ID (requires schedules:manage)
+// @Tags schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} utils.Response{data=domain.WorkSchedule} "Work schedule retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Work schedule not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules/{id} [get]
+func (h *ScheduleHandler) GetSchedule(c *gin.Context) {
+	schedule, err := h.scheduleUsecase.GetSchedule(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrScheduleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get work schedule", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get work schedule", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedule retrieved successfully", schedule)
+}
+
+// UpdateSchedule godoc
+// @Summary Update a work schedule
+// @Description Replace the details of a work schedule (requires schedules:manage)
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Schedule ID"
+// @Param request body scheduleRequest true "Work schedule details"
+// @Success 200 {object} utils.Response{data=domain.WorkSchedule} "Work schedule updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Work schedule not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules/{id} [put]
+func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	var req scheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	schedule := req.toSchedule()
+	schedule.ID = c.Param("id")
+	if err := validator.ValidateWorkSchedule(schedule); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid work schedule", err.Error())
+		return
+	}
+
+	err := h.scheduleUsecase.UpdateSchedule(c.Request.Context(), schedule)
+	if err == domain.ErrScheduleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update work schedule", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update work schedule", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedule updated successfully", schedule)
+}
+
+// DeleteSchedule godoc
+// @Summary Delete a work schedule
+// @Description Delete a work schedule; assigned users fall back to the default schedule (requires schedules:manage)
+// @Tags schedules
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Schedule ID"
+// @Success 200 {object} utils.Response "Work schedule deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Work schedule not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules/{id} [delete]
+func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	err := h.scheduleUsecase.DeleteSchedule(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrScheduleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete work schedule", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete work schedule", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedule deleted successfully", nil)
+}
+
+// AssignUsers godoc
+// @Summary Assign users to a work schedule
+// @Description Assign one or more users to a work schedule, replacing their previous assignment (requires schedules:manage)
+// @Tags schedules
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Schedule ID"
+// @Param request body assignScheduleRequest true "Users to assign"
+// @Success 200 {object} utils.Response "Users assigned successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Work schedule or user not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules/{id}/users [put]
+func (h *ScheduleHandler) AssignUsers(c *gin.Context) {
+	var req assignScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	err := h.scheduleUsecase.AssignUsers(c.Request.Context(), c.Param("id"), req.UserIDs)
+	if err == domain.ErrScheduleNotFound || err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to assign users", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to assign users", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Users assigned successfully", nil)
+}
+
+// UnassignUser godoc
+// @Summary Remove a user's work schedule assignment
+// @Description Remove the schedule assignment of a user so the default schedule applies (requires schedules:manage)
+// @Tags schedules
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path string true "User ID"
+// @Success 200 {object} utils.Response "User unassigned successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/schedules/users/{user_id} [delete]
+func (h *ScheduleHandler) UnassignUser(c *gin.Context) {
+	if err := h.scheduleUsecase.UnassignUser(c.Request.Context(), c.Param("user_id")); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unassign user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User unassigned successfully", nil)
+}
+
+// GetMySchedule godoc
+// @Summary Get my work schedule
+// @Description Get the work schedule that applies to the authenticated user
+// @Tags schedules
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=domain.WorkSchedule} "Work schedule retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "No work schedule applies"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/schedule [get]
+func (h *ScheduleHandler) GetMySchedule(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	schedule, err := h.scheduleUsecase.GetUserSchedule(c.Request.Context(), userID)
+	if err == domain.ErrScheduleNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get work schedule", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get work schedule", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Work schedule retrieved successfully", schedule)
+}
@@ -0,0 +1,377 @@
+package shift
+
+import (
+	"net/http"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils"
+	"golang-tes/internal/utils/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShiftHandler struct {
+	shiftUsecase domain.ShiftUsecase
+}
+
+func NewShiftHandler(shiftUsecase domain.ShiftUsecase) *ShiftHandler {
+	return &ShiftHandler{
+		shiftUsecase: shiftUsecase,
+	}
+}
+
+type shiftRequest struct {
+	Name               string `json:"name" binding:"required"`
+	StartTime          string `json:"start_time" binding:"required" example:"22:00"`
+	EndTime            string `json:"end_time" binding:"required" example:"06:00"`
+	GracePeriodMinutes int    `json:"grace_period_minutes" binding:"min=0"`
+}
+
+type rosterRequest struct {
+	ShiftID  string   `json:"shift_id" binding:"required"`
+	UserIDs  []string `json:"user_ids" binding:"required,min=1"`
+	From     string   `json:"from" binding:"required" example:"2024-07-01"`
+	To       string   `json:"to" binding:"required" example:"2024-07-14"`
+	WorkDays []int    `json:"work_days"` // 0 = Sunday ... 6 = Saturday; every day when empty
+}
+
+type rosterQuery struct {
+	From   string `form:"from" binding:"required"`
+	To     string `form:"to" binding:"required"`
+	UserID string `form:"user_id"`
+}
+
+func (r *shiftRequest) toShift() *domain.Shift {
+	return &domain.Shift{
+		Name:               r.Name,
+		StartTime:          r.StartTime,
+		EndTime:            r.EndTime,
+		GracePeriodMinutes: r.GracePeriodMinutes,
+	}
+}
+
+// parseRange parses a from and to date
+func parseRange(from, to string) (time.Time, time.Time, error) {
+	fromDate, err := time.Parse(domain.DateFormat, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	toDate, err := time.Parse(domain.DateFormat, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return fromDate, toDate, nil
+}
+
+// CreateShift godoc
+// @Summary Create a shift
+// @Description Create a shift template users can be rostered on. A shift ending at or before its start time runs past midnight (requires schedules:manage)
+// @Tags shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body shiftRequest true "Shift details"
+// @Success 201 {object} utils.Response{data=domain.Shift} "Shift created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shifts [post]
+func (h *ShiftHandler) CreateShift(c *gin.Context) {
+	var req shiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	shift := req.toShift()
+	if err := validator.ValidateShift(shift); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid shift", err.Error())
+		return
+	}
+
+	if err := h.shiftUsecase.CreateShift(c.Request.Context(), shift); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create shift", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Shift created successfully", shift)
+}
+
+// ListShifts godoc
+// @Summary List shifts
+// @Description List all shift templates (requires schedules:manage)
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]domain.Shift} "Shifts retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shifts [get]
+func (h *ShiftHandler) ListShifts(c *gin.Context) {
+	shifts, err := h.shiftUsecase.ListShifts(c.Request.Context())
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get shifts", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shifts retrieved successfully", shifts)
+}
+
+// GetShift godoc
+// @Summary Get a shift
+// @Description Get a shift template by ID (requires schedules:manage)
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift ID"
+// @Success 200 {object} utils.Response{data=domain.Shift} "Shift retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Shift not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shifts/{id} [get]
+func (h *ShiftHandler) GetShift(c *gin.Context) {
+	shift, err := h.shiftUsecase.GetShift(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrShiftNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get shift", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get shift", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shift retrieved successfully", shift)
+}
+
+// UpdateShift godoc
+// @Summary Update a shift
+// @Description Replace the details of a shift template. Rostered shifts not yet worked are evaluated against the new times (requires schedules:manage)
+// @Tags shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift ID"
+// @Param request body shiftRequest true "Shift details"
+// @Success 200 {object} utils.Response{data=domain.Shift} "Shift updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Shift not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shifts/{id} [put]
+func (h *ShiftHandler) UpdateShift(c *gin.Context) {
+	var req shiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	shift := req.toShift()
+	shift.ID = c.Param("id")
+	if err := validator.ValidateShift(shift); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid shift", err.Error())
+		return
+	}
+
+	err := h.shiftUsecase.UpdateShift(c.Request.Context(), shift)
+	if err == domain.ErrShiftNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update shift", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update shift", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shift updated successfully", shift)
+}
+
+// DeleteShift godoc
+// @Summary Delete a shift
+// @Description Delete a shift template nobody has been rostered on (requires schedules:manage)
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Shift ID"
+// @Success 200 {object} utils.Response "Shift deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Shift not found"
+// @Failure 409 {object} utils.Response "Shift is used by the roster"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/shifts/{id} [delete]
+func (h *ShiftHandler) DeleteShift(c *gin.Context) {
+	err := h.shiftUsecase.DeleteShift(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrShiftNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete shift", err.Error())
+		return
+	}
+	if err == domain.ErrShiftInUse {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to delete shift", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete shift", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Shift deleted successfully", nil)
+}
+
+// RosterUsers godoc
+// @Summary Roster users on a shift
+// @Description Roster users on a shift on every date of a range, optionally only on some weekdays. The date is the day the shift begins; a user works at most one shift per date. Nobody is rostered if any user is already rostered on one of the dates (requires schedules:manage)
+// @Tags shifts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body rosterRequest true "Shift, users and dates"
+// @Success 201 {object} utils.Response{data=[]domain.RosterEntry} "Users rostered successfully"
+// @Failure 400 {object} utils.Response "Invalid request, date range or weekdays"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Shift or user not found"
+// @Failure 409 {object} utils.Response "User already rostered on one of the dates"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roster [post]
+func (h *ShiftHandler) RosterUsers(c *gin.Context) {
+	var req rosterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	from, to, err := parseRange(req.From, req.To)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	entries, err := h.shiftUsecase.RosterUsers(c.Request.Context(), domain.RosterRequest{
+		ShiftID:  req.ShiftID,
+		UserIDs:  req.UserIDs,
+		From:     from,
+		To:       to,
+		WorkDays: req.WorkDays,
+	})
+	if err == domain.ErrInvalidDateRange || err == domain.ErrInvalidInput || err == domain.ErrNothingToRoster {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to roster users", err.Error())
+		return
+	}
+	if err == domain.ErrShiftNotFound || err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to roster users", err.Error())
+		return
+	}
+	if err == domain.ErrRosterConflict {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to roster users", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to roster users", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "Users rostered successfully", entries)
+}
+
+// GetRoster godoc
+// @Summary Get the roster
+// @Description Get the roster entries dated within a range, of everyone or of one user (requires schedules:manage)
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Param user_id query string false "Only the entries of this user"
+// @Success 200 {object} utils.Response{data=[]domain.RosterEntry} "Roster retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request or date range"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roster [get]
+func (h *ShiftHandler) GetRoster(c *gin.Context) {
+	var req rosterQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	h.getRoster(c, req.From, req.To, req.UserID)
+}
+
+// GetMyRoster godoc
+// @Summary Get my roster
+// @Description Get the shifts the authenticated user is rostered on within a range
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Param from query string true "Start date (YYYY-MM-DD)"
+// @Param to query string true "End date (YYYY-MM-DD)"
+// @Success 200 {object} utils.Response{data=[]domain.RosterEntry} "Roster retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request or date range"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/roster [get]
+func (h *ShiftHandler) GetMyRoster(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	var req rosterQuery
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+	h.getRoster(c, req.From, req.To, userID)
+}
+
+func (h *ShiftHandler) getRoster(c *gin.Context, fromValue, toValue, userID string) {
+	from, to, err := parseRange(fromValue, toValue)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid date format", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	entries, err := h.shiftUsecase.GetRoster(c.Request.Context(), from, to, userID)
+	if err == domain.ErrInvalidDateRange {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get roster", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get roster", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roster retrieved successfully", entries)
+}
+
+// DeleteRosterEntry godoc
+// @Summary Delete a roster entry
+// @Description Take a user off a rostered shift. An attendance record of the shift is kept but no longer linked to it (requires schedules:manage)
+// @Tags shifts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Roster entry ID"
+// @Success 200 {object} utils.Response "Roster entry deleted successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "Roster entry not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/roster/{id} [delete]
+func (h *ShiftHandler) DeleteRosterEntry(c *gin.Context) {
+	err := h.shiftUsecase.DeleteRosterEntry(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrRosterEntryNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete roster entry", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete roster entry", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Roster entry deleted successfully", nil)
+}
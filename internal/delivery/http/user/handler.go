@@ -1,7 +1,11 @@
 package user
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"golang-tes/internal/domain"
 	"golang-tes/internal/utils"
@@ -11,12 +15,14 @@ import (
 )
 
 type UserHandler struct {
-	userUsecase domain.UserUsecase
+	userUsecase         domain.UserUsecase
+	registrationEnabled bool
 }
 
-func NewUserHandler(userUsecase domain.UserUsecase) *UserHandler {
+func NewUserHandler(userUsecase domain.UserUsecase, registrationEnabled bool) *UserHandler {
 	return &UserHandler{
-		userUsecase: userUsecase,
+		userUsecase:         userUsecase,
+		registrationEnabled: registrationEnabled,
 	}
 }
 
@@ -24,7 +30,13 @@ type registerRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
-	Role     string `json:"role"`
+}
+
+type createUserRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=6"`
+	Role     string `json:"role" binding:"required" example:"admin"`
 }
 
 type loginRequest struct {
@@ -32,6 +44,54 @@ type loginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type verifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type verifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type setLocationRequest struct {
+	Location string `json:"location" binding:"max=100" example:"Jakarta"`
+}
+
+type listUsersRequest struct {
+	Search   string `form:"search"`
+	Role     string `form:"role"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1"`
+}
+
+type userListResponse struct {
+	Users    []domain.User `json:"users"`
+	Total    int           `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"page_size"`
+}
+
+type updateRoleRequest struct {
+	Role string `json:"role" binding:"required" example:"admin"`
+}
+
 type updateProfileRequest struct {
 	Name     string `json:"name"`
 	Email    string `json:"email" binding:"omitempty,email"`
@@ -40,17 +100,23 @@ type updateProfileRequest struct {
 
 // Register godoc
 // @Summary Register a new user
-// @Description Register a new user with the provided details
+// @Description Register a new user with the provided details. Registered users always get the user role
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param request body registerRequest true "User registration details"
 // @Success 201 {object} utils.Response "User registered successfully"
 // @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 403 {object} utils.Response "Registration disabled"
 // @Failure 409 {object} utils.Response "Email already exists"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /users/register [post]
 func (h *UserHandler) Register(c *gin.Context) {
+	if !h.registrationEnabled {
+		utils.ErrorResponse(c, http.StatusForbidden, "Registration failed", domain.ErrRegistrationOff.Error())
+		return
+	}
+
 	var req registerRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
@@ -74,15 +140,6 @@ func (h *UserHandler) Register(c *gin.Context) {
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		Role:     domain.RoleUser,
-	}
-
-	if req.Role != "" {
-		if err := validator.ValidateUserRole(req.Role); err != nil {
-			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid role", err.Error())
-			return
-		}
-		user.Role = req.Role
 	}
 
 	err := h.userUsecase.Register(c.Request.Context(), user)
@@ -100,14 +157,16 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary Login user
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return a short-lived access token and a refresh token. With two-factor authentication enabled, an mfa_token is returned instead, to be exchanged at /users/login/mfa
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param request body loginRequest true "User login credentials"
-// @Success 200 {object} utils.Response{data=map[string]string{token=string}} "Login successful"
+// @Success 200 {object} utils.Response{data=domain.LoginResult} "Login successful or MFA code required"
 // @Failure 400 {object} utils.Response "Invalid request"
 // @Failure 401 {object} utils.Response "Invalid credentials"
+// @Failure 403 {object} utils.Response "Account deactivated"
+// @Failure 429 {object} utils.Response "Too many failed attempts, retry after the number of seconds in the Retry-After header"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /users/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
@@ -122,17 +181,269 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.userUsecase.Login(c.Request.Context(), req.Email, req.Password)
+	result, err := h.userUsecase.Login(c.Request.Context(), req.Email, req.Password, c.ClientIP())
+	if respondLocked(c, "Login failed", err) {
+		return
+	}
 	if err == domain.ErrInvalidCredentials {
 		utils.ErrorResponse(c, http.StatusUnauthorized, "Login failed", err.Error())
 		return
 	}
+	if err == domain.ErrUserInactive {
+		utils.ErrorResponse(c, http.StatusForbidden, "Login failed", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Login failed", err.Error())
+		return
+	}
+
+	if result.MFARequired {
+		utils.SuccessResponse(c, http.StatusOK, "Two-factor authentication code required", result)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", result)
+}
+
+// VerifyMFA godoc
+// @Summary Complete a two-factor login
+// @Description Exchange the mfa_token from /users/login and a TOTP or recovery code for an access token and a refresh token
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body verifyMFARequest true "MFA token and code"
+// @Success 200 {object} utils.Response{data=domain.TokenPair} "Login successful"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Invalid or expired MFA token or invalid code"
+// @Failure 429 {object} utils.Response "Too many failed attempts, retry after the number of seconds in the Retry-After header"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/login/mfa [post]
+func (h *UserHandler) VerifyMFA(c *gin.Context) {
+	var req verifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	tokens, err := h.userUsecase.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code)
+	if respondLocked(c, "Login failed", err) {
+		return
+	}
+	if err == domain.ErrInvalidMFAToken || err == domain.ErrInvalidMFACode {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Login failed", err.Error())
+		return
+	}
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Login failed", err.Error())
 		return
 	}
 
-	utils.SuccessResponse(c, http.StatusOK, "Login successful", gin.H{"token": token})
+	utils.SuccessResponse(c, http.StatusOK, "Login successful", tokens)
+}
+
+// respondLocked answers a login lockout with 429 and a Retry-After header.
+// It reports whether err was a lockout.
+func respondLocked(c *gin.Context, message string, err error) bool {
+	var lockout *domain.LockoutError
+	if !errors.As(err, &lockout) {
+		return false
+	}
+	seconds := (lockout.RetryAfter + time.Second - 1) / time.Second
+	c.Header("Retry-After", strconv.Itoa(int(seconds)))
+	utils.ErrorResponse(c, http.StatusTooManyRequests, message, err.Error())
+	return true
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes the whole session
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body refreshRequest true "Refresh token"
+// @Success 200 {object} utils.Response{data=domain.TokenPair} "Tokens refreshed successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Invalid, expired or reused refresh token"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/refresh [post]
+func (h *UserHandler) Refresh(c *gin.Context) {
+	var req refreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	tokens, err := h.userUsecase.Refresh(c.Request.Context(), req.RefreshToken)
+	if err == domain.ErrInvalidToken || err == domain.ErrTokenReused {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Failed to refresh tokens", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to refresh tokens", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Tokens refreshed successfully", tokens)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the current access token and, if given, the refresh token's session
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body logoutRequest false "Refresh token of the session to end"
+// @Success 200 {object} utils.Response "Logout successful"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+	var req logoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+			return
+		}
+	}
+
+	err := h.userUsecase.Logout(c.Request.Context(), c.GetString("token_id"), c.GetTime("token_expires_at"), req.RefreshToken)
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Logout failed", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Logout successful", nil)
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single-use password reset link. The response is the same whether or not the email is registered
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body forgotPasswordRequest true "Account email"
+// @Success 200 {object} utils.Response "Reset link sent if the account exists"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/forgot-password [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	var req forgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	if err := h.userUsecase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to request password reset", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "If the email is registered, a reset link has been sent", nil)
+}
+
+// ResetPassword godoc
+// @Summary Reset password
+// @Description Set a new password with the token from a reset email. All existing sessions are ended
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body resetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} utils.Response "Password reset successfully"
+// @Failure 400 {object} utils.Response "Invalid request or reset token"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/reset-password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req resetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	if err := validator.ValidatePassword(req.Password); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid password", err.Error())
+		return
+	}
+
+	err := h.userUsecase.ResetPassword(c.Request.Context(), req.Token, req.Password)
+	if err == domain.ErrInvalidReset {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to reset password", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to reset password", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Password reset successfully", nil)
+}
+
+// VerifyEmail godoc
+// @Summary Verify email address
+// @Description Confirm an email address with the token from a verification email. A pending email change takes effect once confirmed
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body verifyEmailRequest true "Verification token"
+// @Success 200 {object} utils.Response{data=domain.User} "Email verified successfully"
+// @Failure 400 {object} utils.Response "Invalid request or verification token"
+// @Failure 409 {object} utils.Response "Email already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/verify-email [post]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	var req verifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	user, err := h.userUsecase.VerifyEmail(c.Request.Context(), req.Token)
+	if err == domain.ErrInvalidVerification {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to verify email", err.Error())
+		return
+	}
+	if err == domain.ErrEmailExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to verify email", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify email", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Email verified successfully", user)
+}
+
+// ResendVerification godoc
+// @Summary Resend verification email
+// @Description Send a new verification link to the pending email, or to the current email if it is not verified yet
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "Verification email sent"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Email already verified"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/verify-email/resend [post]
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		utils.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized", domain.ErrUnauthorized.Error())
+		return
+	}
+
+	err := h.userUsecase.ResendVerification(c.Request.Context(), userID)
+	if err == domain.ErrAlreadyVerified {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to resend verification email", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to resend verification email", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Verification email sent", nil)
 }
 
 // GetProfile godoc
@@ -163,7 +474,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 
 // UpdateProfile godoc
 // @Summary Update user profile
-// @Description Update the profile of the authenticated user
+// @Description Update the profile of the authenticated user. A new email is only used after it is verified through the link sent to it
 // @Tags users
 // @Accept json
 // @Produce json
@@ -172,6 +483,7 @@ func (h *UserHandler) GetProfile(c *gin.Context) {
 // @Success 200 {object} utils.Response "Profile updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Email already exists"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /users/profile [put]
 func (h *UserHandler) UpdateProfile(c *gin.Context) {
@@ -195,6 +507,10 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	err := h.userUsecase.UpdateProfile(c.Request.Context(), user)
+	if err == domain.ErrEmailExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to update profile", err.Error())
+		return
+	}
 	if err != nil {
 		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update profile", err.Error())
 		return
@@ -202,3 +518,354 @@ func (h *UserHandler) UpdateProfile(c *gin.Context) {
 
 	utils.SuccessResponse(c, http.StatusOK, "Profile updated successfully", nil)
 }
+
+// SetLocation godoc
+// @Summary Set a user's location
+// @Description Set the location that decides which location-specific holidays apply to the user. Leave empty for company-wide holidays only. Requires users:manage and a role covering the user's; nobody sets their own location.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body setLocationRequest true "Location"
+// @Success 200 {object} utils.Response{data=domain.User} "Location updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/location [put]
+func (h *UserHandler) SetLocation(c *gin.Context) {
+	var req setLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	user, err := h.userUsecase.SetLocation(c.Request.Context(), c.GetString("user_id"), c.Param("id"), strings.TrimSpace(req.Location))
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update location", err.Error())
+		return
+	}
+	if err == domain.ErrSelfManagement {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update location", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to update location", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update location", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Location updated successfully", user)
+}
+
+// ListUsers godoc
+// @Summary List users
+// @Description List users page by page, optionally searching name and email and filtering by role (requires users:read)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param search query string false "Text to search for in name or email"
+// @Param role query string false "Role filter"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Users per page (default 20, max 100)"
+// @Success 200 {object} utils.Response{data=userListResponse} "Users retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	var req listUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	filter := domain.UserFilter{
+		Search:   strings.TrimSpace(req.Search),
+		Role:     req.Role,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	filter.Normalize()
+
+	users, total, err := h.userUsecase.ListUsers(c.Request.Context(), filter)
+	if err == domain.ErrInvalidRole {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to get users", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get users", err.Error())
+		return
+	}
+
+	if users == nil {
+		users = []domain.User{}
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Users retrieved successfully", userListResponse{
+		Users:    users,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	})
+}
+
+// CreateUser godoc
+// @Summary Create a user
+// @Description Create an account with any role, e.g. another admin (requires users:manage)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body createUserRequest true "User details"
+// @Success 201 {object} utils.Response{data=domain.User} "User created successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 409 {object} utils.Response "Email already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	if err := validator.ValidateEmail(req.Email); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid email", err.Error())
+		return
+	}
+	if err := validator.ValidatePassword(req.Password); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid password", err.Error())
+		return
+	}
+	if err := validator.ValidateName(req.Name); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid name", err.Error())
+		return
+	}
+	user := &domain.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+		Role:     req.Role,
+	}
+
+	err := h.userUsecase.CreateUser(c.Request.Context(), c.GetString("user_id"), user)
+	if err == domain.ErrInvalidRole {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to create user", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to create user", err.Error())
+		return
+	}
+	if err == domain.ErrEmailExists {
+		utils.ErrorResponse(c, http.StatusConflict, "Failed to create user", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusCreated, "User created successfully", user)
+}
+
+// GetUser godoc
+// @Summary Get a user
+// @Description Get any user's account (requires users:read)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response{data=domain.User} "User retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	user, err := h.userUsecase.GetProfile(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to get user", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to get user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User retrieved successfully", user)
+}
+
+// UpdateRole godoc
+// @Summary Change a user's role
+// @Description Change the role of another user's account (requires roles:manage)
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param request body updateRoleRequest true "New role"
+// @Success 200 {object} utils.Response{data=domain.User} "Role updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/role [put]
+func (h *UserHandler) UpdateRole(c *gin.Context) {
+	var req updateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Invalid request", domain.ErrInvalidInput.Error())
+		return
+	}
+
+	user, err := h.userUsecase.UpdateRole(c.Request.Context(), c.GetString("user_id"), c.Param("id"), req.Role)
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to update role", err.Error())
+		return
+	}
+	if err == domain.ErrInvalidRole || err == domain.ErrSelfManagement {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to update role", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to update role", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update role", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Role updated successfully", user)
+}
+
+// DeactivateUser godoc
+// @Summary Deactivate a user
+// @Description Block another user from logging in and reject their existing tokens (requires users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response{data=domain.User} "User deactivated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/deactivate [post]
+func (h *UserHandler) DeactivateUser(c *gin.Context) {
+	h.setActive(c, false, "User deactivated successfully", "Failed to deactivate user")
+}
+
+// ActivateUser godoc
+// @Summary Reactivate a user
+// @Description Allow a deactivated user to log in again (requires users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response{data=domain.User} "User activated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/activate [post]
+func (h *UserHandler) ActivateUser(c *gin.Context) {
+	h.setActive(c, true, "User activated successfully", "Failed to activate user")
+}
+
+func (h *UserHandler) setActive(c *gin.Context, active bool, successMessage, failureMessage string) {
+	user, err := h.userUsecase.SetActive(c.Request.Context(), c.GetString("user_id"), c.Param("id"), active)
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrSelfManagement {
+		utils.ErrorResponse(c, http.StatusBadRequest, failureMessage, err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, failureMessage, err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, failureMessage, err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, successMessage, user)
+}
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Description Delete another user's account together with their attendance, schedule assignment, leave and correction records. Attendance history is kept (requires users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response "User deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	err := h.userUsecase.DeleteUser(c.Request.Context(), c.GetString("user_id"), c.Param("id"))
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to delete user", err.Error())
+		return
+	}
+	if err == domain.ErrSelfManagement {
+		utils.ErrorResponse(c, http.StatusBadRequest, "Failed to delete user", err.Error())
+		return
+	}
+	if err == domain.ErrPermissionDenied {
+		utils.ErrorResponse(c, http.StatusForbidden, "Failed to delete user", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User deleted successfully", nil)
+}
+
+// UnlockUser godoc
+// @Summary Unlock a user's login
+// @Description Clear the failed login count and lockout of a user's account. Lockouts of client IPs expire on their own (requires users:manage)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} utils.Response "User unlocked successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Permission denied"
+// @Failure 404 {object} utils.Response "User not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	err := h.userUsecase.Unlock(c.Request.Context(), c.Param("id"))
+	if err == domain.ErrUserNotFound {
+		utils.ErrorResponse(c, http.StatusNotFound, "Failed to unlock user", err.Error())
+		return
+	}
+	if err != nil {
+		utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to unlock user", err.Error())
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "User unlocked successfully", nil)
+}
@@ -5,25 +5,217 @@ import (
 	"time"
 )
 
+// Attendance is a user's record of a day. A record of a rostered shift is
+// linked to the roster entry and dated on the day the shift begins, so an
+// overnight shift is a single record. The worked time excludes the breaks.
+// Its overtime is computed from the worked time by the OvertimePolicy and
+// reviewed by the user's managers.
 type Attendance struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Date      time.Time `json:"date"`
-	Status    string    `json:"status"` // e.g., "present", "absent", "late"
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                  string            `json:"id"`
+	UserID              string            `json:"user_id"`
+	Date                time.Time         `json:"date"`
+	RosterEntryID       string            `json:"roster_entry_id,omitempty"` // the rostered shift the record belongs to
+	Status              string            `json:"status"`                    // e.g., "present", "absent", "late"
+	ClockIn             *time.Time        `json:"clock_in,omitempty"`
+	ClockOut            *time.Time        `json:"clock_out,omitempty"`
+	WorkedMinutes       int               `json:"worked_minutes"`
+	BreakMinutes        int               `json:"break_minutes"`
+	MissingBreakMinutes int               `json:"missing_break_minutes,omitempty"` // break time short of the mandatory break rules
+	Breaks              []AttendanceBreak `json:"breaks,omitempty"`                // only loaded for the user's own listing
+	OvertimeMinutes     int               `json:"overtime_minutes"`
+	OvertimeStatus      string            `json:"overtime_status,omitempty"` // "pending", "approved" or "rejected" when there is overtime
+	OvertimeReviewedBy  string            `json:"overtime_reviewed_by,omitempty"`
+	OvertimeReviewedAt  *time.Time        `json:"overtime_reviewed_at,omitempty"`
+	OvertimeReviewNote  string            `json:"overtime_review_note,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+// SetOvertime sets the overtime of the record. Changed overtime has to be
+// reviewed again.
+func (a *Attendance) SetOvertime(minutes int) {
+	if minutes == a.OvertimeMinutes && (minutes == 0 || a.OvertimeStatus != "") {
+		return
+	}
+	a.OvertimeMinutes = minutes
+	a.OvertimeStatus = ""
+	if minutes > 0 {
+		a.OvertimeStatus = OvertimeStatusPending
+	}
+	a.OvertimeReviewedBy = ""
+	a.OvertimeReviewedAt = nil
+	a.OvertimeReviewNote = ""
+}
+
+// OvertimePolicy sets the worked time after which work counts as overtime.
+// A zero threshold is not applied.
+type OvertimePolicy struct {
+	DailyMinutes  int
+	WeeklyMinutes int
+}
+
+// WeekOvertime splits the worked minutes of the days of a week, in order,
+// into overtime. Time beyond the daily threshold is overtime, and so is the
+// remaining time once the week's total passes the weekly threshold.
+func (p OvertimePolicy) WeekOvertime(worked []int) []int {
+	overtime := make([]int, len(worked))
+	regularSoFar := 0
+	for i, minutes := range worked {
+		regular := minutes
+		if p.DailyMinutes > 0 && regular > p.DailyMinutes {
+			regular = p.DailyMinutes
+		}
+		overtime[i] = minutes - regular
+		if p.WeeklyMinutes > 0 {
+			beyondWeek := regularSoFar + regular - p.WeeklyMinutes
+			if beyondWeek > regular {
+				beyondWeek = regular
+			}
+			if beyondWeek > 0 {
+				overtime[i] += beyondWeek
+				regular -= beyondWeek
+			}
+		}
+		regularSoFar += regular
+	}
+	return overtime
+}
+
+// AttendanceHistory preserves the values of an attendance record before a change
+type AttendanceHistory struct {
+	ID            string     `json:"id"`
+	AttendanceID  string     `json:"attendance_id"`
+	UserID        string     `json:"user_id"`
+	Status        string     `json:"status"`
+	ClockIn       *time.Time `json:"clock_in,omitempty"`
+	ClockOut      *time.Time `json:"clock_out,omitempty"`
+	WorkedMinutes int        `json:"worked_minutes"`
+	Action        string     `json:"action"`                  // "correction", "update" or "delete"
+	CorrectionID  string     `json:"correction_id,omitempty"` // set when the change came from a correction request
+	ChangedBy     string     `json:"changed_by,omitempty"`
+	ChangedAt     time.Time  `json:"changed_at"`
+}
+
+// Attendance sort fields. Sort is a field, optionally prefixed with "-" for
+// descending order.
+const (
+	AttendanceSortDate          = "date"
+	AttendanceSortStatus        = "status"
+	AttendanceSortClockIn       = "clock_in"
+	AttendanceSortWorkedMinutes = "worked_minutes"
+)
+
+// ValidAttendanceSortFields contains the fields attendance listings can be sorted by
+var ValidAttendanceSortFields = map[string]bool{
+	AttendanceSortDate:          true,
+	AttendanceSortStatus:        true,
+	AttendanceSortClockIn:       true,
+	AttendanceSortWorkedMinutes: true,
+}
+
+// AttendanceFilter selects a page of attendance records. Zero dates leave the
+// range open on that side.
+type AttendanceFilter struct {
+	UserIDs        []string // empty selects every user
+	From           time.Time
+	To             time.Time
+	Status         string
+	OvertimeStatus string
+	BreakViolation bool // only records short of the mandatory break rules
+	Sort           string
+	Page           int
+	PageSize       int
+}
+
+// Normalize applies the default sort, newest first, and the page defaults
+func (f *AttendanceFilter) Normalize() {
+	if f.Sort == "" {
+		f.Sort = "-" + AttendanceSortDate
+	}
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = DefaultPageSize
+	}
+	if f.PageSize > MaxPageSize {
+		f.PageSize = MaxPageSize
+	}
+}
+
+// SortField returns the field to sort by and whether the order is descending
+func (f *AttendanceFilter) SortField() (string, bool) {
+	if len(f.Sort) > 0 && f.Sort[0] == '-' {
+		return f.Sort[1:], true
+	}
+	return f.Sort, false
+}
+
+// AttendanceExportRow is an attendance record with the name and email of its user
+type AttendanceExportRow struct {
+	Attendance
+	UserName  string
+	UserEmail string
 }
 
 type AttendanceRepository interface {
 	Create(ctx context.Context, attendance *Attendance) error
+	GetByID(ctx context.Context, id string) (*Attendance, error)
 	GetByDate(ctx context.Context, date time.Time) ([]Attendance, error)
 	GetByUserID(ctx context.Context, userID string) ([]Attendance, error)
 	GetByUserIDAndDate(ctx context.Context, userID string, date time.Time) (*Attendance, error)
+	GetInRange(ctx context.Context, from, to time.Time) ([]Attendance, error)
+	// List returns the records on the filter's page and the total number of matching records
+	List(ctx context.Context, filter AttendanceFilter) ([]Attendance, int, error)
+	// Export passes every record matching the filter, ignoring its page, to
+	// fn while reading them. An error from fn stops the export.
+	Export(ctx context.Context, filter AttendanceFilter, fn func(row AttendanceExportRow) error) error
 	Update(ctx context.Context, attendance *Attendance) error
+	Delete(ctx context.Context, id string) error
+	AddHistory(ctx context.Context, history *AttendanceHistory) error
+	GetHistory(ctx context.Context, attendanceID string) ([]AttendanceHistory, error)
 }
 
 type AttendanceUsecase interface {
 	MarkAttendance(ctx context.Context, attendance *Attendance) error
-	GetAttendanceByDate(ctx context.Context, date time.Time) ([]Attendance, error)
-	GetUserAttendance(ctx context.Context, userID string) ([]Attendance, error)
+	ClockIn(ctx context.Context, userID string) (*Attendance, error)
+	ClockOut(ctx context.Context, userID string) (*Attendance, error)
+	// ListAttendance and GetUserAttendance return a page of the records within
+	// the caller's scope: everyone's with attendance:read:all, otherwise their
+	// own and those of their direct and indirect reports
+	ListAttendance(ctx context.Context, callerID string, filter AttendanceFilter) ([]Attendance, int, error)
+	GetUserAttendance(ctx context.Context, callerID, userID string, filter AttendanceFilter) ([]Attendance, int, error)
+	// ExportAttendance streams all records within the caller's scope that
+	// match the filter to fn. Nothing is passed to fn if the filter or
+	// caller is rejected.
+	ExportAttendance(ctx context.Context, callerID string, filter AttendanceFilter, fn func(row AttendanceExportRow) error) error
+	// ApproveOvertime and RejectOvertime review the pending overtime of a
+	// record. Reviewers need overtime:approve or must manage the record's
+	// user; nobody reviews their own overtime.
+	ApproveOvertime(ctx context.Context, reviewerID, id, note string) (*Attendance, error)
+	RejectOvertime(ctx context.Context, reviewerID, id, note string) (*Attendance, error)
+	// StartBreak and EndBreak record a break of the user's current workday
+	StartBreak(ctx context.Context, userID, breakType string) (*AttendanceBreak, error)
+	EndBreak(ctx context.Context, userID string) (*AttendanceBreak, error)
+	// AddBreak and DeleteBreak change the breaks of one of the user's own
+	// records. Breaks fall between clock-in and clock-out and do not overlap.
+	AddBreak(ctx context.Context, userID string, attendanceBreak *AttendanceBreak) (*Attendance, error)
+	DeleteBreak(ctx context.Context, userID, attendanceID, breakID string) (*Attendance, error)
+	// MarkAbsences creates absent records for users expected at work on the
+	// date who have no record. Users rostered in the week of the date are only
+	// expected on their shifts, once the shifts have ended.
+	MarkAbsences(ctx context.Context, date time.Time) (int, error)
+	// MarkShiftAbsences creates absent records for the shifts rostered on the
+	// day of now or the day before that have ended without a record
+	MarkShiftAbsences(ctx context.Context, now time.Time) (int, error)
+	BackfillAbsences(ctx context.Context, from, to time.Time) (int, error)
+
+	// Admin management of any user's records. Updates and deletes keep the
+	// previous values in the record's history.
+	CreateAttendance(ctx context.Context, attendance *Attendance) error
+	UpdateAttendance(ctx context.Context, attendance *Attendance, changedBy string) error
+	DeleteAttendance(ctx context.Context, id, changedBy string) error
+	// ListUserAttendance returns a page of any user's records
+	ListUserAttendance(ctx context.Context, userID string, filter AttendanceFilter) ([]Attendance, int, error)
+	ListAttendanceInRange(ctx context.Context, from, to time.Time) ([]Attendance, error)
 }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AttendanceBreak is a break taken during the workday of an attendance record.
+// A break without an end is still in progress.
+type AttendanceBreak struct {
+	ID           string     `json:"id"`
+	AttendanceID string     `json:"attendance_id"`
+	Type         string     `json:"type" example:"lunch"`
+	StartTime    time.Time  `json:"start_time"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// Minutes returns the whole minutes of a finished break, 0 while in progress
+func (b *AttendanceBreak) Minutes() int {
+	if b.EndTime == nil {
+		return 0
+	}
+	return int(b.EndTime.Sub(b.StartTime).Minutes())
+}
+
+// Overlaps reports whether the breaks share any time. Breaks in progress
+// last until further notice.
+func (b *AttendanceBreak) Overlaps(other *AttendanceBreak) bool {
+	startsBeforeOtherEnds := other.EndTime == nil || b.StartTime.Before(*other.EndTime)
+	endsAfterOtherStarts := b.EndTime == nil || b.EndTime.After(other.StartTime)
+	return startsBeforeOtherEnds && endsAfterOtherStarts
+}
+
+// BreakRule requires a minimum total break once the net worked time of a day
+// exceeds a threshold, e.g. 30 minutes after 6 hours
+type BreakRule struct {
+	AfterMinutes   int `json:"after_minutes"`
+	MinimumMinutes int `json:"minimum_minutes"`
+}
+
+// BreakRules are the mandatory break rules of every workday
+type BreakRules []BreakRule
+
+// ParseBreakRules parses comma-separated after:minimum durations, e.g.
+// "6h:30m,9h:45m". An empty spec has no rules.
+func ParseBreakRules(spec string) (BreakRules, error) {
+	var rules BreakRules
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("break rule %q: expected after:minimum", entry)
+		}
+		after, err := time.ParseDuration(strings.TrimSpace(parts[0]))
+		if err != nil || after <= 0 {
+			return nil, fmt.Errorf("break rule %q: invalid worked time", entry)
+		}
+		minimum, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil || minimum <= 0 {
+			return nil, fmt.Errorf("break rule %q: invalid break length", entry)
+		}
+		rules = append(rules, BreakRule{AfterMinutes: int(after.Minutes()), MinimumMinutes: int(minimum.Minutes())})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].AfterMinutes < rules[j].AfterMinutes
+	})
+	return rules, nil
+}
+
+// MissingMinutes returns how many minutes of break a day with the given net
+// worked and break time falls short of the rules
+func (r BreakRules) MissingMinutes(workedMinutes, breakMinutes int) int {
+	required := 0
+	for _, rule := range r {
+		if workedMinutes > rule.AfterMinutes && rule.MinimumMinutes > required {
+			required = rule.MinimumMinutes
+		}
+	}
+	if breakMinutes >= required {
+		return 0
+	}
+	return required - breakMinutes
+}
+
+type AttendanceBreakRepository interface {
+	Create(ctx context.Context, attendanceBreak *AttendanceBreak) error
+	GetByID(ctx context.Context, id string) (*AttendanceBreak, error)
+	// GetByAttendanceIDs returns the breaks of the records, ordered by start time
+	GetByAttendanceIDs(ctx context.Context, attendanceIDs []string) ([]AttendanceBreak, error)
+	Update(ctx context.Context, attendanceBreak *AttendanceBreak) error
+	Delete(ctx context.Context, id string) error
+}
@@ -1,7 +1,7 @@
 package domain
 
 const (
-	// User roles
+	// Built-in user roles. More roles can be defined at runtime.
 	RoleAdmin = "admin"
 	RoleUser  = "user"
 
@@ -9,6 +9,42 @@ const (
 	StatusPresent = "present"
 	StatusAbsent  = "absent"
 	StatusLate    = "late"
+	StatusLeave   = "leave"
+
+	// Leave types
+	LeaveTypeAnnual = "annual"
+	LeaveTypeSick   = "sick"
+	LeaveTypeUnpaid = "unpaid"
+
+	// Leave request status
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+
+	// Overtime review status
+	OvertimeStatusPending  = "pending"
+	OvertimeStatusApproved = "approved"
+	OvertimeStatusRejected = "rejected"
+
+	// Break types
+	BreakTypeLunch = "lunch"
+	BreakTypeRest  = "rest"
+	BreakTypeOther = "other"
+
+	// Attendance correction status
+	CorrectionStatusPending   = "pending"
+	CorrectionStatusApproved  = "approved"
+	CorrectionStatusRejected  = "rejected"
+	CorrectionStatusCancelled = "cancelled"
+
+	// Attendance history actions
+	HistoryActionCorrection = "correction"
+	HistoryActionUpdate     = "update"
+	HistoryActionDelete     = "delete"
+
+	// One-time token purposes
+	TokenPurposePasswordReset = "password_reset"
 
 	// Validation constants
 	MinPasswordLength = 6
@@ -16,9 +52,38 @@ const (
 	MaxNameLength     = 255
 	MaxEmailLength    = 255
 
+	// MaxBackfillDays limits the date range of a manual absence backfill
+	MaxBackfillDays = 366
+
+	// MaxAttendanceRangeDays limits the date range of an attendance listing
+	MaxAttendanceRangeDays = 366
+
+	// Pagination defaults for list endpoints
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	// MaxHolidayEventDays limits how many days a single imported calendar event may cover
+	MaxHolidayEventDays = 31
+
+	// Export formats
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+
+	// Payroll export formats
+	PayrollFormatCSV        = "csv"
+	PayrollFormatFixedWidth = "fixed"
+
+	// MaxPayrollPeriodDays limits the length of a pay period
+	MaxPayrollPeriodDays = 31
+
+	// MaxRosterDays limits the date range rostered in one request
+	MaxRosterDays = 62
+
 	// Time formats
-	DateFormat     = "2006-01-02"
-	DateTimeFormat = "2006-01-02 15:04:05"
+	DateFormat      = "2006-01-02"
+	DateTimeFormat  = "2006-01-02 15:04:05"
+	TimeOfDayFormat = "15:04"
+	MonthFormat     = "2006-01"
 )
 
 // ValidAttendanceStatuses contains all valid attendance statuses
@@ -26,10 +91,40 @@ var ValidAttendanceStatuses = map[string]bool{
 	StatusPresent: true,
 	StatusAbsent:  true,
 	StatusLate:    true,
+	StatusLeave:   true,
+}
+
+// ValidOvertimeStatuses contains all valid overtime review statuses
+var ValidOvertimeStatuses = map[string]bool{
+	OvertimeStatusPending:  true,
+	OvertimeStatusApproved: true,
+	OvertimeStatusRejected: true,
+}
+
+// ValidBreakTypes contains all valid break types
+var ValidBreakTypes = map[string]bool{
+	BreakTypeLunch: true,
+	BreakTypeRest:  true,
+	BreakTypeOther: true,
+}
+
+// CorrectableAttendanceStatuses contains the statuses a correction may propose.
+// Leave is only recorded through approved leave requests.
+var CorrectableAttendanceStatuses = map[string]bool{
+	StatusPresent: true,
+	StatusAbsent:  true,
+	StatusLate:    true,
+}
+
+// ValidLeaveTypes contains all valid leave types
+var ValidLeaveTypes = map[string]bool{
+	LeaveTypeAnnual: true,
+	LeaveTypeSick:   true,
+	LeaveTypeUnpaid: true,
 }
 
-// ValidUserRoles contains all valid user roles
-var ValidUserRoles = map[string]bool{
-	RoleAdmin: true,
-	RoleUser:  true,
+// BalanceTrackedLeaveTypes contains the leave types deducted from a yearly balance
+var BalanceTrackedLeaveTypes = map[string]bool{
+	LeaveTypeAnnual: true,
+	LeaveTypeSick:   true,
 }
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AttendanceCorrection is an employee's request to change one of their attendance records.
+// Empty proposed values leave the current value unchanged.
+type AttendanceCorrection struct {
+	ID               string     `json:"id"`
+	AttendanceID     string     `json:"attendance_id"`
+	UserID           string     `json:"user_id"`
+	ProposedStatus   string     `json:"proposed_status,omitempty"`
+	ProposedClockIn  *time.Time `json:"proposed_clock_in,omitempty"`
+	ProposedClockOut *time.Time `json:"proposed_clock_out,omitempty"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"` // "pending", "approved", "rejected" or "cancelled"
+	ReviewedBy       string     `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote       string     `json:"review_note,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type AttendanceCorrectionRepository interface {
+	Create(ctx context.Context, correction *AttendanceCorrection) error
+	GetByID(ctx context.Context, id string) (*AttendanceCorrection, error)
+	GetByUserID(ctx context.Context, userID string) ([]AttendanceCorrection, error)
+	GetByStatus(ctx context.Context, status string) ([]AttendanceCorrection, error)
+	GetPendingByAttendanceID(ctx context.Context, attendanceID string) (*AttendanceCorrection, error)
+	Update(ctx context.Context, correction *AttendanceCorrection) error
+}
+
+type AttendanceCorrectionUsecase interface {
+	RequestCorrection(ctx context.Context, correction *AttendanceCorrection) error
+	CancelCorrection(ctx context.Context, userID, id string) error
+	// ApproveCorrection applies the proposed values and preserves the previous ones in the record's history
+	ApproveCorrection(ctx context.Context, id, reviewerID, note string) (*AttendanceCorrection, error)
+	RejectCorrection(ctx context.Context, id, reviewerID, note string) (*AttendanceCorrection, error)
+	GetUserCorrections(ctx context.Context, userID string) ([]AttendanceCorrection, error)
+	GetCorrectionsByStatus(ctx context.Context, callerID, status string) ([]AttendanceCorrection, error)
+	// GetHistory returns the history of an attendance record. A non-empty ownerID
+	// restricts access to that user's own records.
+	GetHistory(ctx context.Context, attendanceID, ownerID string) ([]AttendanceHistory, error)
+}
@@ -7,16 +7,61 @@ var (
 	ErrNotFound           = errors.New("resource not found")
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrUnauthorized       = errors.New("unauthorized")
+	ErrPermissionDenied   = errors.New("permission denied")
 	ErrInvalidInput       = errors.New("invalid input")
 	ErrConflict           = errors.New("resource already exists")
+	ErrInvalidDateRange   = errors.New("invalid date range")
 )
 
 // User specific errors
 var (
-	ErrUserNotFound    = errors.New("user not found")
-	ErrEmailExists     = errors.New("email already registered")
-	ErrInvalidPassword = errors.New("invalid password")
-	ErrInvalidEmail    = errors.New("invalid email format")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrEmailExists         = errors.New("email already registered")
+	ErrInvalidPassword     = errors.New("invalid password")
+	ErrInvalidEmail        = errors.New("invalid email format")
+	ErrInvalidRole         = errors.New("invalid user role")
+	ErrUserInactive        = errors.New("user account is deactivated")
+	ErrRegistrationOff     = errors.New("public registration is disabled")
+	ErrInvalidToken        = errors.New("invalid or expired refresh token")
+	ErrInvalidReset        = errors.New("invalid or expired password reset token")
+	ErrInvalidVerification = errors.New("invalid or expired email verification link")
+	ErrEmailNotVerified    = errors.New("email address is not verified")
+	ErrAlreadyVerified     = errors.New("email address is already verified")
+	ErrTokenReused         = errors.New("refresh token reuse detected, the session has been revoked")
+	ErrTooManyAttempts     = errors.New("too many failed login attempts, try again later")
+	ErrSelfManagement      = errors.New("you cannot change the role, status or location of your own account or delete it")
+)
+
+// Role specific errors
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleExists        = errors.New("role already exists")
+	ErrRoleInUse         = errors.New("role is assigned to users")
+	ErrSystemRole        = errors.New("built-in roles cannot be deleted and the admin role cannot be changed")
+	ErrInvalidRoleName   = errors.New("role names must be 2-50 lowercase letters, digits, dashes or underscores, starting with a letter")
+	ErrInvalidPermission = errors.New("invalid permission")
+)
+
+// Organization specific errors
+var (
+	ErrDepartmentNotFound  = errors.New("department not found")
+	ErrDepartmentExists    = errors.New("department already exists")
+	ErrDepartmentHasTeams  = errors.New("department still has teams")
+	ErrTeamNotFound        = errors.New("team not found")
+	ErrTeamExists          = errors.New("team already exists in this department")
+	ErrTeamNotInDepartment = errors.New("team does not belong to the department")
+	ErrManagerNotFound     = errors.New("manager not found")
+	ErrManagerCycle        = errors.New("a user cannot report to themselves or to one of their reports")
+)
+
+// Two-factor authentication specific errors
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFANotSetUp       = errors.New("two-factor authentication setup has not been started")
+	ErrMFARequired       = errors.New("two-factor authentication is required for your role")
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired two-factor authentication challenge")
 )
 
 // Attendance specific errors
@@ -24,6 +69,78 @@ var (
 	ErrAttendanceNotFound      = errors.New("attendance not found")
 	ErrAttendanceAlreadyMarked = errors.New("attendance already marked for today")
 	ErrInvalidAttendanceStatus = errors.New("invalid attendance status")
+	ErrAlreadyClockedIn        = errors.New("already clocked in for today")
+	ErrAlreadyClockedOut       = errors.New("already clocked out for today")
+	ErrNotClockedIn            = errors.New("not clocked in for today")
+	ErrHoliday                 = errors.New("attendance cannot be recorded on a holiday")
+	ErrOnLeave                 = errors.New("attendance cannot be recorded on a day of leave")
+	ErrInvalidAttendanceSort   = errors.New("invalid attendance sort field")
+	ErrInvalidOvertimeStatus   = errors.New("invalid overtime status")
+	ErrOvertimeNotPending      = errors.New("attendance record has no overtime pending review")
+	ErrOwnOvertime             = errors.New("you cannot review your own overtime")
+	ErrInvalidBreakType        = errors.New("invalid break type")
+	ErrBreakNotFound           = errors.New("break not found")
+	ErrBreakInProgress         = errors.New("a break is already in progress")
+	ErrNoBreakInProgress       = errors.New("no break in progress")
+	ErrInvalidBreakTimes       = errors.New("break must end after it starts and not in the future")
+	ErrBreakOutsideWorkday     = errors.New("break must fall between clock-in and clock-out")
+	ErrBreakOverlap            = errors.New("break overlaps another break")
+)
+
+// Export specific errors
+var (
+	ErrInvalidExportFormat = errors.New("invalid export format, expected csv or xlsx")
+)
+
+// Payroll specific errors
+var (
+	ErrInvalidPayrollFormat   = errors.New("invalid payroll format, expected csv or fixed")
+	ErrPayrollAlreadyExported = errors.New("pay period overlaps an earlier payroll export, force the export to repeat it")
+	ErrPayrollValueTooWide    = errors.New("payroll value does not fit its fixed-width column")
+)
+
+// Work schedule specific errors
+var (
+	ErrScheduleNotFound = errors.New("work schedule not found")
+	ErrInvalidSchedule  = errors.New("invalid work schedule")
+)
+
+// Shift and roster specific errors
+var (
+	ErrShiftNotFound       = errors.New("shift not found")
+	ErrInvalidShift        = errors.New("invalid shift")
+	ErrShiftInUse          = errors.New("shift is used by the roster")
+	ErrRosterEntryNotFound = errors.New("roster entry not found")
+	ErrRosterConflict      = errors.New("user is already rostered on one of the dates")
+	ErrNothingToRoster     = errors.New("no dates in the range fall on the given weekdays")
+)
+
+// Attendance correction specific errors
+var (
+	ErrCorrectionNotFound   = errors.New("correction request not found")
+	ErrCorrectionNotPending = errors.New("correction request is not pending")
+	ErrCorrectionPending    = errors.New("attendance record already has a pending correction request")
+	ErrOwnCorrection        = errors.New("you cannot review your own correction request")
+	ErrEmptyCorrection      = errors.New("correction request proposes no changes")
+	ErrInvalidClockTimes    = errors.New("clock-out must be after clock-in and neither may be in the future")
+)
+
+// Holiday specific errors
+var (
+	ErrHolidayNotFound = errors.New("holiday not found")
+	ErrHolidayExists   = errors.New("holiday already exists on this date")
+	ErrInvalidCalendar = errors.New("invalid calendar file")
+)
+
+// Leave specific errors
+var (
+	ErrLeaveNotFound            = errors.New("leave request not found")
+	ErrInvalidLeaveType         = errors.New("invalid leave type")
+	ErrLeaveOverlap             = errors.New("leave request overlaps an existing request")
+	ErrLeaveNotPending          = errors.New("leave request is not pending")
+	ErrInsufficientLeaveBalance = errors.New("insufficient leave balance")
+	ErrNoWorkingDays            = errors.New("leave range contains no working days")
+	ErrOwnLeave                 = errors.New("you cannot review your own leave request")
 )
 
 // Database specific errors
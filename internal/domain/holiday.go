@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Holiday is a public holiday or company closure day. Holidays without a
+// location apply to everyone, others only to users at that location.
+type Holiday struct {
+	ID        string    `json:"id"`
+	Date      time.Time `json:"date"`
+	Name      string    `json:"name"`
+	Location  string    `json:"location,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ObservedAt reports whether the holiday applies to users at the location
+func (h Holiday) ObservedAt(location string) bool {
+	return h.Location == "" || h.Location == location
+}
+
+// HolidayImportResult summarises a calendar import
+type HolidayImportResult struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"` // days that already had a holiday at the location
+}
+
+type HolidayRepository interface {
+	Create(ctx context.Context, holiday *Holiday) error
+	GetByID(ctx context.Context, id string) (*Holiday, error)
+	GetByDate(ctx context.Context, date time.Time) ([]Holiday, error)
+	GetInRange(ctx context.Context, from, to time.Time) ([]Holiday, error)
+	Update(ctx context.Context, holiday *Holiday) error
+	Delete(ctx context.Context, id string) error
+}
+
+type HolidayUsecase interface {
+	CreateHoliday(ctx context.Context, holiday *Holiday) error
+	GetHoliday(ctx context.Context, id string) (*Holiday, error)
+	ListHolidays(ctx context.Context, year int, location string) ([]Holiday, error)
+	GetUserHolidays(ctx context.Context, userID string, year int) ([]Holiday, error)
+	UpdateHoliday(ctx context.Context, holiday *Holiday) error
+	DeleteHoliday(ctx context.Context, id string) error
+	ImportHolidays(ctx context.Context, calendar io.Reader, location string) (*HolidayImportResult, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type LeaveRequest struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Type       string     `json:"type"` // "annual", "sick" or "unpaid"
+	StartDate  time.Time  `json:"start_date"`
+	EndDate    time.Time  `json:"end_date"`
+	Days       int        `json:"days"` // working days covered by the request
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"` // "pending", "approved", "rejected" or "cancelled"
+	ReviewedBy string     `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote string     `json:"review_note,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// LeaveBalance tracks the yearly entitlement of a balance-tracked leave type
+type LeaveBalance struct {
+	UserID        string `json:"user_id"`
+	Year          int    `json:"year"`
+	Type          string `json:"type"`
+	EntitledDays  int    `json:"entitled_days"`
+	UsedDays      int    `json:"used_days"`
+	RemainingDays int    `json:"remaining_days"`
+}
+
+type LeaveRepository interface {
+	Create(ctx context.Context, leave *LeaveRequest) error
+	GetByID(ctx context.Context, id string) (*LeaveRequest, error)
+	GetByUserID(ctx context.Context, userID string) ([]LeaveRequest, error)
+	GetByStatus(ctx context.Context, status string) ([]LeaveRequest, error)
+	// GetActiveInRange returns the user's pending and approved requests overlapping the range
+	GetActiveInRange(ctx context.Context, userID string, from, to time.Time) ([]LeaveRequest, error)
+	Update(ctx context.Context, leave *LeaveRequest) error
+	GetBalance(ctx context.Context, userID string, year int, leaveType string) (*LeaveBalance, error)
+	SaveBalance(ctx context.Context, balance *LeaveBalance) error
+}
+
+type LeaveUsecase interface {
+	RequestLeave(ctx context.Context, leave *LeaveRequest) error
+	CancelLeave(ctx context.Context, userID, id string) error
+	ApproveLeave(ctx context.Context, id, reviewerID, note string) (*LeaveRequest, error)
+	RejectLeave(ctx context.Context, id, reviewerID, note string) (*LeaveRequest, error)
+	GetUserLeaves(ctx context.Context, userID string) ([]LeaveRequest, error)
+	GetLeavesByStatus(ctx context.Context, callerID, status string) ([]LeaveRequest, error)
+	GetBalances(ctx context.Context, callerID, userID string, year int) ([]LeaveBalance, error)
+	SetEntitlement(ctx context.Context, userID string, year int, leaveType string, days int) (*LeaveBalance, error)
+}
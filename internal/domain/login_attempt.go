@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Scopes of failed login tracking. Account keys are normalized emails, so
+// guesses against unregistered emails are throttled the same way.
+const (
+	LoginScopeAccount = "account"
+	LoginScopeIP      = "ip"
+)
+
+// LoginThrottle counts recent failed logins for an account or client IP
+type LoginThrottle struct {
+	Scope         string
+	Key           string
+	Failures      int
+	LockedUntil   *time.Time
+	LastFailureAt time.Time
+}
+
+// LockoutError is returned while logins are locked after repeated failures.
+// It matches ErrTooManyAttempts with errors.Is.
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *LockoutError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+type LoginAttemptRepository interface {
+	Get(ctx context.Context, scope, key string) (*LoginThrottle, error)
+	// RecordFailure counts a failed login and returns the number of failures.
+	// The count restarts when the previous failure is older than windowStart.
+	RecordFailure(ctx context.Context, scope, key string, at, windowStart time.Time) (int, error)
+	Lock(ctx context.Context, scope, key string, until time.Time) error
+	Reset(ctx context.Context, scope, key string) error
+	// DeleteStale removes entries without recent failures or an active lock
+	DeleteStale(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// Email is a plain-text message to a single recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers emails, e.g. over SMTP or to a log for local development
+type Mailer interface {
+	Send(ctx context.Context, email Email) error
+}
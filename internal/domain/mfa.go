@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserMFA is a user's TOTP enrollment. It stays pending until EnabledAt is
+// set by confirming a code from the authenticator app.
+type UserMFA struct {
+	UserID    string
+	Secret    string
+	EnabledAt *time.Time
+	// LastStep is the last accepted TOTP time step, so a code cannot be used twice
+	LastStep  int64
+	CreatedAt time.Time
+}
+
+// IsEnabled reports whether the enrollment was confirmed. A nil enrollment is not enabled.
+func (m *UserMFA) IsEnabled() bool {
+	return m != nil && m.EnabledAt != nil
+}
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the
+// authenticator is lost. Only a hash of the code is stored.
+type RecoveryCode struct {
+	ID        string
+	UserID    string
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// MFASetup is returned when starting an enrollment. The URI is meant to be shown as a QR code.
+type MFASetup struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	URI    string `json:"otpauth_uri" example:"otpauth://totp/Attendance:john@example.com?secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP&issuer=Attendance"`
+}
+
+type MFAStatus struct {
+	Enabled bool `json:"enabled"`
+	// Required is set when the policy requires MFA for the user's role
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+// MFAPolicy lists the roles that must use two-factor authentication. Users
+// with such a role can only reach the enrollment endpoints until they enroll.
+type MFAPolicy struct {
+	RequiredRoles []string `json:"required_roles" example:"admin"`
+}
+
+type MFARepository interface {
+	GetByUserID(ctx context.Context, userID string) (*UserMFA, error)
+	// Save creates or replaces the user's enrollment
+	Save(ctx context.Context, mfa *UserMFA) error
+	// Delete removes the user's enrollment and recovery codes
+	Delete(ctx context.Context, userID string) error
+	// UseStep records an accepted time step and reports whether it was newer than the last one
+	UseStep(ctx context.Context, userID string, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes deletes the user's recovery codes and stores the new ones
+	ReplaceRecoveryCodes(ctx context.Context, userID string, codes []RecoveryCode) error
+	// UseRecoveryCode marks an unused code as used and reports whether it was still unused
+	UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) (bool, error)
+	CountRecoveryCodes(ctx context.Context, userID string) (int, error)
+
+	GetRequiredRoles(ctx context.Context) ([]string, error)
+	SetRequiredRoles(ctx context.Context, roles []string) error
+}
+
+type MFAUsecase interface {
+	GetStatus(ctx context.Context, userID string) (*MFAStatus, error)
+	// Setup starts or restarts an enrollment with a new secret
+	Setup(ctx context.Context, userID string) (*MFASetup, error)
+	// Enable confirms the enrollment with a TOTP code and returns new recovery codes
+	Enable(ctx context.Context, userID, code string) ([]string, error)
+	// Disable removes the enrollment after checking a TOTP or recovery code
+	Disable(ctx context.Context, userID, code string) error
+	// RegenerateRecoveryCodes replaces the recovery codes after checking a TOTP code
+	RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error)
+	// Reset removes another user's enrollment, e.g. after they lost their device, and ends their sessions
+	Reset(ctx context.Context, actorID, userID string) error
+
+	GetPolicy(ctx context.Context) (*MFAPolicy, error)
+	SetPolicy(ctx context.Context, policy *MFAPolicy) error
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type Department struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Team is a group of users within a department
+type Team struct {
+	ID           string    `json:"id"`
+	DepartmentID string    `json:"department_id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Placement puts a user in a department and team and under a manager.
+// Empty fields clear the placement. A team implies its department.
+type Placement struct {
+	UserID       string
+	DepartmentID string
+	TeamID       string
+	ManagerID    string
+}
+
+// Scope is the set of users whose records a caller may see: everyone, or
+// the caller and their direct and indirect reports
+type Scope struct {
+	All     bool
+	UserIDs []string
+}
+
+// Includes reports whether the user's records are within the scope
+func (s Scope) Includes(userID string) bool {
+	if s.All {
+		return true
+	}
+	for _, id := range s.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+type DepartmentRepository interface {
+	Create(ctx context.Context, department *Department) error
+	GetByID(ctx context.Context, id string) (*Department, error)
+	GetAll(ctx context.Context) ([]Department, error)
+	Update(ctx context.Context, department *Department) error
+	Delete(ctx context.Context, id string) error
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, team *Team) error
+	GetByID(ctx context.Context, id string) (*Team, error)
+	// GetAll returns the teams of the department, or all teams when departmentID is empty
+	GetAll(ctx context.Context, departmentID string) ([]Team, error)
+	Update(ctx context.Context, team *Team) error
+	Delete(ctx context.Context, id string) error
+}
+
+type OrganizationUsecase interface {
+	CreateDepartment(ctx context.Context, department *Department) error
+	GetDepartment(ctx context.Context, id string) (*Department, error)
+	ListDepartments(ctx context.Context) ([]Department, error)
+	UpdateDepartment(ctx context.Context, department *Department) error
+	// DeleteDepartment refuses departments that still have teams
+	DeleteDepartment(ctx context.Context, id string) error
+
+	CreateTeam(ctx context.Context, team *Team) error
+	GetTeam(ctx context.Context, id string) (*Team, error)
+	ListTeams(ctx context.Context, departmentID string) ([]Team, error)
+	UpdateTeam(ctx context.Context, team *Team) error
+	DeleteTeam(ctx context.Context, id string) error
+
+	// SetPlacement moves a user whose role the actor's role covers. The
+	// manager cannot be the user or one of their reports.
+	SetPlacement(ctx context.Context, actorID string, placement Placement) (*User, error)
+	// GetReports returns the direct and indirect reports of the manager
+	GetReports(ctx context.Context, managerID string) ([]User, error)
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"time"
+)
+
+// PayrollLine is a user's attendance over a pay period, as handed to payroll
+type PayrollLine struct {
+	UserID          string
+	Name            string
+	Email           string
+	PeriodStart     time.Time
+	PeriodEnd       time.Time
+	DaysWorked      int // present or late
+	WorkedMinutes   int
+	OvertimeMinutes int // approved overtime only
+	AbsentDays      int
+	AnnualLeaveDays int
+	SickLeaveDays   int
+	UnpaidLeaveDays int
+	OtherLeaveDays  int // leave records without an approved request of a known type
+}
+
+// UnpaidAbsenceDays counts the days deducted from pay: absences and unpaid leave
+func (l *PayrollLine) UnpaidAbsenceDays() int {
+	return l.AbsentDays + l.UnpaidLeaveDays
+}
+
+// PaidLeaveDays counts the days on leave that are paid
+func (l *PayrollLine) PaidLeaveDays() int {
+	return l.AnnualLeaveDays + l.SickLeaveDays + l.OtherLeaveDays
+}
+
+// Payroll fields a column can be mapped to
+const (
+	PayrollFieldEmployeeID        = "employee_id"
+	PayrollFieldName              = "name"
+	PayrollFieldEmail             = "email"
+	PayrollFieldPeriodStart       = "period_start"
+	PayrollFieldPeriodEnd         = "period_end"
+	PayrollFieldDaysWorked        = "days_worked"
+	PayrollFieldWorkedHours       = "worked_hours"
+	PayrollFieldOvertimeHours     = "overtime_hours"
+	PayrollFieldAbsentDays        = "absent_days"
+	PayrollFieldUnpaidLeaveDays   = "unpaid_leave_days"
+	PayrollFieldUnpaidAbsenceDays = "unpaid_absence_days"
+	PayrollFieldAnnualLeaveDays   = "annual_leave_days"
+	PayrollFieldSickLeaveDays     = "sick_leave_days"
+	PayrollFieldPaidLeaveDays     = "paid_leave_days"
+)
+
+// NumericPayrollFields contains the payroll fields holding numbers. All
+// other fields hold text.
+var NumericPayrollFields = map[string]bool{
+	PayrollFieldDaysWorked:        true,
+	PayrollFieldWorkedHours:       true,
+	PayrollFieldOvertimeHours:     true,
+	PayrollFieldAbsentDays:        true,
+	PayrollFieldUnpaidLeaveDays:   true,
+	PayrollFieldUnpaidAbsenceDays: true,
+	PayrollFieldAnnualLeaveDays:   true,
+	PayrollFieldSickLeaveDays:     true,
+	PayrollFieldPaidLeaveDays:     true,
+}
+
+// ValidPayrollFields contains all payroll fields
+var ValidPayrollFields = map[string]bool{
+	PayrollFieldEmployeeID:        true,
+	PayrollFieldName:              true,
+	PayrollFieldEmail:             true,
+	PayrollFieldPeriodStart:       true,
+	PayrollFieldPeriodEnd:         true,
+	PayrollFieldDaysWorked:        true,
+	PayrollFieldWorkedHours:       true,
+	PayrollFieldOvertimeHours:     true,
+	PayrollFieldAbsentDays:        true,
+	PayrollFieldUnpaidLeaveDays:   true,
+	PayrollFieldUnpaidAbsenceDays: true,
+	PayrollFieldAnnualLeaveDays:   true,
+	PayrollFieldSickLeaveDays:     true,
+	PayrollFieldPaidLeaveDays:     true,
+}
+
+// Value returns the line's value of a payroll field as text. Hours have two
+// decimals and dates use DateFormat.
+func (l *PayrollLine) Value(field string) string {
+	switch field {
+	case PayrollFieldEmployeeID:
+		return l.UserID
+	case PayrollFieldName:
+		return l.Name
+	case PayrollFieldEmail:
+		return l.Email
+	case PayrollFieldPeriodStart:
+		return l.PeriodStart.Format(DateFormat)
+	case PayrollFieldPeriodEnd:
+		return l.PeriodEnd.Format(DateFormat)
+	case PayrollFieldDaysWorked:
+		return strconv.Itoa(l.DaysWorked)
+	case PayrollFieldWorkedHours:
+		return formatHours(l.WorkedMinutes)
+	case PayrollFieldOvertimeHours:
+		return formatHours(l.OvertimeMinutes)
+	case PayrollFieldAbsentDays:
+		return strconv.Itoa(l.AbsentDays)
+	case PayrollFieldUnpaidLeaveDays:
+		return strconv.Itoa(l.UnpaidLeaveDays)
+	case PayrollFieldUnpaidAbsenceDays:
+		return strconv.Itoa(l.UnpaidAbsenceDays())
+	case PayrollFieldAnnualLeaveDays:
+		return strconv.Itoa(l.AnnualLeaveDays)
+	case PayrollFieldSickLeaveDays:
+		return strconv.Itoa(l.SickLeaveDays)
+	case PayrollFieldPaidLeaveDays:
+		return strconv.Itoa(l.PaidLeaveDays())
+	}
+	return ""
+}
+
+func formatHours(minutes int) string {
+	return strconv.FormatFloat(float64(minutes)/60, 'f', 2, 64)
+}
+
+// PayrollColumn maps a payroll field to a column of an export file. Width is
+// only used by fixed-width formats.
+type PayrollColumn struct {
+	Field  string
+	Header string
+	Width  int
+}
+
+// PayrollFormatter writes payroll lines in the import format of a payroll
+// system, e.g. CSV or fixed-width text
+type PayrollFormatter interface {
+	ContentType() string
+	FileExtension() string
+	Write(w io.Writer, columns []PayrollColumn, lines []PayrollLine) error
+}
+
+// PayrollExport records an export of a pay period
+type PayrollExport struct {
+	ID          string    `json:"id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Format      string    `json:"format" example:"csv"`
+	LineCount   int       `json:"line_count"`
+	Forced      bool      `json:"forced"` // exported although the period overlapped an earlier export
+	ExportedBy  string    `json:"exported_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// PayrollExportRequest selects the pay period and format of an export. Force
+// allows exporting a period overlapping an earlier export.
+type PayrollExportRequest struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Format      string
+	Force       bool
+	ExportedBy  string
+}
+
+// PayrollFile is the content of an export and its record in the history
+type PayrollFile struct {
+	Export      PayrollExport
+	ContentType string
+	Filename    string
+	Content     []byte
+}
+
+type PayrollExportRepository interface {
+	Create(ctx context.Context, export *PayrollExport) error
+	// GetOverlapping returns the exports whose period overlaps the range
+	GetOverlapping(ctx context.Context, from, to time.Time) ([]PayrollExport, error)
+	// GetAll returns every export, newest first
+	GetAll(ctx context.Context) ([]PayrollExport, error)
+}
+
+type PayrollUsecase interface {
+	// ExportPayroll converts the attendance of every user with records in the
+	// pay period into the requested format and records the export. Periods
+	// overlapping an earlier export are refused unless forced.
+	ExportPayroll(ctx context.Context, request PayrollExportRequest) (*PayrollFile, error)
+	ListExports(ctx context.Context) ([]PayrollExport, error)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// AttendanceSummary aggregates the attendance records of a user, or of a group
+// of users when UserID is empty, over a period
+type AttendanceSummary struct {
+	UserID                  string  `json:"user_id,omitempty"`
+	Name                    string  `json:"name,omitempty"`
+	Email                   string  `json:"email,omitempty"`
+	DaysPresent             int     `json:"days_present"`
+	DaysLate                int     `json:"days_late"`
+	DaysAbsent              int     `json:"days_absent"`
+	DaysOnLeave             int     `json:"days_on_leave"`
+	WorkedMinutes           int     `json:"worked_minutes"`
+	WorkedHours             float64 `json:"worked_hours"`
+	OvertimeMinutes         int     `json:"overtime_minutes"` // all overtime, whatever its review status
+	OvertimeHours           float64 `json:"overtime_hours"`
+	ApprovedOvertimeMinutes int     `json:"approved_overtime_minutes"`
+	ApprovedOvertimeHours   float64 `json:"approved_overtime_hours"`
+	PunctualityRate         float64 `json:"punctuality_rate"` // percentage of attended days that were on time
+}
+
+// Add adds the counts of other to the summary
+func (s *AttendanceSummary) Add(other AttendanceSummary) {
+	s.DaysPresent += other.DaysPresent
+	s.DaysLate += other.DaysLate
+	s.DaysAbsent += other.DaysAbsent
+	s.DaysOnLeave += other.DaysOnLeave
+	s.WorkedMinutes += other.WorkedMinutes
+	s.OvertimeMinutes += other.OvertimeMinutes
+	s.ApprovedOvertimeMinutes += other.ApprovedOvertimeMinutes
+}
+
+// ComputeRates derives the hours and punctuality rate from the counts
+func (s *AttendanceSummary) ComputeRates() {
+	s.WorkedHours = minutesToHours(s.WorkedMinutes)
+	s.OvertimeHours = minutesToHours(s.OvertimeMinutes)
+	s.ApprovedOvertimeHours = minutesToHours(s.ApprovedOvertimeMinutes)
+	s.PunctualityRate = 0
+	if attended := s.DaysPresent + s.DaysLate; attended > 0 {
+		s.PunctualityRate = math.Round(float64(s.DaysPresent)/float64(attended)*1000) / 10
+	}
+}
+
+func minutesToHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+// MonthlyReport summarizes a month of attendance for a user, a team or the
+// whole company. Users holds the summary of every user with records in the
+// month for team and company reports.
+type MonthlyReport struct {
+	Month    string              `json:"month" example:"2024-07"`
+	UserID   string              `json:"user_id,omitempty"`
+	TeamID   string              `json:"team_id,omitempty"`
+	Totals   AttendanceSummary   `json:"totals"`
+	Users    []AttendanceSummary `json:"users,omitempty"`
+	Previous *MonthlyReport      `json:"previous,omitempty"` // the month before, when a comparison was requested
+}
+
+// ReportFilter selects the attendance records to summarize. Empty user IDs
+// and team ID select every user.
+type ReportFilter struct {
+	From    time.Time
+	To      time.Time
+	UserIDs []string
+	TeamID  string
+}
+
+type ReportRepository interface {
+	// SummarizeByUser aggregates the records in the filter's date range per
+	// user, ordered by name
+	SummarizeByUser(ctx context.Context, filter ReportFilter) ([]AttendanceSummary, error)
+}
+
+// ReportUsecase builds monthly attendance reports. Month is any time within
+// the month. With compare the report includes the previous month.
+type ReportUsecase interface {
+	// GetUserMonthlyReport is limited to the caller's scope: everyone with
+	// reports:read, otherwise the caller and their direct and indirect reports
+	GetUserMonthlyReport(ctx context.Context, callerID, userID string, month time.Time, compare bool) (*MonthlyReport, error)
+	GetTeamMonthlyReport(ctx context.Context, teamID string, month time.Time, compare bool) (*MonthlyReport, error)
+	GetCompanyMonthlyReport(ctx context.Context, month time.Time, compare bool) (*MonthlyReport, error)
+	// ListMonthlySummaries returns the summary of every user within the
+	// caller's scope who has records in the month
+	ListMonthlySummaries(ctx context.Context, callerID string, month time.Time) ([]AttendanceSummary, error)
+}
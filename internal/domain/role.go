@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Permissions. Own permissions cover the user's own records; all permissions
+// cover every user's.
+const (
+	PermAttendanceReadOwn  = "attendance:read:own"
+	PermAttendanceWriteOwn = "attendance:write:own"
+	PermAttendanceReadAll  = "attendance:read:all"
+	PermAttendanceWriteAll = "attendance:write:all"
+	PermCorrectionsApprove = "corrections:approve"
+	PermLeavesWriteOwn     = "leaves:write:own"
+	PermLeavesApprove      = "leaves:approve"
+	PermLeavesManage       = "leaves:manage"
+	PermSchedulesManage    = "schedules:manage"
+	PermHolidaysManage     = "holidays:manage"
+	PermUsersRead          = "users:read"
+	PermUsersManage        = "users:manage"
+	PermRolesManage        = "roles:manage"
+	PermOrganizationManage = "organization:manage"
+	PermReportsRead        = "reports:read"
+	PermPayrollExport      = "payroll:export"
+	PermOvertimeApprove    = "overtime:approve"
+)
+
+// AllPermissions lists every permission. The admin role always holds all of them.
+var AllPermissions = []string{
+	PermAttendanceReadOwn,
+	PermAttendanceWriteOwn,
+	PermAttendanceReadAll,
+	PermAttendanceWriteAll,
+	PermCorrectionsApprove,
+	PermLeavesWriteOwn,
+	PermLeavesApprove,
+	PermLeavesManage,
+	PermSchedulesManage,
+	PermHolidaysManage,
+	PermUsersRead,
+	PermUsersManage,
+	PermRolesManage,
+	PermOrganizationManage,
+	PermReportsRead,
+	PermPayrollExport,
+	PermOvertimeApprove,
+}
+
+// IsValidPermission reports whether the permission is one of AllPermissions
+func IsValidPermission(permission string) bool {
+	for _, p := range AllPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Role is a named set of permissions. The built-in admin and user roles are
+// system roles and cannot be deleted.
+type Role struct {
+	Name        string    `json:"name" example:"team-lead"`
+	Description string    `json:"description" example:"Approves leave and corrections of their team"`
+	Permissions []string  `json:"permissions" example:"leaves:approve,corrections:approve"`
+	System      bool      `json:"system"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HasPermission reports whether the role grants the permission
+func (r *Role) HasPermission(permission string) bool {
+	if r.Name == RoleAdmin {
+		return true
+	}
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether the role holds every permission of other. Only the
+// admin role covers the admin role.
+func (r *Role) Covers(other *Role) bool {
+	if other.Name == RoleAdmin {
+		return r.Name == RoleAdmin
+	}
+	for _, p := range other.Permissions {
+		if !r.HasPermission(p) {
+			return false
+		}
+	}
+	return true
+}
+
+type RoleRepository interface {
+	List(ctx context.Context) ([]Role, error)
+	GetByName(ctx context.Context, name string) (*Role, error)
+	Create(ctx context.Context, role *Role) error
+	// Update replaces the description and permissions of the role
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, name string) error
+}
+
+type RoleUsecase interface {
+	ListRoles(ctx context.Context) ([]Role, error)
+	GetRole(ctx context.Context, name string) (*Role, error)
+	// CreateRole and UpdateRole only allow permissions the actor holds
+	CreateRole(ctx context.Context, actorID string, role *Role) error
+	UpdateRole(ctx context.Context, actorID string, role *Role) error
+	DeleteRole(ctx context.Context, name string) error
+	// GetPermissions returns the permissions of the user's role
+	GetPermissions(ctx context.Context, userID string) ([]string, error)
+}
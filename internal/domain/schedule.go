@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// WorkSchedule describes when a group of users is expected to start work.
+// Users without an assigned schedule fall back to the default schedule.
+type WorkSchedule struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	StartTime          string    `json:"start_time"` // HH:MM, server local time
+	EndTime            string    `json:"end_time"`   // HH:MM, server local time
+	GracePeriodMinutes int       `json:"grace_period_minutes"`
+	WorkDays           []int     `json:"work_days"` // 0 = Sunday ... 6 = Saturday
+	IsDefault          bool      `json:"is_default"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// IsWorkDay reports whether the given weekday is a working day in the schedule
+func (s *WorkSchedule) IsWorkDay(day time.Weekday) bool {
+	for _, d := range s.WorkDays {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// StartAt returns the schedule start time on the day of t
+func (s *WorkSchedule) StartAt(t time.Time) (time.Time, error) {
+	return atTimeOfDay(t, s.StartTime)
+}
+
+// EndAt returns the schedule end time on the day of t
+func (s *WorkSchedule) EndAt(t time.Time) (time.Time, error) {
+	return atTimeOfDay(t, s.EndTime)
+}
+
+// LateAfter returns the moment after which a clock-in on the day of t counts as late
+func (s *WorkSchedule) LateAfter(t time.Time) (time.Time, error) {
+	start, err := s.StartAt(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.Add(time.Duration(s.GracePeriodMinutes) * time.Minute), nil
+}
+
+func atTimeOfDay(t time.Time, clock string) (time.Time, error) {
+	parsed, err := time.Parse(TimeOfDayFormat, clock)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), parsed.Hour(), parsed.Minute(), 0, 0, t.Location()), nil
+}
+
+type WorkScheduleRepository interface {
+	Create(ctx context.Context, schedule *WorkSchedule) error
+	GetByID(ctx context.Context, id string) (*WorkSchedule, error)
+	GetAll(ctx context.Context) ([]WorkSchedule, error)
+	// GetByUserID returns the schedule assigned to the user, falling back to the default schedule
+	GetByUserID(ctx context.Context, userID string) (*WorkSchedule, error)
+	Update(ctx context.Context, schedule *WorkSchedule) error
+	Delete(ctx context.Context, id string) error
+	AssignUser(ctx context.Context, scheduleID, userID string) error
+	UnassignUser(ctx context.Context, userID string) error
+}
+
+type WorkScheduleUsecase interface {
+	CreateSchedule(ctx context.Context, schedule *WorkSchedule) error
+	GetSchedule(ctx context.Context, id string) (*WorkSchedule, error)
+	ListSchedules(ctx context.Context) ([]WorkSchedule, error)
+	UpdateSchedule(ctx context.Context, schedule *WorkSchedule) error
+	DeleteSchedule(ctx context.Context, id string) error
+	AssignUsers(ctx context.Context, scheduleID string, userIDs []string) error
+	UnassignUser(ctx context.Context, userID string) error
+	GetUserSchedule(ctx context.Context, userID string) (*WorkSchedule, error)
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ShiftClockInWindow is how early before a rostered shift a clock-in still
+// belongs to it
+const ShiftClockInWindow = 2 * time.Hour
+
+// Shift is a template of working hours that users are rostered on. A shift
+// ending at or before its start time runs past midnight into the next day.
+type Shift struct {
+	ID                 string    `json:"id"`
+	Name               string    `json:"name"`
+	StartTime          string    `json:"start_time" example:"22:00"` // HH:MM, server local time
+	EndTime            string    `json:"end_time" example:"06:00"`   // HH:MM, server local time
+	GracePeriodMinutes int       `json:"grace_period_minutes"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// Overnight reports whether the shift ends on the day after it starts
+func (s *Shift) Overnight() bool {
+	start, _ := time.Parse(TimeOfDayFormat, s.StartTime)
+	end, _ := time.Parse(TimeOfDayFormat, s.EndTime)
+	return !end.After(start)
+}
+
+// StartAt returns the start of the shift that begins on the day of t
+func (s *Shift) StartAt(t time.Time) (time.Time, error) {
+	return atTimeOfDay(t, s.StartTime)
+}
+
+// EndAt returns the end of the shift that begins on the day of t
+func (s *Shift) EndAt(t time.Time) (time.Time, error) {
+	end, err := atTimeOfDay(t, s.EndTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if s.Overnight() {
+		end = end.AddDate(0, 0, 1)
+	}
+	return end, nil
+}
+
+// LateAfter returns the moment after which a clock-in for the shift that
+// begins on the day of t counts as late
+func (s *Shift) LateAfter(t time.Time) (time.Time, error) {
+	start, err := s.StartAt(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.Add(time.Duration(s.GracePeriodMinutes) * time.Minute), nil
+}
+
+// RosterEntry assigns a user to a shift on the date the shift begins. A user
+// works at most one shift per date, and the attendance record of the shift
+// carries that date even when the shift ends the next day.
+type RosterEntry struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	ShiftID   string    `json:"shift_id"`
+	Date      time.Time `json:"date"`
+	Shift     *Shift    `json:"shift,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Covers reports whether a clock-in at t belongs to the entry's shift: from
+// ShiftClockInWindow before its start until its end
+func (e *RosterEntry) Covers(t time.Time) (bool, error) {
+	start, err := e.Shift.StartAt(e.day(t))
+	if err != nil {
+		return false, err
+	}
+	end, err := e.Shift.EndAt(e.day(t))
+	if err != nil {
+		return false, err
+	}
+	return !t.Before(start.Add(-ShiftClockInWindow)) && t.Before(end), nil
+}
+
+// LateAfter returns the moment after which a clock-in for the entry's shift
+// counts as late, in the location of t
+func (e *RosterEntry) LateAfter(t time.Time) (time.Time, error) {
+	return e.Shift.LateAfter(e.day(t))
+}
+
+// EndedBy reports whether the entry's shift is over at t
+func (e *RosterEntry) EndedBy(t time.Time) (bool, error) {
+	end, err := e.Shift.EndAt(e.day(t))
+	if err != nil {
+		return false, err
+	}
+	return !t.Before(end), nil
+}
+
+// day returns the entry's date in the location of t, as shift times are
+// times of day in server local time
+func (e *RosterEntry) day(t time.Time) time.Time {
+	return time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// RosterRequest rosters users on a shift on every date of a range that falls
+// on one of the weekdays. Without weekdays every date is rostered.
+type RosterRequest struct {
+	ShiftID  string
+	UserIDs  []string
+	From     time.Time
+	To       time.Time
+	WorkDays []int // 0 = Sunday ... 6 = Saturday
+}
+
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *Shift) error
+	GetByID(ctx context.Context, id string) (*Shift, error)
+	GetAll(ctx context.Context) ([]Shift, error)
+	Update(ctx context.Context, shift *Shift) error
+	Delete(ctx context.Context, id string) error
+	// IsRostered reports whether any roster entry uses the shift
+	IsRostered(ctx context.Context, shiftID string) (bool, error)
+	// CreateRosterEntries stores all entries or none, returning
+	// ErrRosterConflict when a user is already rostered on one of the dates
+	CreateRosterEntries(ctx context.Context, entries []RosterEntry) error
+	GetRosterEntry(ctx context.Context, id string) (*RosterEntry, error)
+	// GetRoster returns the entries dated within the range with their shifts,
+	// ordered by date. Without user IDs the entries of every user are returned.
+	GetRoster(ctx context.Context, from, to time.Time, userIDs []string) ([]RosterEntry, error)
+	DeleteRosterEntry(ctx context.Context, id string) error
+}
+
+type ShiftUsecase interface {
+	CreateShift(ctx context.Context, shift *Shift) error
+	GetShift(ctx context.Context, id string) (*Shift, error)
+	ListShifts(ctx context.Context) ([]Shift, error)
+	UpdateShift(ctx context.Context, shift *Shift) error
+	DeleteShift(ctx context.Context, id string) error
+	RosterUsers(ctx context.Context, req RosterRequest) ([]RosterEntry, error)
+	// GetRoster returns the roster within the range, of one user or of everyone
+	GetRoster(ctx context.Context, from, to time.Time, userID string) ([]RosterEntry, error)
+	DeleteRosterEntry(ctx context.Context, id string) error
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Timesheet is a user's attendance for every day of a month, for sign-off
+type Timesheet struct {
+	User   User              `json:"user"`
+	Month  string            `json:"month" example:"2024-07"`
+	Days   []TimesheetDay    `json:"days"`
+	Totals AttendanceSummary `json:"totals"`
+}
+
+// HasRecords reports whether any day of the timesheet has an attendance record
+func (t *Timesheet) HasRecords() bool {
+	for _, day := range t.Days {
+		if day.Attendance != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TimesheetDay is a day of a timesheet with its record, if any, and the
+// holiday or approved leave covering it
+type TimesheetDay struct {
+	Date       time.Time   `json:"date"`
+	Attendance *Attendance `json:"attendance,omitempty"`
+	Holiday    string      `json:"holiday,omitempty"`    // name of the holiday observed by the user
+	LeaveType  string      `json:"leave_type,omitempty"` // type of the approved leave
+}
+
+// TimesheetUsecase builds monthly timesheets. Month is any time within the month.
+type TimesheetUsecase interface {
+	// GetTimesheet is limited to the caller's scope: everyone with
+	// reports:read, otherwise the caller and their direct and indirect reports
+	GetTimesheet(ctx context.Context, callerID, userID string, month time.Time) (*Timesheet, error)
+	// EachTimesheet passes the timesheets of the team's members, or of all
+	// users when teamID is empty, to fn one at a time. Deactivated users
+	// without records in the month are skipped. An error from fn stops it.
+	EachTimesheet(ctx context.Context, teamID string, month time.Time, fn func(timesheet *Timesheet) error) error
+}
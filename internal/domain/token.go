@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TokenPair is returned on login and refresh. The access token authenticates
+// API requests; the refresh token is exchanged for a new pair once it expires.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type" example:"Bearer"`
+	ExpiresIn    int    `json:"expires_in" example:"900"` // access token lifetime in seconds
+}
+
+// LoginResult is returned by a password login. Accounts with two-factor
+// authentication get a short-lived MFA token instead of the token pair, which
+// is exchanged for the pair together with a code.
+type LoginResult struct {
+	*TokenPair
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	// MFAEnrollmentRequired is set when the user's role requires MFA but they
+	// have not enrolled yet. Until they do, only the enrollment endpoints work.
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+}
+
+// RefreshToken is the server-side record of an issued refresh token. Only a
+// hash of the token is stored. Tokens rotated from the same login share a
+// family so that reuse of an old token can revoke every descendant.
+type RefreshToken struct {
+	ID         string
+	UserID     string
+	FamilyID   string
+	TokenHash  string
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+	ReplacedBy string
+	CreatedAt  time.Time
+}
+
+// OneTimeToken is a single-use token sent to a user by email, e.g. to reset
+// their password. Like refresh tokens, only a hash is stored.
+type OneTimeToken struct {
+	ID        string
+	UserID    string
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	// RevokeRefreshToken revokes an active token and reports whether it was still active
+	RevokeRefreshToken(ctx context.Context, id, replacedBy string, at time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID string, at time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userID string, at time.Time) error
+
+	// Access token denylist, keyed by the token's jti claim
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) error
+
+	CreateOneTimeToken(ctx context.Context, token *OneTimeToken) error
+	GetOneTimeToken(ctx context.Context, purpose, hash string) (*OneTimeToken, error)
+	// UseOneTimeToken marks an unused token as used and reports whether it was still unused
+	UseOneTimeToken(ctx context.Context, id string, at time.Time) (bool, error)
+	// InvalidateOneTimeTokens marks all of the user's unused tokens for the purpose as used
+	InvalidateOneTimeTokens(ctx context.Context, userID, purpose string, at time.Time) error
+}
@@ -1,25 +1,112 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type User struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"-"` // "-" means this field won't be included in JSON
-	Role     string `json:"role"`
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email"`
+	Password      string     `json:"-"` // "-" means this field won't be included in JSON
+	Role          string     `json:"role"`
+	Location      string     `json:"location"` // selects location-specific holidays
+	DepartmentID  string     `json:"department_id,omitempty"`
+	TeamID        string     `json:"team_id,omitempty"`
+	ManagerID     string     `json:"manager_id,omitempty"` // the user this user reports to
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	// EmailVerifiedAt is set once the user confirms they own Email
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// PendingEmail replaces Email once confirmed; until then Email stays in use
+	PendingEmail string `json:"pending_email,omitempty"`
+	// PasswordChangedAt invalidates access tokens issued before it
+	PasswordChangedAt *time.Time `json:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// IsActive reports whether the account may log in and use its tokens
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// IsEmailVerified reports whether the user has confirmed their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// UserFilter selects a page of users. Search matches name or email.
+type UserFilter struct {
+	Search   string
+	Role     string
+	Page     int
+	PageSize int
+}
+
+// Normalize applies the default page and page size and caps the page size
+func (f *UserFilter) Normalize() {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.PageSize < 1 {
+		f.PageSize = DefaultPageSize
+	}
+	if f.PageSize > MaxPageSize {
+		f.PageSize = MaxPageSize
+	}
 }
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id string) (*User, error)
+	GetAll(ctx context.Context) ([]User, error)
+	// List returns the users on the filter's page and the total number of matching users
+	List(ctx context.Context, filter UserFilter) ([]User, int, error)
 	Update(ctx context.Context, user *User) error
+	Delete(ctx context.Context, id string) error
+	// GetReports returns the users reporting to the manager directly or
+	// through other managers
+	GetReports(ctx context.Context, managerID string) ([]User, error)
 }
 
 type UserUsecase interface {
+	// Register creates a regular user through public registration
 	Register(ctx context.Context, user *User) error
-	Login(ctx context.Context, email, password string) (string, error)
+	// CreateUser creates an account with a role the actor's role covers.
+	// An empty actorID is the operator using the CLI.
+	CreateUser(ctx context.Context, actorID string, user *User) error
+	// BootstrapAdmin creates the admin when no admin exists yet and reports whether it did
+	BootstrapAdmin(ctx context.Context, user *User) (bool, error)
+	// Login checks the password and either issues tokens or, with MFA enabled, an MFA challenge.
+	// Repeated failures for the email or client IP lock further attempts with a *LockoutError.
+	Login(ctx context.Context, email, password, ip string) (*LoginResult, error)
+	// VerifyMFA completes a login with the MFA token and a TOTP or recovery code.
+	// Failed codes count towards the account's lockout.
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*TokenPair, error)
+	// Refresh rotates a refresh token. Reusing a rotated token revokes its whole family.
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	// Logout revokes the access token with the jti and, if given, the refresh token's family
+	Logout(ctx context.Context, jti string, accessExpiresAt time.Time, refreshToken string) error
+	// RequestPasswordReset emails a reset link. Unknown emails are silently ignored.
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword sets a new password with a reset token and ends all sessions
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	// VerifyEmail confirms the address in a verification token, switching to a pending email if needed
+	VerifyEmail(ctx context.Context, token string) (*User, error)
+	// ResendVerification emails a new link for the pending or unverified email
+	ResendVerification(ctx context.Context, id string) error
 	GetProfile(ctx context.Context, id string) (*User, error)
 	UpdateProfile(ctx context.Context, user *User) error
+
+	// Account management. actorID is the user making the change, who cannot
+	// change their own role, status or location or delete their own account,
+	// and whose role must cover the role of the account and any role they assign.
+	SetLocation(ctx context.Context, actorID, id, location string) (*User, error)
+	ListUsers(ctx context.Context, filter UserFilter) ([]User, int, error)
+	UpdateRole(ctx context.Context, actorID, id, role string) (*User, error)
+	SetActive(ctx context.Context, actorID, id string, active bool) (*User, error)
+	DeleteUser(ctx context.Context, actorID, id string) error
+	// Unlock clears the failed login count and lockout of the user's account
+	Unlock(ctx context.Context, id string) error
 }
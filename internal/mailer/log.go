@@ -0,0 +1,58 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils/logger"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type logMailer struct{}
+
+// NewLogMailer writes emails to the application log instead of sending them.
+// Intended for local development only, as the log then contains reset links.
+func NewLogMailer() domain.Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(ctx context.Context, email domain.Email) error {
+	logger.Info("Email not sent, logged instead",
+		zap.String("to", email.To),
+		zap.String("subject", email.Subject),
+		zap.String("body", email.Body))
+	return nil
+}
+
+type fileMailer struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+// NewFileMailer appends every email to a file, e.g. for local development or
+// end-to-end tests that read the links out of sent emails
+func NewFileMailer(path, from string) domain.Mailer {
+	return &fileMailer{path: path, from: from}
+}
+
+func (m *fileMailer) Send(ctx context.Context, email domain.Email) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.Write(buildMessage(m.from, email)); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "\r\n\r\n--- %s ---\r\n\r\n", time.Now().Format(time.RFC3339))
+	return err
+}
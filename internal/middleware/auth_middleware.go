@@ -13,11 +13,19 @@ import (
 
 type AuthMiddleware struct {
 	jwtSecret string
+	userRepo  domain.UserRepository
+	tokenRepo domain.TokenRepository
+	mfaRepo   domain.MFARepository
+	roleRepo  domain.RoleRepository
 }
 
-func NewAuthMiddleware(jwtSecret string) *AuthMiddleware {
+func NewAuthMiddleware(jwtSecret string, userRepo domain.UserRepository, tokenRepo domain.TokenRepository, mfaRepo domain.MFARepository, roleRepo domain.RoleRepository) *AuthMiddleware {
 	return &AuthMiddleware{
 		jwtSecret: jwtSecret,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		mfaRepo:   mfaRepo,
+		roleRepo:  roleRepo,
 	}
 }
 
@@ -93,11 +101,82 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 			return
 		}
 
+		// Tokens without a jti cannot be revoked, so they are not accepted
+		jti, _ := claims["jti"].(string)
+		expiresAt, err := claims.GetExpirationTime()
+		if jti == "" || err != nil || expiresAt == nil {
+			logger.Warn("Token without jti or expiry",
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": domain.ErrUnauthorized.Error(),
+			})
+			return
+		}
+
+		revoked, err := m.tokenRepo.IsAccessTokenRevoked(c.Request.Context(), jti)
+		if err != nil {
+			logger.Error("Failed to check token revocation",
+				zap.Error(err),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": domain.ErrDatabase.Error(),
+			})
+			return
+		}
+		if revoked {
+			logger.Warn("Revoked token used",
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": domain.ErrUnauthorized.Error(),
+			})
+			return
+		}
+
+		// Tokens stay valid until they expire, so check the account on every
+		// request to reject deleted or deactivated users and pick up role changes
+		user, err := m.userRepo.GetByID(c.Request.Context(), userID)
+		if err != nil {
+			logger.Error("Failed to load user for token",
+				zap.Error(err),
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": domain.ErrDatabase.Error(),
+			})
+			return
+		}
+		if user == nil || !user.IsActive() {
+			logger.Warn("Token used by missing or deactivated user",
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": domain.ErrUnauthorized.Error(),
+			})
+			return
+		}
+		if issuedBeforePasswordChange(claims, user) {
+			logger.Warn("Token issued before password change",
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": domain.ErrUnauthorized.Error(),
+			})
+			return
+		}
+
 		// Add user information to context
 		c.Set("user_id", userID)
-		if role, ok := claims["role"].(string); ok {
-			c.Set("user_role", role)
-		}
+		c.Set("user_role", user.Role)
+		c.Set("token_id", jti)
+		c.Set("token_expires_at", expiresAt.Time)
 
 		logger.Debug("Authentication successful",
 			zap.String("user_id", userID),
@@ -108,20 +187,121 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 	}
 }
 
-// AdminRequired middleware checks if the user has admin role
-func (m *AuthMiddleware) AdminRequired() gin.HandlerFunc {
+// issuedBeforePasswordChange reports whether the token predates the user's
+// last password change. The stored time has second precision, like iat.
+func issuedBeforePasswordChange(claims jwt.MapClaims, user *domain.User) bool {
+	if user.PasswordChangedAt == nil {
+		return false
+	}
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return true
+	}
+	return issuedAt.Unix() < user.PasswordChangedAt.Unix()
+}
+
+// MFAEnrollmentRequired rejects users whose role must use two-factor
+// authentication but who have not enrolled yet. It must run after AuthRequired.
+// Enrolled users always log in with a code, so their tokens need no further check.
+func (m *AuthMiddleware) MFAEnrollmentRequired() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("user_role")
-		if !exists || role != domain.RoleAdmin {
-			logger.Warn("Unauthorized access to admin endpoint",
+		userID := c.GetString("user_id")
+		enrolled, err := m.mfaEnrolledIfRequired(c, userID, c.GetString("user_role"))
+		if err != nil {
+			logger.Error("Failed to check MFA policy",
+				zap.Error(err),
+				zap.String("user_id", userID),
 				zap.String("path", c.Request.URL.Path),
-				zap.String("method", c.Request.Method),
-				zap.String("user_id", c.GetString("user_id")))
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": domain.ErrDatabase.Error(),
+			})
+			return
+		}
+		if !enrolled {
+			logger.Warn("MFA enrollment required",
+				zap.String("user_id", userID),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "admin access required",
+				"error": domain.ErrMFARequired.Error(),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// mfaEnrolledIfRequired reports false only when the policy covers the role and the user has not enrolled
+func (m *AuthMiddleware) mfaEnrolledIfRequired(c *gin.Context, userID, role string) (bool, error) {
+	roles, err := m.mfaRepo.GetRequiredRoles(c.Request.Context())
+	if err != nil {
+		return false, err
+	}
+	required := false
+	for _, requiredRole := range roles {
+		if requiredRole == role {
+			required = true
+			break
+		}
+	}
+	if !required {
+		return true, nil
+	}
+
+	mfa, err := m.mfaRepo.GetByUserID(c.Request.Context(), userID)
+	if err != nil {
+		return false, err
+	}
+	return mfa.IsEnabled(), nil
+}
+
+// RequirePermission rejects users whose role lacks any of the permissions.
+// It must run after AuthRequired. The role is loaded once per request, so
+// role changes apply immediately.
+func (m *AuthMiddleware) RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, err := m.userRoleOf(c)
+		if err != nil {
+			logger.Error("Failed to load role",
+				zap.Error(err),
+				zap.String("user_id", c.GetString("user_id")),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method))
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": domain.ErrDatabase.Error(),
 			})
 			return
 		}
+
+		for _, permission := range permissions {
+			if role == nil || !role.HasPermission(permission) {
+				logger.Warn("Permission denied",
+					zap.String("permission", permission),
+					zap.String("role", c.GetString("user_role")),
+					zap.String("user_id", c.GetString("user_id")),
+					zap.String("path", c.Request.URL.Path),
+					zap.String("method", c.Request.Method))
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error": domain.ErrPermissionDenied.Error(),
+				})
+				return
+			}
+		}
 		c.Next()
 	}
 }
+
+// userRoleOf returns the role of the authenticated user, or nil if it no
+// longer exists
+func (m *AuthMiddleware) userRoleOf(c *gin.Context) (*domain.Role, error) {
+	if role, ok := c.Get("user_role_permissions"); ok {
+		return role.(*domain.Role), nil
+	}
+	role, err := m.roleRepo.GetByName(c.Request.Context(), c.GetString("user_role"))
+	if err != nil {
+		return nil, err
+	}
+	c.Set("user_role_permissions", role)
+	return role, nil
+}
@@ -0,0 +1,63 @@
+package payrollformat
+
+import (
+	"fmt"
+	"golang-tes/internal/domain"
+	"strconv"
+	"strings"
+)
+
+// defaultWidths are the fixed-width column widths used when a mapping does
+// not set one
+var defaultWidths = map[string]int{
+	domain.PayrollFieldEmployeeID:        36,
+	domain.PayrollFieldName:              40,
+	domain.PayrollFieldEmail:             60,
+	domain.PayrollFieldPeriodStart:       10,
+	domain.PayrollFieldPeriodEnd:         10,
+	domain.PayrollFieldDaysWorked:        4,
+	domain.PayrollFieldWorkedHours:       8,
+	domain.PayrollFieldOvertimeHours:     8,
+	domain.PayrollFieldAbsentDays:        4,
+	domain.PayrollFieldUnpaidLeaveDays:   4,
+	domain.PayrollFieldUnpaidAbsenceDays: 4,
+	domain.PayrollFieldAnnualLeaveDays:   4,
+	domain.PayrollFieldSickLeaveDays:     4,
+	domain.PayrollFieldPaidLeaveDays:     4,
+}
+
+// DefaultColumns is the mapping used when none is configured
+const DefaultColumns = "employee_id,name,period_start,period_end,days_worked,worked_hours,overtime_hours,unpaid_absence_days,paid_leave_days"
+
+// ParseColumns reads a column mapping: comma separated columns of the form
+// field[:header[:width]], e.g. "employee_id:EmpNo:10,worked_hours:Hours".
+// The header defaults to the field name and the width to the field's default.
+func ParseColumns(spec string) ([]domain.PayrollColumn, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = DefaultColumns
+	}
+
+	var columns []domain.PayrollColumn
+	for _, entry := range strings.Split(spec, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid payroll column %q", entry)
+		}
+		column := domain.PayrollColumn{Field: parts[0], Header: parts[0], Width: defaultWidths[parts[0]]}
+		if !domain.ValidPayrollFields[column.Field] {
+			return nil, fmt.Errorf("unknown payroll field %q", column.Field)
+		}
+		if len(parts) > 1 && parts[1] != "" {
+			column.Header = parts[1]
+		}
+		if len(parts) > 2 {
+			width, err := strconv.Atoi(parts[2])
+			if err != nil || width < 1 {
+				return nil, fmt.Errorf("invalid width of payroll column %q", entry)
+			}
+			column.Width = width
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
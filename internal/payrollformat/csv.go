@@ -0,0 +1,45 @@
+package payrollformat
+
+import (
+	"encoding/csv"
+	"golang-tes/internal/domain"
+	"io"
+)
+
+type csvFormatter struct{}
+
+// NewCSVFormatter writes a header row with the column headers followed by a
+// row per user
+func NewCSVFormatter() domain.PayrollFormatter {
+	return &csvFormatter{}
+}
+
+func (f *csvFormatter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (f *csvFormatter) FileExtension() string {
+	return "csv"
+}
+
+func (f *csvFormatter) Write(w io.Writer, columns []domain.PayrollColumn, lines []domain.PayrollLine) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.Header
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	for i := range lines {
+		for j, column := range columns {
+			record[j] = lines[i].Value(column.Field)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,50 @@
+package payrollformat
+
+import (
+	"bufio"
+	"fmt"
+	"golang-tes/internal/domain"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+type fixedWidthFormatter struct{}
+
+// NewFixedWidthFormatter writes a line per user without a header. Text is
+// left-aligned and cut to the column width, numbers are right-aligned and
+// never cut: a number wider than its column fails the export.
+func NewFixedWidthFormatter() domain.PayrollFormatter {
+	return &fixedWidthFormatter{}
+}
+
+func (f *fixedWidthFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (f *fixedWidthFormatter) FileExtension() string {
+	return "txt"
+}
+
+func (f *fixedWidthFormatter) Write(w io.Writer, columns []domain.PayrollColumn, lines []domain.PayrollLine) error {
+	writer := bufio.NewWriter(w)
+	for i := range lines {
+		for _, column := range columns {
+			value := lines[i].Value(column.Field)
+			padding := column.Width - utf8.RuneCountInString(value)
+			switch {
+			case domain.NumericPayrollFields[column.Field]:
+				if padding < 0 {
+					return fmt.Errorf("%w: %s of user %s", domain.ErrPayrollValueTooWide, column.Field, lines[i].UserID)
+				}
+				writer.WriteString(strings.Repeat(" ", padding) + value)
+			case padding < 0:
+				writer.WriteString(string([]rune(value)[:column.Width]))
+			default:
+				writer.WriteString(value + strings.Repeat(" ", padding))
+			}
+		}
+		writer.WriteString("\r\n")
+	}
+	return writer.Flush()
+}
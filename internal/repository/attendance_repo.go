@@ -4,9 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"golang-tes/internal/domain"
+	"strings"
 	"time"
 )
 
+const attendanceColumns = `id, user_id, attendance_date, roster_entry_id, status, clock_in, clock_out, worked_minutes, break_minutes, missing_break_minutes,
+	overtime_minutes, overtime_status, overtime_reviewed_by, overtime_reviewed_at, overtime_review_note, created_at, updated_at`
+
 type mysqlAttendanceRepository struct {
 	db *sql.DB
 }
@@ -15,9 +19,54 @@ func NewMySQLAttendanceRepository(db *sql.DB) domain.AttendanceRepository {
 	return &mysqlAttendanceRepository{db: db}
 }
 
+// scanAttendance scans a row of attendanceColumns followed by the extra columns
+func scanAttendance(row rowScanner, attendance *domain.Attendance, extra ...interface{}) error {
+	var rosterEntryID, reviewedBy sql.NullString
+	err := row.Scan(append([]interface{}{
+		&attendance.ID,
+		&attendance.UserID,
+		&attendance.Date,
+		&rosterEntryID,
+		&attendance.Status,
+		&attendance.ClockIn,
+		&attendance.ClockOut,
+		&attendance.WorkedMinutes,
+		&attendance.BreakMinutes,
+		&attendance.MissingBreakMinutes,
+		&attendance.OvertimeMinutes,
+		&attendance.OvertimeStatus,
+		&reviewedBy,
+		&attendance.OvertimeReviewedAt,
+		&attendance.OvertimeReviewNote,
+		&attendance.CreatedAt,
+		&attendance.UpdatedAt,
+	}, extra...)...)
+	attendance.RosterEntryID = rosterEntryID.String
+	attendance.OvertimeReviewedBy = reviewedBy.String
+	return err
+}
+
+func (r *mysqlAttendanceRepository) queryAttendances(ctx context.Context, query string, args ...interface{}) ([]domain.Attendance, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attendances []domain.Attendance
+	for rows.Next() {
+		var attendance domain.Attendance
+		if err := scanAttendance(rows, &attendance); err != nil {
+			return nil, err
+		}
+		attendances = append(attendances, attendance)
+	}
+	return attendances, rows.Err()
+}
+
 func (r *mysqlAttendanceRepository) Create(ctx context.Context, attendance *domain.Attendance) error {
-	query := `INSERT INTO attendances (id, user_id, attendance_date, status, created_at, updated_at) 
-			  VALUES (?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO attendances (` + attendanceColumns + `)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 	now := time.Now()
 	attendance.CreatedAt = now
 	attendance.UpdatedAt = now
@@ -25,88 +74,65 @@ func (r *mysqlAttendanceRepository) Create(ctx context.Context, attendance *doma
 		attendance.ID,
 		attendance.UserID,
 		attendance.Date,
+		nullString(attendance.RosterEntryID),
 		attendance.Status,
+		attendance.ClockIn,
+		attendance.ClockOut,
+		attendance.WorkedMinutes,
+		attendance.BreakMinutes,
+		attendance.MissingBreakMinutes,
+		attendance.OvertimeMinutes,
+		attendance.OvertimeStatus,
+		nullString(attendance.OvertimeReviewedBy),
+		attendance.OvertimeReviewedAt,
+		attendance.OvertimeReviewNote,
 		attendance.CreatedAt,
 		attendance.UpdatedAt,
 	)
+	if isDuplicateEntry(err) {
+		return domain.ErrAttendanceAlreadyMarked
+	}
 	return err
 }
 
-func (r *mysqlAttendanceRepository) GetByDate(ctx context.Context, date time.Time) ([]domain.Attendance, error) {
-	query := `SELECT id, user_id, attendance_date, status, created_at, updated_at 
-			  FROM attendances 
-			  WHERE DATE(attendance_date) = DATE(?)`
+func (r *mysqlAttendanceRepository) GetByID(ctx context.Context, id string) (*domain.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + ` FROM attendances WHERE id = ?`
 
-	rows, err := r.db.QueryContext(ctx, query, date)
+	attendance := &domain.Attendance{}
+	err := scanAttendance(r.db.QueryRowContext(ctx, query, id), attendance)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return attendance, nil
+}
 
-	var attendances []domain.Attendance
-	for rows.Next() {
-		var attendance domain.Attendance
-		err := rows.Scan(
-			&attendance.ID,
-			&attendance.UserID,
-			&attendance.Date,
-			&attendance.Status,
-			&attendance.CreatedAt,
-			&attendance.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		attendances = append(attendances, attendance)
-	}
-	return attendances, nil
+func (r *mysqlAttendanceRepository) GetByDate(ctx context.Context, date time.Time) ([]domain.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+			  FROM attendances
+			  WHERE attendance_date = DATE(?)`
+
+	return r.queryAttendances(ctx, query, date)
 }
 
 func (r *mysqlAttendanceRepository) GetByUserID(ctx context.Context, userID string) ([]domain.Attendance, error) {
-	query := `SELECT id, user_id, attendance_date, status, created_at, updated_at 
-			  FROM attendances 
+	query := `SELECT ` + attendanceColumns + `
+			  FROM attendances
 			  WHERE user_id = ?
 			  ORDER BY attendance_date DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var attendances []domain.Attendance
-	for rows.Next() {
-		var attendance domain.Attendance
-		err := rows.Scan(
-			&attendance.ID,
-			&attendance.UserID,
-			&attendance.Date,
-			&attendance.Status,
-			&attendance.CreatedAt,
-			&attendance.UpdatedAt,
-		)
-		if err != nil {
-			return nil, err
-		}
-		attendances = append(attendances, attendance)
-	}
-	return attendances, nil
+	return r.queryAttendances(ctx, query, userID)
 }
 
 func (r *mysqlAttendanceRepository) GetByUserIDAndDate(ctx context.Context, userID string, date time.Time) (*domain.Attendance, error) {
-	query := `SELECT id, user_id, attendance_date, status, created_at, updated_at 
-			  FROM attendances 
-			  WHERE user_id = ? AND DATE(attendance_date) = DATE(?)`
+	query := `SELECT ` + attendanceColumns + `
+			  FROM attendances
+			  WHERE user_id = ? AND attendance_date = DATE(?)`
 
 	attendance := &domain.Attendance{}
-	err := r.db.QueryRowContext(ctx, query, userID, date).Scan(
-		&attendance.ID,
-		&attendance.UserID,
-		&attendance.Date,
-		&attendance.Status,
-		&attendance.CreatedAt,
-		&attendance.UpdatedAt,
-	)
+	err := scanAttendance(r.db.QueryRowContext(ctx, query, userID, date), attendance)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -116,16 +142,206 @@ func (r *mysqlAttendanceRepository) GetByUserIDAndDate(ctx context.Context, user
 	return attendance, nil
 }
 
+func (r *mysqlAttendanceRepository) GetInRange(ctx context.Context, from, to time.Time) ([]domain.Attendance, error) {
+	query := `SELECT ` + attendanceColumns + `
+			  FROM attendances
+			  WHERE attendance_date BETWEEN DATE(?) AND DATE(?)
+			  ORDER BY attendance_date, user_id`
+
+	return r.queryAttendances(ctx, query, from, to)
+}
+
+// attendanceSortColumns maps the sort fields to their columns
+var attendanceSortColumns = map[string]string{
+	domain.AttendanceSortDate:          "a.attendance_date",
+	domain.AttendanceSortStatus:        "a.status",
+	domain.AttendanceSortClockIn:       "a.clock_in",
+	domain.AttendanceSortWorkedMinutes: "a.worked_minutes",
+}
+
+// attendanceFilterClauses returns the WHERE and ORDER BY clauses of the filter
+// for attendances aliased as a
+func attendanceFilterClauses(filter domain.AttendanceFilter) (string, string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+	if len(filter.UserIDs) > 0 {
+		conditions = append(conditions, `a.user_id IN (`+placeholders(len(filter.UserIDs))+`)`)
+		for _, userID := range filter.UserIDs {
+			args = append(args, userID)
+		}
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, `a.attendance_date >= DATE(?)`)
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, `a.attendance_date <= DATE(?)`)
+		args = append(args, filter.To)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, `a.status = ?`)
+		args = append(args, filter.Status)
+	}
+	if filter.OvertimeStatus != "" {
+		conditions = append(conditions, `a.overtime_status = ?`)
+		args = append(args, filter.OvertimeStatus)
+	}
+	if filter.BreakViolation {
+		conditions = append(conditions, `a.missing_break_minutes > 0`)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	field, desc := filter.SortField()
+	column, ok := attendanceSortColumns[field]
+	if !ok {
+		return "", "", nil, domain.ErrInvalidAttendanceSort
+	}
+	if desc {
+		column += ` DESC`
+	}
+	// The id keeps the order of equal values stable between pages
+	order := ` ORDER BY ` + column + `, a.id`
+	return where, order, args, nil
+}
+
+func (r *mysqlAttendanceRepository) List(ctx context.Context, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	where, order, args, err := attendanceFilterClauses(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM attendances a`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + attendanceColumns + ` FROM attendances a` + where + order + ` LIMIT ? OFFSET ?`
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	attendances, err := r.queryAttendances(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return attendances, total, nil
+}
+
+func (r *mysqlAttendanceRepository) Export(ctx context.Context, filter domain.AttendanceFilter, fn func(row domain.AttendanceExportRow) error) error {
+	where, order, args, err := attendanceFilterClauses(filter)
+	if err != nil {
+		return err
+	}
+
+	query := `SELECT a.id, a.user_id, a.attendance_date, a.roster_entry_id, a.status, a.clock_in, a.clock_out, a.worked_minutes, a.break_minutes, a.missing_break_minutes,
+				  a.overtime_minutes, a.overtime_status, a.overtime_reviewed_by, a.overtime_reviewed_at, a.overtime_review_note,
+				  a.created_at, a.updated_at, u.name, u.email
+			  FROM attendances a
+			  JOIN users u ON u.id = a.user_id` + where + order
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// Rows are handed over one at a time so exports of any size use constant memory
+	for rows.Next() {
+		var row domain.AttendanceExportRow
+		if err := scanAttendance(rows, &row.Attendance, &row.UserName, &row.UserEmail); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *mysqlAttendanceRepository) Update(ctx context.Context, attendance *domain.Attendance) error {
-	query := `UPDATE attendances 
-			  SET status = ?, updated_at = ? 
+	query := `UPDATE attendances
+			  SET status = ?, clock_in = ?, clock_out = ?, worked_minutes = ?, break_minutes = ?, missing_break_minutes = ?,
+				  overtime_minutes = ?, overtime_status = ?, overtime_reviewed_by = ?, overtime_reviewed_at = ?, overtime_review_note = ?,
+				  updated_at = ?
 			  WHERE id = ?`
 
 	attendance.UpdatedAt = time.Now()
 	_, err := r.db.ExecContext(ctx, query,
 		attendance.Status,
+		attendance.ClockIn,
+		attendance.ClockOut,
+		attendance.WorkedMinutes,
+		attendance.BreakMinutes,
+		attendance.MissingBreakMinutes,
+		attendance.OvertimeMinutes,
+		attendance.OvertimeStatus,
+		nullString(attendance.OvertimeReviewedBy),
+		attendance.OvertimeReviewedAt,
+		attendance.OvertimeReviewNote,
 		attendance.UpdatedAt,
 		attendance.ID,
 	)
 	return err
 }
+
+func (r *mysqlAttendanceRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM attendances WHERE id = ?`, id)
+	return err
+}
+
+func (r *mysqlAttendanceRepository) AddHistory(ctx context.Context, history *domain.AttendanceHistory) error {
+	query := `INSERT INTO attendance_history (id, attendance_id, user_id, status, clock_in, clock_out, worked_minutes, action, correction_id, changed_by, changed_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query,
+		history.ID,
+		history.AttendanceID,
+		history.UserID,
+		history.Status,
+		history.ClockIn,
+		history.ClockOut,
+		history.WorkedMinutes,
+		history.Action,
+		nullString(history.CorrectionID),
+		nullString(history.ChangedBy),
+		history.ChangedAt,
+	)
+	return err
+}
+
+func (r *mysqlAttendanceRepository) GetHistory(ctx context.Context, attendanceID string) ([]domain.AttendanceHistory, error) {
+	query := `SELECT id, attendance_id, user_id, status, clock_in, clock_out, worked_minutes, action, correction_id, changed_by, changed_at
+			  FROM attendance_history
+			  WHERE attendance_id = ?
+			  ORDER BY changed_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []domain.AttendanceHistory
+	for rows.Next() {
+		var entry domain.AttendanceHistory
+		var correctionID, changedBy sql.NullString
+		err := rows.Scan(
+			&entry.ID,
+			&entry.AttendanceID,
+			&entry.UserID,
+			&entry.Status,
+			&entry.ClockIn,
+			&entry.ClockOut,
+			&entry.WorkedMinutes,
+			&entry.Action,
+			&correctionID,
+			&changedBy,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.CorrectionID = correctionID.String
+		entry.ChangedBy = changedBy.String
+		history = append(history, entry)
+	}
+	return history, rows.Err()
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const breakColumns = `id, attendance_id, type, start_time, end_time, created_at, updated_at`
+
+type mysqlAttendanceBreakRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLAttendanceBreakRepository(db *sql.DB) domain.AttendanceBreakRepository {
+	return &mysqlAttendanceBreakRepository{db: db}
+}
+
+func scanBreak(row rowScanner, attendanceBreak *domain.AttendanceBreak) error {
+	return row.Scan(
+		&attendanceBreak.ID,
+		&attendanceBreak.AttendanceID,
+		&attendanceBreak.Type,
+		&attendanceBreak.StartTime,
+		&attendanceBreak.EndTime,
+		&attendanceBreak.CreatedAt,
+		&attendanceBreak.UpdatedAt,
+	)
+}
+
+func (r *mysqlAttendanceBreakRepository) Create(ctx context.Context, attendanceBreak *domain.AttendanceBreak) error {
+	query := `INSERT INTO attendance_breaks (` + breakColumns + `)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	attendanceBreak.CreatedAt = now
+	attendanceBreak.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		attendanceBreak.ID,
+		attendanceBreak.AttendanceID,
+		attendanceBreak.Type,
+		attendanceBreak.StartTime,
+		attendanceBreak.EndTime,
+		attendanceBreak.CreatedAt,
+		attendanceBreak.UpdatedAt,
+	)
+	return err
+}
+
+func (r *mysqlAttendanceBreakRepository) GetByID(ctx context.Context, id string) (*domain.AttendanceBreak, error) {
+	query := `SELECT ` + breakColumns + ` FROM attendance_breaks WHERE id = ?`
+
+	attendanceBreak := &domain.AttendanceBreak{}
+	err := scanBreak(r.db.QueryRowContext(ctx, query, id), attendanceBreak)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attendanceBreak, nil
+}
+
+func (r *mysqlAttendanceBreakRepository) GetByAttendanceIDs(ctx context.Context, attendanceIDs []string) ([]domain.AttendanceBreak, error) {
+	breaks := []domain.AttendanceBreak{}
+	if len(attendanceIDs) == 0 {
+		return breaks, nil
+	}
+
+	query := `SELECT ` + breakColumns + `
+			  FROM attendance_breaks
+			  WHERE attendance_id IN (` + placeholders(len(attendanceIDs)) + `)
+			  ORDER BY start_time`
+	args := make([]interface{}, len(attendanceIDs))
+	for i, id := range attendanceIDs {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attendanceBreak domain.AttendanceBreak
+		if err := scanBreak(rows, &attendanceBreak); err != nil {
+			return nil, err
+		}
+		breaks = append(breaks, attendanceBreak)
+	}
+	return breaks, rows.Err()
+}
+
+func (r *mysqlAttendanceBreakRepository) Update(ctx context.Context, attendanceBreak *domain.AttendanceBreak) error {
+	query := `UPDATE attendance_breaks
+			  SET type = ?, start_time = ?, end_time = ?, updated_at = ?
+			  WHERE id = ?`
+
+	attendanceBreak.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		attendanceBreak.Type,
+		attendanceBreak.StartTime,
+		attendanceBreak.EndTime,
+		attendanceBreak.UpdatedAt,
+		attendanceBreak.ID,
+	)
+	return err
+}
+
+func (r *mysqlAttendanceBreakRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM attendance_breaks WHERE id = ?`, id)
+	return err
+}
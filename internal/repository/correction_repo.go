@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const correctionColumns = `id, attendance_id, user_id, proposed_status, proposed_clock_in, proposed_clock_out, reason, status, reviewed_by, reviewed_at, review_note, created_at, updated_at`
+
+type mysqlAttendanceCorrectionRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLAttendanceCorrectionRepository(db *sql.DB) domain.AttendanceCorrectionRepository {
+	return &mysqlAttendanceCorrectionRepository{db: db}
+}
+
+func scanCorrection(row rowScanner, correction *domain.AttendanceCorrection) error {
+	var reviewedBy sql.NullString
+	err := row.Scan(
+		&correction.ID,
+		&correction.AttendanceID,
+		&correction.UserID,
+		&correction.ProposedStatus,
+		&correction.ProposedClockIn,
+		&correction.ProposedClockOut,
+		&correction.Reason,
+		&correction.Status,
+		&reviewedBy,
+		&correction.ReviewedAt,
+		&correction.ReviewNote,
+		&correction.CreatedAt,
+		&correction.UpdatedAt,
+	)
+	correction.ReviewedBy = reviewedBy.String
+	return err
+}
+
+func (r *mysqlAttendanceCorrectionRepository) queryCorrections(ctx context.Context, query string, args ...interface{}) ([]domain.AttendanceCorrection, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var corrections []domain.AttendanceCorrection
+	for rows.Next() {
+		var correction domain.AttendanceCorrection
+		if err := scanCorrection(rows, &correction); err != nil {
+			return nil, err
+		}
+		corrections = append(corrections, correction)
+	}
+	return corrections, rows.Err()
+}
+
+func (r *mysqlAttendanceCorrectionRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.AttendanceCorrection, error) {
+	correction := &domain.AttendanceCorrection{}
+	err := scanCorrection(r.db.QueryRowContext(ctx, query, args...), correction)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+func (r *mysqlAttendanceCorrectionRepository) Create(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	query := `INSERT INTO attendance_corrections (id, attendance_id, user_id, proposed_status, proposed_clock_in, proposed_clock_out, reason, status, review_note, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	correction.CreatedAt = now
+	correction.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		correction.ID,
+		correction.AttendanceID,
+		correction.UserID,
+		correction.ProposedStatus,
+		correction.ProposedClockIn,
+		correction.ProposedClockOut,
+		correction.Reason,
+		correction.Status,
+		correction.ReviewNote,
+		correction.CreatedAt,
+		correction.UpdatedAt,
+	)
+	return err
+}
+
+func (r *mysqlAttendanceCorrectionRepository) GetByID(ctx context.Context, id string) (*domain.AttendanceCorrection, error) {
+	query := `SELECT ` + correctionColumns + ` FROM attendance_corrections WHERE id = ?`
+	return r.getOne(ctx, query, id)
+}
+
+func (r *mysqlAttendanceCorrectionRepository) GetByUserID(ctx context.Context, userID string) ([]domain.AttendanceCorrection, error) {
+	query := `SELECT ` + correctionColumns + `
+			  FROM attendance_corrections
+			  WHERE user_id = ?
+			  ORDER BY created_at DESC`
+
+	return r.queryCorrections(ctx, query, userID)
+}
+
+func (r *mysqlAttendanceCorrectionRepository) GetByStatus(ctx context.Context, status string) ([]domain.AttendanceCorrection, error) {
+	query := `SELECT ` + correctionColumns + `
+			  FROM attendance_corrections
+			  WHERE status = ?
+			  ORDER BY created_at`
+
+	return r.queryCorrections(ctx, query, status)
+}
+
+func (r *mysqlAttendanceCorrectionRepository) GetPendingByAttendanceID(ctx context.Context, attendanceID string) (*domain.AttendanceCorrection, error) {
+	query := `SELECT ` + correctionColumns + `
+			  FROM attendance_corrections
+			  WHERE attendance_id = ? AND status = ?
+			  LIMIT 1`
+
+	return r.getOne(ctx, query, attendanceID, domain.CorrectionStatusPending)
+}
+
+func (r *mysqlAttendanceCorrectionRepository) Update(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	query := `UPDATE attendance_corrections
+			  SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?, updated_at = ?
+			  WHERE id = ?`
+
+	correction.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		correction.Status,
+		nullString(correction.ReviewedBy),
+		correction.ReviewedAt,
+		correction.ReviewNote,
+		correction.UpdatedAt,
+		correction.ID,
+	)
+	return err
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const holidayColumns = `id, holiday_date, name, location, created_at, updated_at`
+
+type mysqlHolidayRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLHolidayRepository(db *sql.DB) domain.HolidayRepository {
+	return &mysqlHolidayRepository{db: db}
+}
+
+func scanHoliday(row rowScanner, holiday *domain.Holiday) error {
+	return row.Scan(
+		&holiday.ID,
+		&holiday.Date,
+		&holiday.Name,
+		&holiday.Location,
+		&holiday.CreatedAt,
+		&holiday.UpdatedAt,
+	)
+}
+
+func (r *mysqlHolidayRepository) queryHolidays(ctx context.Context, query string, args ...interface{}) ([]domain.Holiday, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holidays []domain.Holiday
+	for rows.Next() {
+		var holiday domain.Holiday
+		if err := scanHoliday(rows, &holiday); err != nil {
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, rows.Err()
+}
+
+func (r *mysqlHolidayRepository) Create(ctx context.Context, holiday *domain.Holiday) error {
+	query := `INSERT INTO holidays (id, holiday_date, name, location, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	holiday.CreatedAt = now
+	holiday.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		holiday.ID,
+		holiday.Date,
+		holiday.Name,
+		holiday.Location,
+		holiday.CreatedAt,
+		holiday.UpdatedAt,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrHolidayExists
+	}
+	return err
+}
+
+func (r *mysqlHolidayRepository) GetByID(ctx context.Context, id string) (*domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM holidays WHERE id = ?`
+
+	holiday := &domain.Holiday{}
+	err := scanHoliday(r.db.QueryRowContext(ctx, query, id), holiday)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return holiday, nil
+}
+
+func (r *mysqlHolidayRepository) GetByDate(ctx context.Context, date time.Time) ([]domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + `
+			  FROM holidays
+			  WHERE holiday_date = DATE(?)`
+
+	return r.queryHolidays(ctx, query, date)
+}
+
+func (r *mysqlHolidayRepository) GetInRange(ctx context.Context, from, to time.Time) ([]domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + `
+			  FROM holidays
+			  WHERE holiday_date BETWEEN DATE(?) AND DATE(?)
+			  ORDER BY holiday_date, location`
+
+	return r.queryHolidays(ctx, query, from, to)
+}
+
+func (r *mysqlHolidayRepository) Update(ctx context.Context, holiday *domain.Holiday) error {
+	query := `UPDATE holidays
+			  SET holiday_date = ?, name = ?, location = ?, updated_at = ?
+			  WHERE id = ?`
+
+	holiday.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		holiday.Date,
+		holiday.Name,
+		holiday.Location,
+		holiday.UpdatedAt,
+		holiday.ID,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrHolidayExists
+	}
+	return err
+}
+
+func (r *mysqlHolidayRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM holidays WHERE id = ?`, id)
+	return err
+}
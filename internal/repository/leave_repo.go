@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const leaveColumns = `id, user_id, leave_type, start_date, end_date, days, reason, status, reviewed_by, reviewed_at, review_note, created_at, updated_at`
+
+type mysqlLeaveRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLLeaveRepository(db *sql.DB) domain.LeaveRepository {
+	return &mysqlLeaveRepository{db: db}
+}
+
+func scanLeave(row rowScanner, leave *domain.LeaveRequest) error {
+	var reviewedBy sql.NullString
+	err := row.Scan(
+		&leave.ID,
+		&leave.UserID,
+		&leave.Type,
+		&leave.StartDate,
+		&leave.EndDate,
+		&leave.Days,
+		&leave.Reason,
+		&leave.Status,
+		&reviewedBy,
+		&leave.ReviewedAt,
+		&leave.ReviewNote,
+		&leave.CreatedAt,
+		&leave.UpdatedAt,
+	)
+	leave.ReviewedBy = reviewedBy.String
+	return err
+}
+
+func (r *mysqlLeaveRepository) queryLeaves(ctx context.Context, query string, args ...interface{}) ([]domain.LeaveRequest, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leaves []domain.LeaveRequest
+	for rows.Next() {
+		var leave domain.LeaveRequest
+		if err := scanLeave(rows, &leave); err != nil {
+			return nil, err
+		}
+		leaves = append(leaves, leave)
+	}
+	return leaves, rows.Err()
+}
+
+func (r *mysqlLeaveRepository) Create(ctx context.Context, leave *domain.LeaveRequest) error {
+	query := `INSERT INTO leave_requests (id, user_id, leave_type, start_date, end_date, days, reason, status, review_note, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	leave.CreatedAt = now
+	leave.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		leave.ID,
+		leave.UserID,
+		leave.Type,
+		leave.StartDate,
+		leave.EndDate,
+		leave.Days,
+		leave.Reason,
+		leave.Status,
+		leave.ReviewNote,
+		leave.CreatedAt,
+		leave.UpdatedAt,
+	)
+	return err
+}
+
+func (r *mysqlLeaveRepository) GetByID(ctx context.Context, id string) (*domain.LeaveRequest, error) {
+	query := `SELECT ` + leaveColumns + ` FROM leave_requests WHERE id = ?`
+
+	leave := &domain.LeaveRequest{}
+	err := scanLeave(r.db.QueryRowContext(ctx, query, id), leave)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (r *mysqlLeaveRepository) GetByUserID(ctx context.Context, userID string) ([]domain.LeaveRequest, error) {
+	query := `SELECT ` + leaveColumns + `
+			  FROM leave_requests
+			  WHERE user_id = ?
+			  ORDER BY start_date DESC`
+
+	return r.queryLeaves(ctx, query, userID)
+}
+
+func (r *mysqlLeaveRepository) GetByStatus(ctx context.Context, status string) ([]domain.LeaveRequest, error) {
+	query := `SELECT ` + leaveColumns + `
+			  FROM leave_requests
+			  WHERE status = ?
+			  ORDER BY start_date`
+
+	return r.queryLeaves(ctx, query, status)
+}
+
+func (r *mysqlLeaveRepository) GetActiveInRange(ctx context.Context, userID string, from, to time.Time) ([]domain.LeaveRequest, error) {
+	query := `SELECT ` + leaveColumns + `
+			  FROM leave_requests
+			  WHERE user_id = ? AND status IN (?, ?) AND start_date <= DATE(?) AND end_date >= DATE(?)
+			  ORDER BY start_date`
+
+	return r.queryLeaves(ctx, query, userID, domain.LeaveStatusPending, domain.LeaveStatusApproved, to, from)
+}
+
+func (r *mysqlLeaveRepository) Update(ctx context.Context, leave *domain.LeaveRequest) error {
+	query := `UPDATE leave_requests
+			  SET status = ?, reviewed_by = ?, reviewed_at = ?, review_note = ?, updated_at = ?
+			  WHERE id = ?`
+
+	leave.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		leave.Status,
+		nullString(leave.ReviewedBy),
+		leave.ReviewedAt,
+		leave.ReviewNote,
+		leave.UpdatedAt,
+		leave.ID,
+	)
+	return err
+}
+
+func (r *mysqlLeaveRepository) GetBalance(ctx context.Context, userID string, year int, leaveType string) (*domain.LeaveBalance, error) {
+	query := `SELECT user_id, year, leave_type, entitled_days, used_days
+			  FROM leave_balances
+			  WHERE user_id = ? AND year = ? AND leave_type = ?`
+
+	balance := &domain.LeaveBalance{}
+	err := r.db.QueryRowContext(ctx, query, userID, year, leaveType).Scan(
+		&balance.UserID,
+		&balance.Year,
+		&balance.Type,
+		&balance.EntitledDays,
+		&balance.UsedDays,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	balance.RemainingDays = balance.EntitledDays - balance.UsedDays
+	return balance, nil
+}
+
+func (r *mysqlLeaveRepository) SaveBalance(ctx context.Context, balance *domain.LeaveBalance) error {
+	query := `INSERT INTO leave_balances (user_id, year, leave_type, entitled_days, used_days)
+			  VALUES (?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE entitled_days = VALUES(entitled_days), used_days = VALUES(used_days)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		balance.UserID,
+		balance.Year,
+		balance.Type,
+		balance.EntitledDays,
+		balance.UsedDays,
+	)
+	balance.RemainingDays = balance.EntitledDays - balance.UsedDays
+	return err
+}
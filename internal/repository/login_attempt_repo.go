@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type mysqlLoginAttemptRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLLoginAttemptRepository(db *sql.DB) domain.LoginAttemptRepository {
+	return &mysqlLoginAttemptRepository{db: db}
+}
+
+func (r *mysqlLoginAttemptRepository) Get(ctx context.Context, scope, key string) (*domain.LoginThrottle, error) {
+	query := `SELECT scope, attempt_key, failures, locked_until, last_failure_at
+			  FROM login_attempts
+			  WHERE scope = ? AND attempt_key = ?`
+
+	throttle := &domain.LoginThrottle{}
+	err := r.db.QueryRowContext(ctx, query, scope, key).Scan(
+		&throttle.Scope,
+		&throttle.Key,
+		&throttle.Failures,
+		&throttle.LockedUntil,
+		&throttle.LastFailureAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return throttle, nil
+}
+
+func (r *mysqlLoginAttemptRepository) RecordFailure(ctx context.Context, scope, key string, at, windowStart time.Time) (int, error) {
+	// Counting in the upsert keeps concurrent failures from being lost. MySQL
+	// evaluates the assignments in order, so failures sees the old last_failure_at.
+	query := `INSERT INTO login_attempts (scope, attempt_key, failures, last_failure_at)
+			  VALUES (?, ?, 1, ?)
+			  ON DUPLICATE KEY UPDATE
+			      failures = IF(last_failure_at < ?, 1, failures + 1),
+			      last_failure_at = VALUES(last_failure_at)`
+	if _, err := r.db.ExecContext(ctx, query, scope, key, at, windowStart); err != nil {
+		return 0, err
+	}
+
+	var failures int
+	err := r.db.QueryRowContext(ctx, `SELECT failures FROM login_attempts WHERE scope = ? AND attempt_key = ?`, scope, key).Scan(&failures)
+	return failures, err
+}
+
+func (r *mysqlLoginAttemptRepository) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	query := `UPDATE login_attempts SET locked_until = ? WHERE scope = ? AND attempt_key = ?`
+	_, err := r.db.ExecContext(ctx, query, until, scope, key)
+	return err
+}
+
+func (r *mysqlLoginAttemptRepository) Reset(ctx context.Context, scope, key string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM login_attempts WHERE scope = ? AND attempt_key = ?`, scope, key)
+	return err
+}
+
+func (r *mysqlLoginAttemptRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM login_attempts
+			  WHERE last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)`
+	_, err := r.db.ExecContext(ctx, query, before, before)
+	return err
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type mysqlMFARepository struct {
+	db *sql.DB
+}
+
+func NewMySQLMFARepository(db *sql.DB) domain.MFARepository {
+	return &mysqlMFARepository{db: db}
+}
+
+func (r *mysqlMFARepository) GetByUserID(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	query := `SELECT user_id, secret, enabled_at, last_step, created_at FROM user_mfa WHERE user_id = ?`
+
+	mfa := &domain.UserMFA{}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.EnabledAt,
+		&mfa.LastStep,
+		&mfa.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+func (r *mysqlMFARepository) Save(ctx context.Context, mfa *domain.UserMFA) error {
+	query := `INSERT INTO user_mfa (user_id, secret, enabled_at, last_step, created_at)
+			  VALUES (?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE secret = VALUES(secret), enabled_at = VALUES(enabled_at),
+			                          last_step = VALUES(last_step), created_at = VALUES(created_at)`
+	mfa.CreatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		mfa.UserID,
+		mfa.Secret,
+		mfa.EnabledAt,
+		mfa.LastStep,
+		mfa.CreatedAt,
+	)
+	return err
+}
+
+func (r *mysqlMFARepository) Delete(ctx context.Context, userID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlMFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	// The last_step condition makes concurrent logins with the same code race
+	// safely: only one of them records the step
+	query := `UPDATE user_mfa SET last_step = ? WHERE user_id = ? AND last_step < ?`
+	result, err := r.db.ExecContext(ctx, query, step, userID, step)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *mysqlMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []domain.RecoveryCode) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO mfa_recovery_codes (id, user_id, code_hash, created_at) VALUES (?, ?, ?, ?)`
+	now := time.Now()
+	for i := range codes {
+		codes[i].CreatedAt = now
+		if _, err := tx.ExecContext(ctx, query, codes[i].ID, userID, codes[i].CodeHash, codes[i].CreatedAt); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlMFARepository) UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) (bool, error) {
+	query := `UPDATE mfa_recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, at, userID, hash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *mysqlMFARepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = ? AND used_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *mysqlMFARepository) GetRequiredRoles(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT role FROM mfa_required_roles ORDER BY role`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *mysqlMFARepository) SetRequiredRoles(ctx context.Context, roles []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM mfa_required_roles`); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if _, err := tx.ExecContext(ctx, `INSERT IGNORE INTO mfa_required_roles (role) VALUES (?)`, role); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const (
+	departmentColumns = `id, name, description, created_at, updated_at`
+	teamColumns       = `id, department_id, name, description, created_at, updated_at`
+)
+
+type mysqlDepartmentRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLDepartmentRepository(db *sql.DB) domain.DepartmentRepository {
+	return &mysqlDepartmentRepository{db: db}
+}
+
+func scanDepartment(row rowScanner, department *domain.Department) error {
+	return row.Scan(
+		&department.ID,
+		&department.Name,
+		&department.Description,
+		&department.CreatedAt,
+		&department.UpdatedAt,
+	)
+}
+
+func (r *mysqlDepartmentRepository) Create(ctx context.Context, department *domain.Department) error {
+	query := `INSERT INTO departments (id, name, description, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?)`
+	now := time.Now()
+	department.CreatedAt = now
+	department.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		department.ID,
+		department.Name,
+		department.Description,
+		department.CreatedAt,
+		department.UpdatedAt,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrDepartmentExists
+	}
+	return err
+}
+
+func (r *mysqlDepartmentRepository) GetByID(ctx context.Context, id string) (*domain.Department, error) {
+	query := `SELECT ` + departmentColumns + ` FROM departments WHERE id = ?`
+
+	department := &domain.Department{}
+	err := scanDepartment(r.db.QueryRowContext(ctx, query, id), department)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return department, nil
+}
+
+func (r *mysqlDepartmentRepository) GetAll(ctx context.Context) ([]domain.Department, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+departmentColumns+` FROM departments ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	departments := []domain.Department{}
+	for rows.Next() {
+		var department domain.Department
+		if err := scanDepartment(rows, &department); err != nil {
+			return nil, err
+		}
+		departments = append(departments, department)
+	}
+	return departments, rows.Err()
+}
+
+func (r *mysqlDepartmentRepository) Update(ctx context.Context, department *domain.Department) error {
+	query := `UPDATE departments
+			  SET name = ?, description = ?, updated_at = ?
+			  WHERE id = ?`
+
+	department.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		department.Name,
+		department.Description,
+		department.UpdatedAt,
+		department.ID,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrDepartmentExists
+	}
+	return err
+}
+
+// Delete removes the department. Its users are left without a department
+// by the foreign key in schema.sql.
+func (r *mysqlDepartmentRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM departments WHERE id = ?`, id)
+	return err
+}
+
+type mysqlTeamRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLTeamRepository(db *sql.DB) domain.TeamRepository {
+	return &mysqlTeamRepository{db: db}
+}
+
+func scanTeam(row rowScanner, team *domain.Team) error {
+	return row.Scan(
+		&team.ID,
+		&team.DepartmentID,
+		&team.Name,
+		&team.Description,
+		&team.CreatedAt,
+		&team.UpdatedAt,
+	)
+}
+
+func (r *mysqlTeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	query := `INSERT INTO teams (id, department_id, name, description, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	team.CreatedAt = now
+	team.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		team.ID,
+		team.DepartmentID,
+		team.Name,
+		team.Description,
+		team.CreatedAt,
+		team.UpdatedAt,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrTeamExists
+	}
+	return err
+}
+
+func (r *mysqlTeamRepository) GetByID(ctx context.Context, id string) (*domain.Team, error) {
+	query := `SELECT ` + teamColumns + ` FROM teams WHERE id = ?`
+
+	team := &domain.Team{}
+	err := scanTeam(r.db.QueryRowContext(ctx, query, id), team)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return team, nil
+}
+
+func (r *mysqlTeamRepository) GetAll(ctx context.Context, departmentID string) ([]domain.Team, error) {
+	query := `SELECT ` + teamColumns + ` FROM teams`
+	args := []interface{}{}
+	if departmentID != "" {
+		query += ` WHERE department_id = ?`
+		args = append(args, departmentID)
+	}
+	query += ` ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := []domain.Team{}
+	for rows.Next() {
+		var team domain.Team
+		if err := scanTeam(rows, &team); err != nil {
+			return nil, err
+		}
+		teams = append(teams, team)
+	}
+	return teams, rows.Err()
+}
+
+// Update renames the team. Teams cannot move to another department.
+func (r *mysqlTeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	query := `UPDATE teams
+			  SET name = ?, description = ?, updated_at = ?
+			  WHERE id = ?`
+
+	team.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		team.Name,
+		team.Description,
+		team.UpdatedAt,
+		team.ID,
+	)
+	if isDuplicateEntry(err) {
+		return domain.ErrTeamExists
+	}
+	return err
+}
+
+// Delete removes the team. Its users stay in the department without a team
+// by the foreign key in schema.sql.
+func (r *mysqlTeamRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM teams WHERE id = ?`, id)
+	return err
+}
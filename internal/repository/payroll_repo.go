@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const payrollExportColumns = `id, period_start, period_end, format, line_count, forced, exported_by, created_at`
+
+type mysqlPayrollExportRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLPayrollExportRepository(db *sql.DB) domain.PayrollExportRepository {
+	return &mysqlPayrollExportRepository{db: db}
+}
+
+func (r *mysqlPayrollExportRepository) Create(ctx context.Context, export *domain.PayrollExport) error {
+	query := `INSERT INTO payroll_exports (` + payrollExportColumns + `)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	export.CreatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		export.ID,
+		export.PeriodStart,
+		export.PeriodEnd,
+		export.Format,
+		export.LineCount,
+		export.Forced,
+		nullString(export.ExportedBy),
+		export.CreatedAt,
+	)
+	return err
+}
+
+func (r *mysqlPayrollExportRepository) GetOverlapping(ctx context.Context, from, to time.Time) ([]domain.PayrollExport, error) {
+	query := `SELECT ` + payrollExportColumns + `
+			  FROM payroll_exports
+			  WHERE period_start <= DATE(?) AND period_end >= DATE(?)
+			  ORDER BY created_at DESC`
+
+	return r.queryExports(ctx, query, to, from)
+}
+
+func (r *mysqlPayrollExportRepository) GetAll(ctx context.Context) ([]domain.PayrollExport, error) {
+	query := `SELECT ` + payrollExportColumns + `
+			  FROM payroll_exports
+			  ORDER BY created_at DESC`
+
+	return r.queryExports(ctx, query)
+}
+
+func (r *mysqlPayrollExportRepository) queryExports(ctx context.Context, query string, args ...interface{}) ([]domain.PayrollExport, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports := []domain.PayrollExport{}
+	for rows.Next() {
+		var export domain.PayrollExport
+		var exportedBy sql.NullString
+		if err := rows.Scan(
+			&export.ID,
+			&export.PeriodStart,
+			&export.PeriodEnd,
+			&export.Format,
+			&export.LineCount,
+			&export.Forced,
+			&exportedBy,
+			&export.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		export.ExportedBy = exportedBy.String
+		exports = append(exports, export)
+	}
+	return exports, rows.Err()
+}
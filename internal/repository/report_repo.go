@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"strings"
+)
+
+type mysqlReportRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLReportRepository(db *sql.DB) domain.ReportRepository {
+	return &mysqlReportRepository{db: db}
+}
+
+// SummarizeByUser counts the statuses and sums the worked and overtime minutes
+// in MySQL so that no attendance rows are loaded
+func (r *mysqlReportRepository) SummarizeByUser(ctx context.Context, filter domain.ReportFilter) ([]domain.AttendanceSummary, error) {
+	conditions := []string{`a.attendance_date BETWEEN DATE(?) AND DATE(?)`}
+	args := []interface{}{filter.From, filter.To}
+	if len(filter.UserIDs) > 0 {
+		conditions = append(conditions, `a.user_id IN (`+placeholders(len(filter.UserIDs))+`)`)
+		for _, userID := range filter.UserIDs {
+			args = append(args, userID)
+		}
+	}
+	if filter.TeamID != "" {
+		conditions = append(conditions, `u.team_id = ?`)
+		args = append(args, filter.TeamID)
+	}
+
+	query := `SELECT a.user_id, u.name, u.email,
+				  SUM(a.status = ?), SUM(a.status = ?), SUM(a.status = ?), SUM(a.status = ?),
+				  SUM(a.worked_minutes), SUM(a.overtime_minutes),
+				  SUM(CASE WHEN a.overtime_status = ? THEN a.overtime_minutes ELSE 0 END)
+			  FROM attendances a
+			  JOIN users u ON u.id = a.user_id
+			  WHERE ` + strings.Join(conditions, ` AND `) + `
+			  GROUP BY a.user_id, u.name, u.email
+			  ORDER BY u.name, a.user_id`
+	args = append([]interface{}{domain.StatusPresent, domain.StatusLate, domain.StatusAbsent, domain.StatusLeave, domain.OvertimeStatusApproved}, args...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []domain.AttendanceSummary{}
+	for rows.Next() {
+		var summary domain.AttendanceSummary
+		if err := rows.Scan(
+			&summary.UserID,
+			&summary.Name,
+			&summary.Email,
+			&summary.DaysPresent,
+			&summary.DaysLate,
+			&summary.DaysAbsent,
+			&summary.DaysOnLeave,
+			&summary.WorkedMinutes,
+			&summary.OvertimeMinutes,
+			&summary.ApprovedOvertimeMinutes,
+		); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, rows.Err()
+}
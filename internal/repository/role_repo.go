@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+)
+
+type mysqlRoleRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLRoleRepository(db *sql.DB) domain.RoleRepository {
+	return &mysqlRoleRepository{db: db}
+}
+
+func (r *mysqlRoleRepository) List(ctx context.Context) ([]domain.Role, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT name, description, is_system, created_at, updated_at FROM roles ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []domain.Role{}
+	for rows.Next() {
+		var role domain.Role
+		if err := rows.Scan(&role.Name, &role.Description, &role.System, &role.CreatedAt, &role.UpdatedAt); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	permissions, err := r.permissions(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range roles {
+		roles[i].Permissions = permissions[roles[i].Name]
+		if roles[i].Permissions == nil {
+			roles[i].Permissions = []string{}
+		}
+	}
+	return roles, nil
+}
+
+func (r *mysqlRoleRepository) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	query := `SELECT name, description, is_system, created_at, updated_at FROM roles WHERE name = ?`
+
+	role := &domain.Role{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&role.Name, &role.Description, &role.System, &role.CreatedAt, &role.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := r.permissions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	role.Permissions = permissions[name]
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	return role, nil
+}
+
+// permissions returns the permissions of one role, or of all roles when name is empty
+func (r *mysqlRoleRepository) permissions(ctx context.Context, name string) (map[string][]string, error) {
+	query := `SELECT role_name, permission FROM role_permissions`
+	args := []interface{}{}
+	if name != "" {
+		query += ` WHERE role_name = ?`
+		args = append(args, name)
+	}
+	query += ` ORDER BY role_name, permission`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := map[string][]string{}
+	for rows.Next() {
+		var roleName, permission string
+		if err := rows.Scan(&roleName, &permission); err != nil {
+			return nil, err
+		}
+		permissions[roleName] = append(permissions[roleName], permission)
+	}
+	return permissions, rows.Err()
+}
+
+func (r *mysqlRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO roles (name, description, is_system, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`
+	_, err = tx.ExecContext(ctx, query, role.Name, role.Description, role.System, role.CreatedAt, role.UpdatedAt)
+	if isDuplicateEntry(err) {
+		return domain.ErrRoleExists
+	}
+	if err != nil {
+		return err
+	}
+	if err := insertPermissions(ctx, tx, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE roles SET description = ?, updated_at = ? WHERE name = ?`
+	if _, err := tx.ExecContext(ctx, query, role.Description, role.UpdatedAt, role.Name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role_name = ?`, role.Name); err != nil {
+		return err
+	}
+	if err := insertPermissions(ctx, tx, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertPermissions(ctx context.Context, tx *sql.Tx, role *domain.Role) error {
+	for _, permission := range role.Permissions {
+		_, err := tx.ExecContext(ctx, `INSERT IGNORE INTO role_permissions (role_name, permission) VALUES (?, ?)`, role.Name, permission)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mysqlRoleRepository) Delete(ctx context.Context, name string) error {
+	// Permissions and MFA policy entries are removed by ON DELETE CASCADE
+	_, err := r.db.ExecContext(ctx, `DELETE FROM roles WHERE name = ?`, name)
+	return err
+}
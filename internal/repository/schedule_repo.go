@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const workScheduleColumns = `ws.id, ws.name, ws.start_time, ws.end_time, ws.grace_period_minutes, ws.work_days, ws.is_default, ws.created_at, ws.updated_at`
+
+type mysqlWorkScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLWorkScheduleRepository(db *sql.DB) domain.WorkScheduleRepository {
+	return &mysqlWorkScheduleRepository{db: db}
+}
+
+func scanWorkSchedule(row rowScanner, schedule *domain.WorkSchedule) error {
+	var workDays string
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.Name,
+		&schedule.StartTime,
+		&schedule.EndTime,
+		&schedule.GracePeriodMinutes,
+		&workDays,
+		&schedule.IsDefault,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	schedule.WorkDays, err = parseWorkDays(workDays)
+	return err
+}
+
+// formatWorkDays stores weekdays as a comma separated list, e.g. "1,2,3,4,5"
+func formatWorkDays(days []int) string {
+	parts := make([]string, len(days))
+	for i, d := range days {
+		parts[i] = strconv.Itoa(d)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseWorkDays(value string) ([]int, error) {
+	days := []int{}
+	if value == "" {
+		return days, nil
+	}
+	for _, part := range strings.Split(value, ",") {
+		d, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func (r *mysqlWorkScheduleRepository) Create(ctx context.Context, schedule *domain.WorkSchedule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if schedule.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE work_schedules SET is_default = FALSE WHERE is_default = TRUE`); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO work_schedules (id, name, start_time, end_time, grace_period_minutes, work_days, is_default, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	schedule.CreatedAt = now
+	schedule.UpdatedAt = now
+	_, err = tx.ExecContext(ctx, query,
+		schedule.ID,
+		schedule.Name,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.GracePeriodMinutes,
+		formatWorkDays(schedule.WorkDays),
+		schedule.IsDefault,
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlWorkScheduleRepository) GetByID(ctx context.Context, id string) (*domain.WorkSchedule, error) {
+	query := `SELECT ` + workScheduleColumns + ` FROM work_schedules ws WHERE ws.id = ?`
+
+	schedule := &domain.WorkSchedule{}
+	err := scanWorkSchedule(r.db.QueryRowContext(ctx, query, id), schedule)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (r *mysqlWorkScheduleRepository) GetAll(ctx context.Context) ([]domain.WorkSchedule, error) {
+	query := `SELECT ` + workScheduleColumns + ` FROM work_schedules ws ORDER BY ws.name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []domain.WorkSchedule
+	for rows.Next() {
+		var schedule domain.WorkSchedule
+		if err := scanWorkSchedule(rows, &schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+func (r *mysqlWorkScheduleRepository) GetByUserID(ctx context.Context, userID string) (*domain.WorkSchedule, error) {
+	query := `SELECT ` + workScheduleColumns + `
+			  FROM work_schedules ws
+			  LEFT JOIN work_schedule_assignments a ON a.schedule_id = ws.id AND a.user_id = ?
+			  WHERE a.user_id IS NOT NULL OR ws.is_default = TRUE
+			  ORDER BY a.user_id IS NULL
+			  LIMIT 1`
+
+	schedule := &domain.WorkSchedule{}
+	err := scanWorkSchedule(r.db.QueryRowContext(ctx, query, userID), schedule)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (r *mysqlWorkScheduleRepository) Update(ctx context.Context, schedule *domain.WorkSchedule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if schedule.IsDefault {
+		if _, err := tx.ExecContext(ctx, `UPDATE work_schedules SET is_default = FALSE WHERE id <> ?`, schedule.ID); err != nil {
+			return err
+		}
+	}
+
+	query := `UPDATE work_schedules
+			  SET name = ?, start_time = ?, end_time = ?, grace_period_minutes = ?, work_days = ?, is_default = ?, updated_at = ?
+			  WHERE id = ?`
+	schedule.UpdatedAt = time.Now()
+	_, err = tx.ExecContext(ctx, query,
+		schedule.Name,
+		schedule.StartTime,
+		schedule.EndTime,
+		schedule.GracePeriodMinutes,
+		formatWorkDays(schedule.WorkDays),
+		schedule.IsDefault,
+		schedule.UpdatedAt,
+		schedule.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlWorkScheduleRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM work_schedules WHERE id = ?`, id)
+	return err
+}
+
+func (r *mysqlWorkScheduleRepository) AssignUser(ctx context.Context, scheduleID, userID string) error {
+	query := `INSERT INTO work_schedule_assignments (user_id, schedule_id) VALUES (?, ?)
+			  ON DUPLICATE KEY UPDATE schedule_id = VALUES(schedule_id)`
+	_, err := r.db.ExecContext(ctx, query, userID, scheduleID)
+	return err
+}
+
+func (r *mysqlWorkScheduleRepository) UnassignUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM work_schedule_assignments WHERE user_id = ?`, userID)
+	return err
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+const shiftColumns = `s.id, s.name, s.start_time, s.end_time, s.grace_period_minutes, s.created_at, s.updated_at`
+
+const rosterColumns = `r.id, r.user_id, r.shift_id, r.roster_date, r.created_at, ` + shiftColumns
+
+type mysqlShiftRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLShiftRepository(db *sql.DB) domain.ShiftRepository {
+	return &mysqlShiftRepository{db: db}
+}
+
+func shiftFields(shift *domain.Shift) []interface{} {
+	return []interface{}{
+		&shift.ID,
+		&shift.Name,
+		&shift.StartTime,
+		&shift.EndTime,
+		&shift.GracePeriodMinutes,
+		&shift.CreatedAt,
+		&shift.UpdatedAt,
+	}
+}
+
+// scanRosterEntry scans a row of rosterColumns into the entry and its shift
+func scanRosterEntry(row rowScanner, entry *domain.RosterEntry) error {
+	entry.Shift = &domain.Shift{}
+	return row.Scan(append([]interface{}{
+		&entry.ID,
+		&entry.UserID,
+		&entry.ShiftID,
+		&entry.Date,
+		&entry.CreatedAt,
+	}, shiftFields(entry.Shift)...)...)
+}
+
+func (r *mysqlShiftRepository) Create(ctx context.Context, shift *domain.Shift) error {
+	query := `INSERT INTO shifts (id, name, start_time, end_time, grace_period_minutes, created_at, updated_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+	now := time.Now()
+	shift.CreatedAt = now
+	shift.UpdatedAt = now
+	_, err := r.db.ExecContext(ctx, query,
+		shift.ID,
+		shift.Name,
+		shift.StartTime,
+		shift.EndTime,
+		shift.GracePeriodMinutes,
+		shift.CreatedAt,
+		shift.UpdatedAt,
+	)
+	return err
+}
+
+func (r *mysqlShiftRepository) GetByID(ctx context.Context, id string) (*domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts s WHERE s.id = ?`
+
+	shift := &domain.Shift{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(shiftFields(shift)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+func (r *mysqlShiftRepository) GetAll(ctx context.Context) ([]domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts s ORDER BY s.start_time, s.name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shifts := []domain.Shift{}
+	for rows.Next() {
+		var shift domain.Shift
+		if err := rows.Scan(shiftFields(&shift)...); err != nil {
+			return nil, err
+		}
+		shifts = append(shifts, shift)
+	}
+	return shifts, rows.Err()
+}
+
+func (r *mysqlShiftRepository) Update(ctx context.Context, shift *domain.Shift) error {
+	query := `UPDATE shifts
+			  SET name = ?, start_time = ?, end_time = ?, grace_period_minutes = ?, updated_at = ?
+			  WHERE id = ?`
+	shift.UpdatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		shift.Name,
+		shift.StartTime,
+		shift.EndTime,
+		shift.GracePeriodMinutes,
+		shift.UpdatedAt,
+		shift.ID,
+	)
+	return err
+}
+
+func (r *mysqlShiftRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM shifts WHERE id = ?`, id)
+	return err
+}
+
+func (r *mysqlShiftRepository) IsRostered(ctx context.Context, shiftID string) (bool, error) {
+	var rostered bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM roster_entries WHERE shift_id = ?)`, shiftID).Scan(&rostered)
+	return rostered, err
+}
+
+func (r *mysqlShiftRepository) CreateRosterEntries(ctx context.Context, entries []domain.RosterEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO roster_entries (id, user_id, shift_id, roster_date, created_at) VALUES (?, ?, ?, ?, ?)`
+	now := time.Now()
+	for i := range entries {
+		entries[i].CreatedAt = now
+		_, err := tx.ExecContext(ctx, query,
+			entries[i].ID,
+			entries[i].UserID,
+			entries[i].ShiftID,
+			entries[i].Date,
+			entries[i].CreatedAt,
+		)
+		if isDuplicateEntry(err) {
+			return domain.ErrRosterConflict
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *mysqlShiftRepository) GetRosterEntry(ctx context.Context, id string) (*domain.RosterEntry, error) {
+	query := `SELECT ` + rosterColumns + `
+			  FROM roster_entries r
+			  JOIN shifts s ON s.id = r.shift_id
+			  WHERE r.id = ?`
+
+	entry := &domain.RosterEntry{}
+	err := scanRosterEntry(r.db.QueryRowContext(ctx, query, id), entry)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *mysqlShiftRepository) GetRoster(ctx context.Context, from, to time.Time, userIDs []string) ([]domain.RosterEntry, error) {
+	query := `SELECT ` + rosterColumns + `
+			  FROM roster_entries r
+			  JOIN shifts s ON s.id = r.shift_id
+			  WHERE r.roster_date BETWEEN DATE(?) AND DATE(?)`
+	args := []interface{}{from, to}
+	if len(userIDs) > 0 {
+		query += ` AND r.user_id IN (` + placeholders(len(userIDs)) + `)`
+		for _, id := range userIDs {
+			args = append(args, id)
+		}
+	}
+	query += ` ORDER BY r.roster_date, s.start_time, r.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []domain.RosterEntry{}
+	for rows.Next() {
+		var entry domain.RosterEntry
+		if err := scanRosterEntry(rows, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *mysqlShiftRepository) DeleteRosterEntry(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM roster_entries WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type mysqlTokenRepository struct {
+	db *sql.DB
+}
+
+func NewMySQLTokenRepository(db *sql.DB) domain.TokenRepository {
+	return &mysqlTokenRepository{db: db}
+}
+
+func (r *mysqlTokenRepository) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	query := `INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	token.CreatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.FamilyID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+func (r *mysqlTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	query := `SELECT id, user_id, family_id, token_hash, expires_at, revoked_at, replaced_by, created_at
+			  FROM refresh_tokens
+			  WHERE token_hash = ?`
+
+	token := &domain.RefreshToken{}
+	var replacedBy sql.NullString
+	err := r.db.QueryRowContext(ctx, query, hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.FamilyID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RevokedAt,
+		&replacedBy,
+		&token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	token.ReplacedBy = replacedBy.String
+	return token, nil
+}
+
+func (r *mysqlTokenRepository) RevokeRefreshToken(ctx context.Context, id, replacedBy string, at time.Time) (bool, error) {
+	// The revoked_at condition makes concurrent rotations of the same token
+	// race safely: only one of them sees the token as active
+	query := `UPDATE refresh_tokens SET revoked_at = ?, replaced_by = ? WHERE id = ? AND revoked_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, at, nullString(replacedBy), id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *mysqlTokenRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, at, familyID)
+	return err
+}
+
+func (r *mysqlTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID string, at time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, at, userID)
+	return err
+}
+
+func (r *mysqlTokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	query := `INSERT IGNORE INTO revoked_tokens (jti, expires_at) VALUES (?, ?)`
+	_, err := r.db.ExecContext(ctx, query, jti, expiresAt)
+	return err
+}
+
+func (r *mysqlTokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = ?)`, jti).Scan(&exists)
+	return exists, err
+}
+
+// DeleteExpired removes denylist entries and tokens that can no longer be used
+func (r *mysqlTokenRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at < ?`, before); err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM refresh_tokens WHERE expires_at < ?`, before); err != nil {
+		return err
+	}
+	_, err := r.db.ExecContext(ctx, `DELETE FROM one_time_tokens WHERE expires_at < ?`, before)
+	return err
+}
+
+func (r *mysqlTokenRepository) CreateOneTimeToken(ctx context.Context, token *domain.OneTimeToken) error {
+	query := `INSERT INTO one_time_tokens (id, user_id, purpose, token_hash, expires_at, created_at)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	token.CreatedAt = time.Now()
+	_, err := r.db.ExecContext(ctx, query,
+		token.ID,
+		token.UserID,
+		token.Purpose,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	return err
+}
+
+func (r *mysqlTokenRepository) GetOneTimeToken(ctx context.Context, purpose, hash string) (*domain.OneTimeToken, error) {
+	query := `SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+			  FROM one_time_tokens
+			  WHERE purpose = ? AND token_hash = ?`
+
+	token := &domain.OneTimeToken{}
+	err := r.db.QueryRowContext(ctx, query, purpose, hash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *mysqlTokenRepository) UseOneTimeToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE one_time_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL`, at, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *mysqlTokenRepository) InvalidateOneTimeTokens(ctx context.Context, userID, purpose string, at time.Time) error {
+	query := `UPDATE one_time_tokens SET used_at = ? WHERE user_id = ? AND purpose = ? AND used_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, at, userID, purpose)
+	return err
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"golang-tes/internal/domain"
+	"strings"
 )
 
+const userColumns = `id, name, email, password, role, location, department_id, team_id, manager_id, deactivated_at, email_verified_at, pending_email, password_changed_at, created_at`
+
 type mysqlUserRepository struct {
 	db *sql.DB
 }
@@ -14,16 +17,53 @@ func NewMySQLUserRepository(db *sql.DB) domain.UserRepository {
 	return &mysqlUserRepository{db: db}
 }
 
+func scanUser(row rowScanner, user *domain.User) error {
+	var departmentID, teamID, managerID, pendingEmail sql.NullString
+	err := row.Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Password,
+		&user.Role,
+		&user.Location,
+		&departmentID,
+		&teamID,
+		&managerID,
+		&user.DeactivatedAt,
+		&user.EmailVerifiedAt,
+		&pendingEmail,
+		&user.PasswordChangedAt,
+		&user.CreatedAt,
+	)
+	user.DepartmentID = departmentID.String
+	user.TeamID = teamID.String
+	user.ManagerID = managerID.String
+	user.PendingEmail = pendingEmail.String
+	return err
+}
+
 func (r *mysqlUserRepository) Create(ctx context.Context, user *domain.User) error {
-	query := `INSERT INTO users (id, name, email, password, role) VALUES (?, ?, ?, ?, ?)`
-	_, err := r.db.ExecContext(ctx, query, user.ID, user.Name, user.Email, user.Password, user.Role)
+	query := `INSERT INTO users (id, name, email, password, role, location, department_id, team_id, manager_id, email_verified_at)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query,
+		user.ID,
+		user.Name,
+		user.Email,
+		user.Password,
+		user.Role,
+		user.Location,
+		nullString(user.DepartmentID),
+		nullString(user.TeamID),
+		nullString(user.ManagerID),
+		user.EmailVerifiedAt,
+	)
 	return err
 }
 
 func (r *mysqlUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	user := &domain.User{}
-	query := `SELECT id, name, email, password, role FROM users WHERE email = ?`
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role)
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ?`
+	err := scanUser(r.db.QueryRowContext(ctx, query, email), user)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -35,8 +75,8 @@ func (r *mysqlUserRepository) GetByEmail(ctx context.Context, email string) (*do
 
 func (r *mysqlUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	user := &domain.User{}
-	query := `SELECT id, name, email, password, role FROM users WHERE id = ?`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.Name, &user.Email, &user.Password, &user.Role)
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+	err := scanUser(r.db.QueryRowContext(ctx, query, id), user)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -46,8 +86,98 @@ func (r *mysqlUserRepository) GetByID(ctx context.Context, id string) (*domain.U
 	return user, nil
 }
 
+func (r *mysqlUserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users ORDER BY name`
+	return r.queryUsers(ctx, query)
+}
+
+func (r *mysqlUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Search != "" {
+		pattern := "%" + escapeLike(filter.Search) + "%"
+		conditions = append(conditions, `(name LIKE ? OR email LIKE ?)`)
+		args = append(args, pattern, pattern)
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, `role = ?`)
+		args = append(args, filter.Role)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = ` WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + where + ` ORDER BY name, id LIMIT ? OFFSET ?`
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	users, err := r.queryUsers(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r *mysqlUserRepository) queryUsers(ctx context.Context, query string, args ...interface{}) ([]domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var user domain.User
+		if err := scanUser(rows, &user); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 func (r *mysqlUserRepository) Update(ctx context.Context, user *domain.User) error {
-	query := `UPDATE users SET name = ?, email = ?, password = ?, role = ? WHERE id = ?`
-	_, err := r.db.ExecContext(ctx, query, user.Name, user.Email, user.Password, user.Role, user.ID)
+	query := `UPDATE users
+			  SET name = ?, email = ?, password = ?, role = ?, location = ?, department_id = ?, team_id = ?,
+			      manager_id = ?, deactivated_at = ?, email_verified_at = ?, pending_email = ?, password_changed_at = ?
+			  WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query,
+		user.Name,
+		user.Email,
+		user.Password,
+		user.Role,
+		user.Location,
+		nullString(user.DepartmentID),
+		nullString(user.TeamID),
+		nullString(user.ManagerID),
+		user.DeactivatedAt,
+		user.EmailVerifiedAt,
+		nullString(user.PendingEmail),
+		user.PasswordChangedAt,
+		user.ID,
+	)
 	return err
 }
+
+// Delete removes the user. Their attendance, schedule assignment, leave and
+// correction records are removed by the foreign keys in schema.sql, and
+// their reports are left without a manager.
+func (r *mysqlUserRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+	return err
+}
+
+func (r *mysqlUserRepository) GetReports(ctx context.Context, managerID string) ([]domain.User, error) {
+	// UNION rather than UNION ALL stops the recursion should the hierarchy contain a cycle
+	query := `WITH RECURSIVE reports (id) AS (
+				  SELECT id FROM users WHERE manager_id = ?
+				  UNION
+				  SELECT u.id FROM users u JOIN reports r ON u.manager_id = r.id
+			  )
+			  SELECT ` + userColumns + ` FROM users WHERE id IN (SELECT id FROM reports) AND id <> ? ORDER BY name`
+	return r.queryUsers(ctx, query, managerID, managerID)
+}
@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils/logger"
+
+	"go.uber.org/zap"
+)
+
+// AbsenceScheduler periodically marks users without an attendance record as absent
+// once the daily cutoff has passed, and rostered users as soon as their shift has
+// ended. Marking is idempotent, so restarts are safe.
+type AbsenceScheduler struct {
+	attendanceUsecase domain.AttendanceUsecase
+	cutoff            string
+	interval          time.Duration
+	lastRun           time.Time
+}
+
+func NewAbsenceScheduler(attendanceUsecase domain.AttendanceUsecase, cutoff string, interval time.Duration) *AbsenceScheduler {
+	return &AbsenceScheduler{
+		attendanceUsecase: attendanceUsecase,
+		cutoff:            cutoff,
+		interval:          interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled
+func (s *AbsenceScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.run(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.run(ctx, now)
+			}
+		}
+	}()
+}
+
+func (s *AbsenceScheduler) run(ctx context.Context, now time.Time) {
+	s.markShifts(ctx, now)
+
+	// The calendar day in the server's location, which the cutoff is given in
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// On the first run catch up on yesterday in case the server was down at the cutoff
+	if s.lastRun.IsZero() {
+		yesterday := today.AddDate(0, 0, -1)
+		if !s.markDay(ctx, yesterday) {
+			return
+		}
+		s.lastRun = yesterday
+	}
+	if !s.lastRun.Before(today) {
+		return
+	}
+
+	cutoff, err := time.Parse(domain.TimeOfDayFormat, s.cutoff)
+	if err != nil {
+		logger.Error("Invalid absence cutoff", zap.String("cutoff", s.cutoff), zap.Error(err))
+		return
+	}
+	cutoffAt := time.Date(now.Year(), now.Month(), now.Day(), cutoff.Hour(), cutoff.Minute(), 0, 0, now.Location())
+	if now.Before(cutoffAt) {
+		return
+	}
+
+	if s.markDay(ctx, today) {
+		s.lastRun = today
+	}
+}
+
+func (s *AbsenceScheduler) markDay(ctx context.Context, day time.Time) bool {
+	marked, err := s.attendanceUsecase.MarkAbsences(ctx, day)
+	if err != nil {
+		logger.Error("Failed to mark absences",
+			zap.String("date", day.Format(domain.DateFormat)),
+			zap.Error(err))
+		return false
+	}
+	logger.Info("Marked absences",
+		zap.String("date", day.Format(domain.DateFormat)),
+		zap.Int("count", marked))
+	return true
+}
+
+func (s *AbsenceScheduler) markShifts(ctx context.Context, now time.Time) {
+	marked, err := s.attendanceUsecase.MarkShiftAbsences(ctx, now)
+	if err != nil {
+		logger.Error("Failed to mark shift absences", zap.Error(err))
+		return
+	}
+	if marked > 0 {
+		logger.Info("Marked shift absences", zap.Int("count", marked))
+	}
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang-tes/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingUsecase records the days absences are marked for
+type recordingUsecase struct {
+	domain.AttendanceUsecase
+	days []time.Time
+}
+
+func (u *recordingUsecase) MarkAbsences(ctx context.Context, date time.Time) (int, error) {
+	u.days = append(u.days, date)
+	return 0, nil
+}
+
+func (u *recordingUsecase) MarkShiftAbsences(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestAbsenceScheduler_Run_LocalDay(t *testing.T) {
+	jakarta := time.FixedZone("UTC+7", 7*60*60)
+	usecase := &recordingUsecase{}
+	s := NewAbsenceScheduler(usecase, "17:00", time.Minute)
+	ctx := context.Background()
+
+	// 01:00 local is still the previous day in UTC
+	s.run(ctx, time.Date(2024, 7, 2, 1, 0, 0, 0, jakarta))
+	s.run(ctx, time.Date(2024, 7, 2, 16, 59, 0, 0, jakarta))
+	s.run(ctx, time.Date(2024, 7, 2, 17, 0, 0, 0, jakarta))
+	s.run(ctx, time.Date(2024, 7, 2, 18, 0, 0, 0, jakarta))
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 7, 1, 0, 0, 0, 0, jakarta),
+		time.Date(2024, 7, 2, 0, 0, 0, 0, jakarta),
+	}, usecase.days)
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"golang-tes/internal/domain"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,13 +11,29 @@ import (
 
 type attendanceUsecase struct {
 	attendanceRepo domain.AttendanceRepository
+	breakRepo      domain.AttendanceBreakRepository
 	userRepo       domain.UserRepository
+	scheduleRepo   domain.WorkScheduleRepository
+	shiftRepo      domain.ShiftRepository
+	holidayRepo    domain.HolidayRepository
+	roleRepo       domain.RoleRepository
+	overtime       domain.OvertimePolicy
+	breakRules     domain.BreakRules
+	now            func() time.Time
 }
 
-func NewAttendanceUsecase(attendanceRepo domain.AttendanceRepository, userRepo domain.UserRepository) domain.AttendanceUsecase {
+func NewAttendanceUsecase(attendanceRepo domain.AttendanceRepository, breakRepo domain.AttendanceBreakRepository, userRepo domain.UserRepository, scheduleRepo domain.WorkScheduleRepository, shiftRepo domain.ShiftRepository, holidayRepo domain.HolidayRepository, roleRepo domain.RoleRepository, overtime domain.OvertimePolicy, breakRules domain.BreakRules) domain.AttendanceUsecase {
 	return &attendanceUsecase{
 		attendanceRepo: attendanceRepo,
+		breakRepo:      breakRepo,
 		userRepo:       userRepo,
+		scheduleRepo:   scheduleRepo,
+		shiftRepo:      shiftRepo,
+		holidayRepo:    holidayRepo,
+		roleRepo:       roleRepo,
+		overtime:       overtime,
+		breakRules:     breakRules,
+		now:            time.Now,
 	}
 }
 
@@ -28,10 +45,23 @@ func (u *attendanceUsecase) MarkAttendance(ctx context.Context, attendance *doma
 	if user == nil {
 		return domain.ErrUserNotFound
 	}
+	if !user.IsEmailVerified() {
+		return domain.ErrEmailNotVerified
+	}
 
-	// Check if attendance already exists for today
-	today := time.Now().Truncate(24 * time.Hour)
-	existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, attendance.UserID, today)
+	// Check if attendance already exists for the workday
+	now := u.now()
+	entry, err := u.rosteredShift(ctx, user.ID, now)
+	if err != nil {
+		return err
+	}
+	// Rostered shifts are worked regardless of holidays
+	if entry == nil {
+		if err := u.ensureNotHoliday(ctx, user, now.Truncate(24*time.Hour)); err != nil {
+			return err
+		}
+	}
+	existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, attendance.UserID, arrivalDay(entry, now))
 	if err != nil {
 		return err
 	}
@@ -39,45 +69,936 @@ func (u *attendanceUsecase) MarkAttendance(ctx context.Context, attendance *doma
 		return domain.ErrAttendanceAlreadyMarked
 	}
 
-	// Set default status if not provided
-	if attendance.Status == "" {
-		attendance.Status = domain.StatusPresent
+	// Status is derived from the user's shift or work schedule, never taken from the client
+	status, err := u.arrivalStatus(ctx, attendance.UserID, now, entry)
+	if err != nil {
+		return err
 	}
 
 	// Continue with marking attendance
 	attendance.ID = uuid.New().String()
-	attendance.Date = time.Now()
+	attendance.Date = now
+	attendance.Status = status
+	if entry != nil {
+		attendance.Date = entry.Date
+		attendance.RosterEntryID = entry.ID
+	}
 
 	return u.attendanceRepo.Create(ctx, attendance)
 }
 
-func (u *attendanceUsecase) GetAttendanceByDate(ctx context.Context, date time.Time) ([]domain.Attendance, error) {
-	attendances, err := u.attendanceRepo.GetByDate(ctx, date)
+func (u *attendanceUsecase) ClockIn(ctx context.Context, userID string) (*domain.Attendance, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if !user.IsEmailVerified() {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	now := u.now()
+	entry, err := u.rosteredShift(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		if err := u.ensureNotHoliday(ctx, user, now.Truncate(24*time.Hour)); err != nil {
+			return nil, err
+		}
+	}
+	existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, userID, arrivalDay(entry, now))
+	if err != nil {
+		return nil, err
+	}
+
+	// A record marked earlier without clock times only gets its clock-in filled
+	// in. Days of approved leave are not worked; an admin corrects the record
+	// if the user works anyway.
+	if existing != nil {
+		if existing.ClockIn != nil {
+			return nil, domain.ErrAlreadyClockedIn
+		}
+		if existing.Status == domain.StatusLeave {
+			return nil, domain.ErrOnLeave
+		}
+		if existing.Status == domain.StatusAbsent {
+			status, err := u.arrivalStatus(ctx, userID, now, entry)
+			if err != nil {
+				return nil, err
+			}
+			existing.Status = status
+		}
+		existing.ClockIn = &now
+		if err := u.attendanceRepo.Update(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	status, err := u.arrivalStatus(ctx, userID, now, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance := &domain.Attendance{
+		ID:      uuid.New().String(),
+		UserID:  userID,
+		Date:    now,
+		Status:  status,
+		ClockIn: &now,
+	}
+	if entry != nil {
+		attendance.Date = entry.Date
+		attendance.RosterEntryID = entry.ID
+	}
+	if err := u.attendanceRepo.Create(ctx, attendance); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (u *attendanceUsecase) ClockOut(ctx context.Context, userID string) (*domain.Attendance, error) {
+	now := u.now()
+	attendance, err := u.workdayRecord(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil || attendance.ClockIn == nil {
+		return nil, domain.ErrNotClockedIn
+	}
+	if attendance.ClockOut != nil {
+		return nil, domain.ErrAlreadyClockedOut
+	}
+
+	// A break still in progress ends with the workday
+	breaks, err := u.breakRepo.GetByAttendanceIDs(ctx, []string{attendance.ID})
 	if err != nil {
 		return nil, err
 	}
+	if open := openBreak(breaks); open != nil {
+		open.EndTime = &now
+		if err := u.breakRepo.Update(ctx, open); err != nil {
+			return nil, err
+		}
+	}
+
+	attendance.ClockOut = &now
+	if err := u.saveBreaks(ctx, attendance, breaks); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (u *attendanceUsecase) StartBreak(ctx context.Context, userID, breakType string) (*domain.AttendanceBreak, error) {
+	if !domain.ValidBreakTypes[breakType] {
+		return nil, domain.ErrInvalidBreakType
+	}
+	now := u.now()
+	attendance, breaks, err := u.currentWorkday(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if openBreak(breaks) != nil {
+		return nil, domain.ErrBreakInProgress
+	}
+
+	attendanceBreak := &domain.AttendanceBreak{
+		ID:           uuid.New().String(),
+		AttendanceID: attendance.ID,
+		Type:         breakType,
+		StartTime:    now,
+	}
+	if err := u.breakRepo.Create(ctx, attendanceBreak); err != nil {
+		return nil, err
+	}
+	return attendanceBreak, nil
+}
+
+func (u *attendanceUsecase) EndBreak(ctx context.Context, userID string) (*domain.AttendanceBreak, error) {
+	now := u.now()
+	attendance, breaks, err := u.currentWorkday(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	open := openBreak(breaks)
+	if open == nil {
+		return nil, domain.ErrNoBreakInProgress
+	}
+
+	open.EndTime = &now
+	if err := u.breakRepo.Update(ctx, open); err != nil {
+		return nil, err
+	}
+	if err := u.saveBreaks(ctx, attendance, breaks); err != nil {
+		return nil, err
+	}
+	return open, nil
+}
+
+func (u *attendanceUsecase) AddBreak(ctx context.Context, userID string, attendanceBreak *domain.AttendanceBreak) (*domain.Attendance, error) {
+	if !domain.ValidBreakTypes[attendanceBreak.Type] {
+		return nil, domain.ErrInvalidBreakType
+	}
+	end := attendanceBreak.EndTime
+	if end == nil || !end.After(attendanceBreak.StartTime) || end.After(u.now()) {
+		return nil, domain.ErrInvalidBreakTimes
+	}
+
+	attendance, breaks, err := u.ownRecord(ctx, userID, attendanceBreak.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if attendance.ClockIn == nil || attendanceBreak.StartTime.Before(*attendance.ClockIn) ||
+		(attendance.ClockOut != nil && end.After(*attendance.ClockOut)) {
+		return nil, domain.ErrBreakOutsideWorkday
+	}
+	for i := range breaks {
+		if attendanceBreak.Overlaps(&breaks[i]) {
+			return nil, domain.ErrBreakOverlap
+		}
+	}
+
+	attendanceBreak.ID = uuid.New().String()
+	if err := u.breakRepo.Create(ctx, attendanceBreak); err != nil {
+		return nil, err
+	}
+	breaks = append(breaks, *attendanceBreak)
+	sort.SliceStable(breaks, func(i, j int) bool {
+		return breaks[i].StartTime.Before(breaks[j].StartTime)
+	})
+	if err := u.saveBreaks(ctx, attendance, breaks); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (u *attendanceUsecase) DeleteBreak(ctx context.Context, userID, attendanceID, breakID string) (*domain.Attendance, error) {
+	attendance, breaks, err := u.ownRecord(ctx, userID, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make([]domain.AttendanceBreak, 0, len(breaks))
+	for _, attendanceBreak := range breaks {
+		if attendanceBreak.ID != breakID {
+			remaining = append(remaining, attendanceBreak)
+		}
+	}
+	if len(remaining) == len(breaks) {
+		return nil, domain.ErrBreakNotFound
+	}
+
+	if err := u.breakRepo.Delete(ctx, breakID); err != nil {
+		return nil, err
+	}
+	if err := u.saveBreaks(ctx, attendance, remaining); err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+// currentWorkday returns the user's record of the current workday and its
+// breaks, as long as the user is clocked in
+func (u *attendanceUsecase) currentWorkday(ctx context.Context, userID string, now time.Time) (*domain.Attendance, []domain.AttendanceBreak, error) {
+	attendance, err := u.workdayRecord(ctx, userID, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attendance == nil || attendance.ClockIn == nil {
+		return nil, nil, domain.ErrNotClockedIn
+	}
+	if attendance.ClockOut != nil {
+		return nil, nil, domain.ErrAlreadyClockedOut
+	}
+	breaks, err := u.breakRepo.GetByAttendanceIDs(ctx, []string{attendance.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return attendance, breaks, nil
+}
+
+// workdayRecord returns the user's record of today or, while an overnight
+// shift that began yesterday is still open, yesterday's record
+func (u *attendanceUsecase) workdayRecord(ctx context.Context, userID string, now time.Time) (*domain.Attendance, error) {
+	today := now.Truncate(24 * time.Hour)
+	attendance, err := u.attendanceRepo.GetByUserIDAndDate(ctx, userID, today)
+	if err != nil {
+		return nil, err
+	}
+	if attendance != nil && attendance.ClockIn != nil {
+		return attendance, nil
+	}
+
+	previous, err := u.attendanceRepo.GetByUserIDAndDate(ctx, userID, today.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.RosterEntryID != "" && previous.ClockIn != nil && previous.ClockOut == nil {
+		return previous, nil
+	}
+	return attendance, nil
+}
+
+// ownRecord returns one of the user's records and its breaks
+func (u *attendanceUsecase) ownRecord(ctx context.Context, userID, attendanceID string) (*domain.Attendance, []domain.AttendanceBreak, error) {
+	attendance, err := u.attendanceRepo.GetByID(ctx, attendanceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attendance == nil || attendance.UserID != userID {
+		return nil, nil, domain.ErrAttendanceNotFound
+	}
+	breaks, err := u.breakRepo.GetByAttendanceIDs(ctx, []string{attendance.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+	return attendance, breaks, nil
+}
+
+// saveBreaks stores the record with its break time and the worked time and
+// overtime that depend on it
+func (u *attendanceUsecase) saveBreaks(ctx context.Context, attendance *domain.Attendance, breaks []domain.AttendanceBreak) error {
+	attendance.Breaks = breaks
+	attendance.BreakMinutes = 0
+	for i := range breaks {
+		attendance.BreakMinutes += breaks[i].Minutes()
+	}
+	setWorkedTime(attendance, u.breakRules)
+	if err := applyWeekOvertime(ctx, u.attendanceRepo, u.overtime, attendance); err != nil {
+		return err
+	}
+	return u.attendanceRepo.Update(ctx, attendance)
+}
+
+// openBreak returns the break still in progress, if any
+func openBreak(breaks []domain.AttendanceBreak) *domain.AttendanceBreak {
+	for i := range breaks {
+		if breaks[i].EndTime == nil {
+			return &breaks[i]
+		}
+	}
+	return nil
+}
+
+func (u *attendanceUsecase) ListAttendance(ctx context.Context, callerID string, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	if err := validateAttendanceFilter(&filter); err != nil {
+		return nil, 0, err
+	}
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermAttendanceReadAll)
+	if err != nil {
+		return nil, 0, err
+	}
+	filter.UserIDs = nil
+	if !scope.All {
+		filter.UserIDs = scope.UserIDs
+	}
+	return u.listAttendance(ctx, filter)
+}
+
+func (u *attendanceUsecase) ExportAttendance(ctx context.Context, callerID string, filter domain.AttendanceFilter, fn func(row domain.AttendanceExportRow) error) error {
+	if err := validateAttendanceFilter(&filter); err != nil {
+		return err
+	}
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermAttendanceReadAll)
+	if err != nil {
+		return err
+	}
+	filter.UserIDs = nil
+	if !scope.All {
+		filter.UserIDs = scope.UserIDs
+	}
+	return u.attendanceRepo.Export(ctx, filter, fn)
+}
+
+func (u *attendanceUsecase) GetUserAttendance(ctx context.Context, callerID, userID string, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	if err := validateAttendanceFilter(&filter); err != nil {
+		return nil, 0, err
+	}
+	if userID != callerID {
+		scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermAttendanceReadAll)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !scope.Includes(userID) {
+			return nil, 0, domain.ErrPermissionDenied
+		}
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user == nil {
+		return nil, 0, domain.ErrUserNotFound
+	}
+
+	filter.UserIDs = []string{userID}
+	attendances, total, err := u.listAttendance(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := u.attachBreaks(ctx, attendances); err != nil {
+		return nil, 0, err
+	}
+	return attendances, total, nil
+}
+
+// attachBreaks loads the breaks of the records
+func (u *attendanceUsecase) attachBreaks(ctx context.Context, attendances []domain.Attendance) error {
 	if len(attendances) == 0 {
+		return nil
+	}
+	ids := make([]string, len(attendances))
+	for i, attendance := range attendances {
+		ids[i] = attendance.ID
+	}
+	breaks, err := u.breakRepo.GetByAttendanceIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	byAttendance := make(map[string][]domain.AttendanceBreak)
+	for _, attendanceBreak := range breaks {
+		byAttendance[attendanceBreak.AttendanceID] = append(byAttendance[attendanceBreak.AttendanceID], attendanceBreak)
+	}
+	for i := range attendances {
+		attendances[i].Breaks = byAttendance[attendances[i].ID]
+	}
+	return nil
+}
+
+func (u *attendanceUsecase) listAttendance(ctx context.Context, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	attendances, total, err := u.attendanceRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	if attendances == nil {
+		attendances = []domain.Attendance{}
+	}
+	return attendances, total, nil
+}
+
+// validateAttendanceFilter normalizes the filter and checks its statuses, sort and date range
+func validateAttendanceFilter(filter *domain.AttendanceFilter) error {
+	filter.Normalize()
+	if filter.Status != "" && !domain.ValidAttendanceStatuses[filter.Status] {
+		return domain.ErrInvalidAttendanceStatus
+	}
+	if filter.OvertimeStatus != "" && !domain.ValidOvertimeStatuses[filter.OvertimeStatus] {
+		return domain.ErrInvalidOvertimeStatus
+	}
+	if field, _ := filter.SortField(); !domain.ValidAttendanceSortFields[field] {
+		return domain.ErrInvalidAttendanceSort
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+		return domain.ErrInvalidDateRange
+	}
+	return nil
+}
+
+func (u *attendanceUsecase) MarkAbsences(ctx context.Context, date time.Time) (int, error) {
+	day := calendarDay(date)
+
+	users, err := u.userRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := u.attendanceRepo.GetByDate(ctx, day)
+	if err != nil {
+		return 0, err
+	}
+	recorded := make(map[string]bool, len(existing))
+	for _, attendance := range existing {
+		recorded[attendance.UserID] = true
+	}
+
+	holidays, err := u.holidayRepo.GetByDate(ctx, day)
+	if err != nil {
+		return 0, err
+	}
+
+	// Users rostered in the week are only expected at work on their shifts
+	monday := weekStart(day)
+	roster, err := u.shiftRepo.GetRoster(ctx, monday, monday.AddDate(0, 0, 6), nil)
+	if err != nil {
+		return 0, err
+	}
+	rostered := make(map[string]bool, len(roster))
+	var shifts []domain.RosterEntry
+	for _, entry := range roster {
+		rostered[entry.UserID] = true
+		if calendarDay(entry.Date).Equal(calendarDay(day)) {
+			shifts = append(shifts, entry)
+		}
+	}
+
+	marked := 0
+	for _, user := range users {
+		if recorded[user.ID] || rostered[user.ID] || !user.IsActive() {
+			continue
+		}
+		// Users who joined after the day cannot have been absent on it
+		if user.CreatedAt.After(day.Add(24 * time.Hour)) {
+			continue
+		}
+		if observedHolidays(holidays, user.Location).contains(day) {
+			continue
+		}
+
+		expected, err := u.isExpectedAtWork(ctx, user.ID, day)
+		if err != nil {
+			return marked, err
+		}
+		if !expected {
+			continue
+		}
+
+		attendance := &domain.Attendance{
+			ID:     uuid.New().String(),
+			UserID: user.ID,
+			Date:   day,
+			Status: domain.StatusAbsent,
+		}
+		err = u.attendanceRepo.Create(ctx, attendance)
+		if err == domain.ErrAttendanceAlreadyMarked {
+			// Marked concurrently, e.g. the user checked in while the job was running
+			continue
+		}
+		if err != nil {
+			return marked, err
+		}
+		marked++
+	}
+
+	shiftsMarked, err := u.markShiftAbsences(ctx, shifts)
+	return marked + shiftsMarked, err
+}
+
+func (u *attendanceUsecase) MarkShiftAbsences(ctx context.Context, now time.Time) (int, error) {
+	today := now.Truncate(24 * time.Hour)
+	entries, err := u.shiftRepo.GetRoster(ctx, today.AddDate(0, 0, -1), today, nil)
+	if err != nil {
+		return 0, err
+	}
+	return u.markShiftAbsences(ctx, entries)
+}
+
+// markShiftAbsences creates absent records for the rostered shifts that have
+// ended without a record of the user
+func (u *attendanceUsecase) markShiftAbsences(ctx context.Context, entries []domain.RosterEntry) (int, error) {
+	now := u.now()
+	marked := 0
+	for i := range entries {
+		entry := &entries[i]
+		ended, err := entry.EndedBy(now)
+		if err != nil {
+			return marked, err
+		}
+		if !ended {
+			continue
+		}
+
+		existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, entry.UserID, entry.Date)
+		if err != nil {
+			return marked, err
+		}
+		if existing != nil {
+			continue
+		}
+		user, err := u.userRepo.GetByID(ctx, entry.UserID)
+		if err != nil {
+			return marked, err
+		}
+		if user == nil || !user.IsActive() {
+			continue
+		}
+
+		attendance := &domain.Attendance{
+			ID:            uuid.New().String(),
+			UserID:        entry.UserID,
+			Date:          entry.Date,
+			RosterEntryID: entry.ID,
+			Status:        domain.StatusAbsent,
+		}
+		err = u.attendanceRepo.Create(ctx, attendance)
+		if err == domain.ErrAttendanceAlreadyMarked {
+			continue
+		}
+		if err != nil {
+			return marked, err
+		}
+		marked++
+	}
+	return marked, nil
+}
+
+func (u *attendanceUsecase) BackfillAbsences(ctx context.Context, from, to time.Time) (int, error) {
+	from = from.Truncate(24 * time.Hour)
+	to = to.Truncate(24 * time.Hour)
+	if to.Before(from) || to.After(u.now()) || to.Sub(from) > domain.MaxBackfillDays*24*time.Hour {
+		return 0, domain.ErrInvalidDateRange
+	}
+
+	total := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		marked, err := u.MarkAbsences(ctx, day)
+		total += marked
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (u *attendanceUsecase) CreateAttendance(ctx context.Context, attendance *domain.Attendance) error {
+	if !domain.ValidAttendanceStatuses[attendance.Status] {
+		return domain.ErrInvalidAttendanceStatus
+	}
+
+	user, err := u.userRepo.GetByID(ctx, attendance.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	attendance.Date = calendarDay(attendance.Date)
+	if !validClockTimes(attendance, u.now()) {
+		return domain.ErrInvalidClockTimes
+	}
+
+	existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, attendance.UserID, attendance.Date)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrAttendanceAlreadyMarked
+	}
+
+	attendance.ID = uuid.New().String()
+	setWorkedTime(attendance, u.breakRules)
+	if err := applyWeekOvertime(ctx, u.attendanceRepo, u.overtime, attendance); err != nil {
+		return err
+	}
+
+	return u.attendanceRepo.Create(ctx, attendance)
+}
+
+// UpdateAttendance replaces the status and clock times of a record. The user and date stay unchanged.
+func (u *attendanceUsecase) UpdateAttendance(ctx context.Context, attendance *domain.Attendance, changedBy string) error {
+	if !domain.ValidAttendanceStatuses[attendance.Status] {
+		return domain.ErrInvalidAttendanceStatus
+	}
+
+	existing, err := u.attendanceRepo.GetByID(ctx, attendance.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrAttendanceNotFound
+	}
+
+	now := u.now()
+	if !validClockTimes(attendance, now) {
+		return domain.ErrInvalidClockTimes
+	}
+
+	if err := u.attendanceRepo.AddHistory(ctx, newHistory(existing, domain.HistoryActionUpdate, changedBy, now)); err != nil {
+		return err
+	}
+
+	attendance.UserID = existing.UserID
+	attendance.Date = existing.Date
+	attendance.CreatedAt = existing.CreatedAt
+	attendance.BreakMinutes = existing.BreakMinutes
+	setWorkedTime(attendance, u.breakRules)
+	// The review of the overtime stands unless the overtime changes
+	attendance.OvertimeMinutes = existing.OvertimeMinutes
+	attendance.OvertimeStatus = existing.OvertimeStatus
+	attendance.OvertimeReviewedBy = existing.OvertimeReviewedBy
+	attendance.OvertimeReviewedAt = existing.OvertimeReviewedAt
+	attendance.OvertimeReviewNote = existing.OvertimeReviewNote
+	if err := applyWeekOvertime(ctx, u.attendanceRepo, u.overtime, attendance); err != nil {
+		return err
+	}
+	return u.attendanceRepo.Update(ctx, attendance)
+}
+
+func (u *attendanceUsecase) DeleteAttendance(ctx context.Context, id, changedBy string) error {
+	existing, err := u.attendanceRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrAttendanceNotFound
+	}
+
+	if err := u.attendanceRepo.AddHistory(ctx, newHistory(existing, domain.HistoryActionDelete, changedBy, u.now())); err != nil {
+		return err
+	}
+	if err := u.attendanceRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// The rest of the week may no longer pass the weekly threshold
+	deleted := *existing
+	deleted.WorkedMinutes = 0
+	return applyWeekOvertime(ctx, u.attendanceRepo, u.overtime, &deleted)
+}
+
+func (u *attendanceUsecase) ApproveOvertime(ctx context.Context, reviewerID, id, note string) (*domain.Attendance, error) {
+	return u.reviewOvertime(ctx, reviewerID, id, domain.OvertimeStatusApproved, note)
+}
+
+func (u *attendanceUsecase) RejectOvertime(ctx context.Context, reviewerID, id, note string) (*domain.Attendance, error) {
+	return u.reviewOvertime(ctx, reviewerID, id, domain.OvertimeStatusRejected, note)
+}
+
+// reviewOvertime records the decision on a record's pending overtime. Users
+// with overtime:approve review anyone's overtime, managers their reports'.
+func (u *attendanceUsecase) reviewOvertime(ctx context.Context, reviewerID, id, status, note string) (*domain.Attendance, error) {
+	attendance, err := u.attendanceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
 		return nil, domain.ErrAttendanceNotFound
 	}
-	return attendances, nil
+	if attendance.UserID == reviewerID {
+		return nil, domain.ErrOwnOvertime
+	}
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, reviewerID, domain.PermOvertimeApprove)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Includes(attendance.UserID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if attendance.OvertimeStatus != domain.OvertimeStatusPending {
+		return nil, domain.ErrOvertimeNotPending
+	}
+
+	now := u.now()
+	attendance.OvertimeStatus = status
+	attendance.OvertimeReviewedBy = reviewerID
+	attendance.OvertimeReviewedAt = &now
+	attendance.OvertimeReviewNote = note
+	if err := u.attendanceRepo.Update(ctx, attendance); err != nil {
+		return nil, err
+	}
+	return attendance, nil
 }
 
-func (u *attendanceUsecase) GetUserAttendance(ctx context.Context, userID string) ([]domain.Attendance, error) {
+func (u *attendanceUsecase) ListUserAttendance(ctx context.Context, userID string, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	if err := validateAttendanceFilter(&filter); err != nil {
+		return nil, 0, err
+	}
 	user, err := u.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	if user == nil {
-		return nil, domain.ErrUserNotFound
+		return nil, 0, domain.ErrUserNotFound
+	}
+
+	filter.UserIDs = []string{userID}
+	return u.listAttendance(ctx, filter)
+}
+
+func (u *attendanceUsecase) ListAttendanceInRange(ctx context.Context, from, to time.Time) ([]domain.Attendance, error) {
+	from = calendarDay(from)
+	to = calendarDay(to)
+	if to.Before(from) || to.Sub(from) > domain.MaxAttendanceRangeDays*24*time.Hour {
+		return nil, domain.ErrInvalidDateRange
+	}
+	return u.attendanceRepo.GetInRange(ctx, from, to)
+}
+
+// ensureNotHoliday rejects recording attendance on a holiday observed at the user's location
+func (u *attendanceUsecase) ensureNotHoliday(ctx context.Context, user *domain.User, day time.Time) error {
+	holidays, err := u.holidayRepo.GetByDate(ctx, day)
+	if err != nil {
+		return err
+	}
+	if observedHolidays(holidays, user.Location).contains(day) {
+		return domain.ErrHoliday
+	}
+	return nil
+}
+
+// isExpectedAtWork reports whether the user is scheduled to work on the day
+func (u *attendanceUsecase) isExpectedAtWork(ctx context.Context, userID string, day time.Time) (bool, error) {
+	schedule, err := u.scheduleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return false, err
 	}
+	return isWorkingDay(schedule, day), nil
+}
+
+// isWorkingDay reports whether the day is a working day in the schedule.
+// Without a schedule, Monday to Friday are working days.
+func isWorkingDay(schedule *domain.WorkSchedule, day time.Time) bool {
+	if schedule != nil {
+		return schedule.IsWorkDay(day.Weekday())
+	}
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
 
-	attendances, err := u.attendanceRepo.GetByUserID(ctx, userID)
+// rosteredShift returns the user's roster entry of yesterday or today whose
+// shift a clock-in at now belongs to, if any
+func (u *attendanceUsecase) rosteredShift(ctx context.Context, userID string, now time.Time) (*domain.RosterEntry, error) {
+	today := now.Truncate(24 * time.Hour)
+	entries, err := u.shiftRepo.GetRoster(ctx, today.AddDate(0, 0, -1), today, []string{userID})
 	if err != nil {
 		return nil, err
 	}
-	if len(attendances) == 0 {
-		return nil, domain.ErrAttendanceNotFound
+	for i := range entries {
+		covers, err := entries[i].Covers(now)
+		if err != nil {
+			return nil, err
+		}
+		if covers {
+			return &entries[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// arrivalDay returns the date of the record of an arrival at now: the day
+// the rostered shift begins, or else today
+func arrivalDay(entry *domain.RosterEntry, now time.Time) time.Time {
+	if entry != nil {
+		return entry.Date
+	}
+	return now.Truncate(24 * time.Hour)
+}
+
+// arrivalStatus derives present or late from the rostered shift of the
+// arrival or, without one, from the user's work schedule. Users without a
+// schedule, or arriving on a non-working day, are always present.
+func (u *attendanceUsecase) arrivalStatus(ctx context.Context, userID string, arrival time.Time, entry *domain.RosterEntry) (string, error) {
+	if entry != nil {
+		lateAfter, err := entry.LateAfter(arrival)
+		if err != nil {
+			return "", err
+		}
+		if arrival.After(lateAfter) {
+			return domain.StatusLate, nil
+		}
+		return domain.StatusPresent, nil
+	}
+
+	schedule, err := u.scheduleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if schedule == nil || !schedule.IsWorkDay(arrival.Weekday()) {
+		return domain.StatusPresent, nil
 	}
 
-	return attendances, nil
+	lateAfter, err := schedule.LateAfter(arrival)
+	if err != nil {
+		return "", err
+	}
+	if arrival.After(lateAfter) {
+		return domain.StatusLate, nil
+	}
+	return domain.StatusPresent, nil
+}
+
+// newHistory snapshots the current values of a record before it is changed
+func newHistory(attendance *domain.Attendance, action, changedBy string, at time.Time) *domain.AttendanceHistory {
+	return &domain.AttendanceHistory{
+		ID:            uuid.New().String(),
+		AttendanceID:  attendance.ID,
+		UserID:        attendance.UserID,
+		Status:        attendance.Status,
+		ClockIn:       attendance.ClockIn,
+		ClockOut:      attendance.ClockOut,
+		WorkedMinutes: attendance.WorkedMinutes,
+		Action:        action,
+		ChangedBy:     changedBy,
+		ChangedAt:     at,
+	}
+}
+
+// workedMinutes returns the whole minutes between clock-in and clock-out, less the breaks
+func workedMinutes(attendance *domain.Attendance) int {
+	if attendance.ClockIn == nil || attendance.ClockOut == nil {
+		return 0
+	}
+	worked := int(attendance.ClockOut.Sub(*attendance.ClockIn).Minutes()) - attendance.BreakMinutes
+	if worked < 0 {
+		return 0
+	}
+	return worked
+}
+
+// setWorkedTime recomputes the worked time of the record and, once the
+// workday is over, its shortfall against the mandatory break rules
+func setWorkedTime(attendance *domain.Attendance, rules domain.BreakRules) {
+	attendance.WorkedMinutes = workedMinutes(attendance)
+	attendance.MissingBreakMinutes = 0
+	if attendance.ClockOut != nil {
+		attendance.MissingBreakMinutes = rules.MissingMinutes(attendance.WorkedMinutes, attendance.BreakMinutes)
+	}
+}
+
+// weekStart returns the Monday of the week of the day
+func weekStart(day time.Time) time.Time {
+	day = calendarDay(day)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// applyWeekOvertime recomputes the overtime of the user's records in the
+// Monday to Sunday week of the changed record, whose new values may not be
+// stored yet. Other records of the week whose overtime changes are updated;
+// the changed record is left for the caller to store.
+func applyWeekOvertime(ctx context.Context, repo domain.AttendanceRepository, policy domain.OvertimePolicy, changed *domain.Attendance) error {
+	if policy == (domain.OvertimePolicy{}) {
+		return nil
+	}
+
+	monday := weekStart(changed.Date)
+	records, _, err := repo.List(ctx, domain.AttendanceFilter{
+		UserIDs:  []string{changed.UserID},
+		From:     monday,
+		To:       monday.AddDate(0, 0, 6),
+		Sort:     domain.AttendanceSortDate,
+		Page:     1,
+		PageSize: 7,
+	})
+	if err != nil {
+		return err
+	}
+
+	week := []*domain.Attendance{changed}
+	for i := range records {
+		if records[i].ID != changed.ID {
+			week = append(week, &records[i])
+		}
+	}
+	sort.SliceStable(week, func(i, j int) bool {
+		return week[i].Date.Before(week[j].Date)
+	})
+
+	worked := make([]int, len(week))
+	for i, attendance := range week {
+		worked[i] = attendance.WorkedMinutes
+	}
+	for i, overtime := range policy.WeekOvertime(worked) {
+		record := week[i]
+		minutes, status := record.OvertimeMinutes, record.OvertimeStatus
+		record.SetOvertime(overtime)
+		if record == changed || (record.OvertimeMinutes == minutes && record.OvertimeStatus == status) {
+			continue
+		}
+		if err := repo.Update(ctx, record); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -20,11 +20,36 @@ func (m *MockAttendanceRepository) Create(ctx context.Context, attendance *domai
 	return args.Error(0)
 }
 
+func (m *MockAttendanceRepository) List(ctx context.Context, filter domain.AttendanceFilter) ([]domain.Attendance, int, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.Attendance), args.Int(1), args.Error(2)
+}
+
+func (m *MockAttendanceRepository) Export(ctx context.Context, filter domain.AttendanceFilter, fn func(row domain.AttendanceExportRow) error) error {
+	args := m.Called(ctx, filter, fn)
+	if rows, ok := args.Get(0).([]domain.AttendanceExportRow); ok {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 func (m *MockAttendanceRepository) Update(ctx context.Context, attendance *domain.Attendance) error {
 	args := m.Called(ctx, attendance)
 	return args.Error(0)
 }
 
+func (m *MockAttendanceRepository) GetByID(ctx context.Context, id string) (*domain.Attendance, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Attendance), args.Error(1)
+}
+
 func (m *MockAttendanceRepository) GetByUserIDAndDate(ctx context.Context, userID string, date time.Time) (*domain.Attendance, error) {
 	args := m.Called(ctx, userID, date)
 	if args.Get(0) == nil {
@@ -43,11 +68,80 @@ func (m *MockAttendanceRepository) GetByUserID(ctx context.Context, userID strin
 	return args.Get(0).([]domain.Attendance), args.Error(1)
 }
 
+func (m *MockAttendanceRepository) GetInRange(ctx context.Context, from, to time.Time) ([]domain.Attendance, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.Attendance), args.Error(1)
+}
+
+func (m *MockAttendanceRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceRepository) AddHistory(ctx context.Context, history *domain.AttendanceHistory) error {
+	args := m.Called(ctx, history)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceRepository) GetHistory(ctx context.Context, attendanceID string) ([]domain.AttendanceHistory, error) {
+	args := m.Called(ctx, attendanceID)
+	return args.Get(0).([]domain.AttendanceHistory), args.Error(1)
+}
+
+// MockAttendanceBreakRepository is a mock type for domain.AttendanceBreakRepository
+type MockAttendanceBreakRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceBreakRepository) Create(ctx context.Context, attendanceBreak *domain.AttendanceBreak) error {
+	args := m.Called(ctx, attendanceBreak)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceBreakRepository) GetByID(ctx context.Context, id string) (*domain.AttendanceBreak, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceBreak), args.Error(1)
+}
+
+func (m *MockAttendanceBreakRepository) GetByAttendanceIDs(ctx context.Context, attendanceIDs []string) ([]domain.AttendanceBreak, error) {
+	args := m.Called(ctx, attendanceIDs)
+	return args.Get(0).([]domain.AttendanceBreak), args.Error(1)
+}
+
+func (m *MockAttendanceBreakRepository) Update(ctx context.Context, attendanceBreak *domain.AttendanceBreak) error {
+	args := m.Called(ctx, attendanceBreak)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceBreakRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// newEmptyBreakRepository returns a break repository without any breaks
+func newEmptyBreakRepository() *MockAttendanceBreakRepository {
+	m := new(MockAttendanceBreakRepository)
+	m.On("GetByAttendanceIDs", mock.Anything, mock.Anything).Return([]domain.AttendanceBreak{}, nil).Maybe()
+	return m
+}
+
+// adminCaller is a caller who may see everyone's attendance
+var adminCaller = &domain.User{ID: "admin-id", Role: domain.RoleAdmin}
+
+// verifiedUser returns a user who may mark attendance
+func verifiedUser(id string) *domain.User {
+	verifiedAt := time.Now()
+	return &domain.User{ID: id, EmailVerifiedAt: &verifiedAt}
+}
+
 func TestAttendanceUsecase_MarkAttendance(t *testing.T) {
 	type testCase struct {
 		name          string
 		attendance    *domain.Attendance
-		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance)
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance)
 		expectedError error
 	}
 
@@ -58,10 +152,11 @@ func TestAttendanceUsecase_MarkAttendance(t *testing.T) {
 				UserID: "test-user-id",
 				Status: domain.StatusPresent,
 			},
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance) {
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance) {
 				today := time.Now().Truncate(24 * time.Hour)
-				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
 				mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, today).Return(nil, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, mock.AnythingOfType("string")).Return(nil, nil)
 				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
 			},
 			expectedError: nil,
@@ -72,9 +167,9 @@ func TestAttendanceUsecase_MarkAttendance(t *testing.T) {
 				UserID: "test-user-id",
 				Status: domain.StatusPresent,
 			},
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance) {
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance) {
 				today := time.Now().Truncate(24 * time.Hour)
-				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
 				mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, today).Return(&domain.Attendance{}, nil)
 			},
 			expectedError: domain.ErrAttendanceAlreadyMarked,
@@ -86,11 +181,12 @@ func TestAttendanceUsecase_MarkAttendance(t *testing.T) {
 			// Setup
 			mockAttendRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
 			// Set mock behavior
-			tc.mockBehavior(mockAttendRepo, mockUserRepo, ctx, tc.attendance)
+			tc.mockBehavior(mockAttendRepo, mockUserRepo, mockScheduleRepo, ctx, tc.attendance)
 
 			// Execute
 			err := usecase.MarkAttendance(ctx, tc.attendance)
@@ -104,6 +200,7 @@ func TestAttendanceUsecase_MarkAttendance(t *testing.T) {
 			}
 			mockAttendRepo.AssertExpectations(t)
 			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -112,7 +209,7 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 	type testCase struct {
 		name          string
 		attendance    *domain.Attendance
-		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance)
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance)
 		expectedError error
 	}
 
@@ -123,7 +220,7 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 				UserID: "test-user-id",
 				Status: domain.StatusPresent,
 			},
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance) {
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance) {
 				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(nil, domain.ErrDatabase)
 			},
 			expectedError: domain.ErrDatabase,
@@ -134,8 +231,8 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 				UserID: "test-user-id",
 				Status: domain.StatusPresent,
 			},
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance) {
-				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance) {
+				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
 				mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, mock.AnythingOfType("time.Time")).Return(nil, domain.ErrDatabase)
 			},
 			expectedError: domain.ErrDatabase,
@@ -146,9 +243,10 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 				UserID: "test-user-id",
 				Status: domain.StatusPresent,
 			},
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, attendance *domain.Attendance) {
-				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, attendance *domain.Attendance) {
+				mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
 				mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, mock.AnythingOfType("string")).Return(nil, nil)
 				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(domain.ErrDatabase)
 			},
 			expectedError: domain.ErrDatabase,
@@ -160,11 +258,12 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 			// Setup
 			mockAttendRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
 			// Set mock behavior
-			tc.mockBehavior(mockAttendRepo, mockUserRepo, ctx, tc.attendance)
+			tc.mockBehavior(mockAttendRepo, mockUserRepo, mockScheduleRepo, ctx, tc.attendance)
 
 			// Execute
 			err := usecase.MarkAttendance(ctx, tc.attendance)
@@ -173,35 +272,52 @@ func TestAttendanceUsecase_MarkAttendance_Errors(t *testing.T) {
 			assert.ErrorIs(t, err, tc.expectedError)
 			mockAttendRepo.AssertExpectations(t)
 			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
 		})
 	}
 }
 
-func TestAttendanceUsecase_GetAttendanceByDate(t *testing.T) {
+func TestAttendanceUsecase_ListAttendance(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+
 	type testCase struct {
-		name             string
-		date             time.Time
-		mockAttendances  []domain.Attendance
-		expectedError    error
-		expectedResponse []domain.Attendance
+		name          string
+		filter        domain.AttendanceFilter
+		mockRecords   []domain.Attendance
+		mockTotal     int
+		expectedError error
+		expectedTotal int
 	}
 
 	tests := []testCase{
 		{
-			name: "Success",
-			date: time.Now(),
-			mockAttendances: []domain.Attendance{
-				{ID: "1", UserID: "user1"},
-				{ID: "2", UserID: "user2"},
-			},
-			expectedError:    nil,
-			expectedResponse: []domain.Attendance{{ID: "1", UserID: "user1"}, {ID: "2", UserID: "user2"}},
+			name:          "Success",
+			filter:        domain.AttendanceFilter{From: from, To: to, Status: domain.StatusLate, Sort: "clock_in", Page: 2, PageSize: 10},
+			mockRecords:   []domain.Attendance{{ID: "1", UserID: "user1"}, {ID: "2", UserID: "user2"}},
+			mockTotal:     12,
+			expectedTotal: 12,
+		},
+		{
+			name:          "No Records Found",
+			filter:        domain.AttendanceFilter{From: from, To: from},
+			mockRecords:   nil,
+			expectedTotal: 0,
+		},
+		{
+			name:          "Invalid Status",
+			filter:        domain.AttendanceFilter{Status: "sick"},
+			expectedError: domain.ErrInvalidAttendanceStatus,
+		},
+		{
+			name:          "Invalid Sort Field",
+			filter:        domain.AttendanceFilter{Sort: "-password"},
+			expectedError: domain.ErrInvalidAttendanceSort,
 		},
 		{
-			name:            "No Records Found",
-			date:            time.Now(),
-			mockAttendances: []domain.Attendance{},
-			expectedError:   domain.ErrAttendanceNotFound,
+			name:          "Reversed Range",
+			filter:        domain.AttendanceFilter{From: to, To: from},
+			expectedError: domain.ErrInvalidDateRange,
 		},
 	}
 
@@ -209,41 +325,118 @@ func TestAttendanceUsecase_GetAttendanceByDate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockAttendanceRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendanceRepo, mockUserRepo)
+			usecase := NewAttendanceUsecase(mockAttendanceRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
-			mockAttendanceRepo.On("GetByDate", ctx, tc.date).Return(tc.mockAttendances, nil)
+			if tc.expectedError == nil {
+				expected := tc.filter
+				expected.Normalize()
+				mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+				mockAttendanceRepo.On("List", ctx, expected).Return(tc.mockRecords, tc.mockTotal, nil)
+			}
 
-			attendances, err := usecase.GetAttendanceByDate(ctx, tc.date)
+			attendances, total, err := usecase.ListAttendance(ctx, adminCaller.ID, tc.filter)
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
 				assert.Nil(t, attendances)
+				mockAttendanceRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedResponse, attendances)
+				assert.NotNil(t, attendances)
+				assert.Len(t, attendances, len(tc.mockRecords))
+				assert.Equal(t, tc.expectedTotal, total)
 			}
 			mockAttendanceRepo.AssertExpectations(t)
 		})
 	}
 }
 
-func TestAttendanceUsecase_GetAttendanceByDate_Errors(t *testing.T) {
+func TestAttendanceUsecase_ListAttendance_DefaultFilter(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+	mockAttendRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+		return filter.Sort == "-date" && filter.Page == 1 && filter.PageSize == domain.MaxPageSize
+	})).Return([]domain.Attendance{}, 0, nil)
+
+	_, _, err := usecase.ListAttendance(ctx, adminCaller.ID, domain.AttendanceFilter{PageSize: domain.MaxPageSize + 1})
+	assert.NoError(t, err)
+	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_ListUserAttendance(t *testing.T) {
+	t.Run("Pages The User's Records", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+		mockAttendRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+			return assert.ObjectsAreEqual([]string{"user1"}, filter.UserIDs) && filter.Page == 2 && filter.PageSize == 10
+		})).Return([]domain.Attendance{{ID: "1", UserID: "user1"}}, 11, nil)
+
+		attendances, total, err := usecase.ListUserAttendance(ctx, "user1", domain.AttendanceFilter{UserIDs: []string{"user2"}, Page: 2, PageSize: 10})
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		assert.Equal(t, 11, total)
+		mockAttendRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		attendances, _, err := usecase.ListUserAttendance(ctx, "missing", domain.AttendanceFilter{})
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Nil(t, attendances)
+		mockAttendRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttendanceUsecase_ListAttendance_CallerScope(t *testing.T) {
+	all := []domain.Attendance{
+		{ID: "1", UserID: "user-id"},
+		{ID: "2", UserID: "manager-id"},
+		{ID: "3", UserID: "report-id"},
+	}
+
 	type testCase struct {
-		name          string
-		date          time.Time
-		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, date time.Time)
-		expectedError error
+		name            string
+		caller          *domain.User
+		reports         []domain.User
+		expectedUserIDs []string
+		mockRecords     []domain.Attendance
 	}
 
 	tests := []testCase{
 		{
-			name: "Database Error",
-			date: time.Now(),
-			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, date time.Time) {
-				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, domain.ErrDatabase)
-			},
-			expectedError: domain.ErrDatabase,
+			name:            "Employee Sees Own Record",
+			caller:          &domain.User{ID: "user-id", Role: domain.RoleUser},
+			reports:         []domain.User{},
+			expectedUserIDs: []string{"user-id"},
+			mockRecords:     all[:1],
+		},
+		{
+			name:            "Manager Sees Own And Reports' Records",
+			caller:          &domain.User{ID: "manager-id", Role: domain.RoleUser},
+			reports:         []domain.User{{ID: "report-id"}},
+			expectedUserIDs: []string{"manager-id", "report-id"},
+			mockRecords:     all[1:],
+		},
+		{
+			name:            "Admin Sees Every Record",
+			caller:          adminCaller,
+			expectedUserIDs: nil,
+			mockRecords:     all,
 		},
 	}
 
@@ -251,17 +444,99 @@ func TestAttendanceUsecase_GetAttendanceByDate_Errors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockAttendRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
-			tc.mockBehavior(mockAttendRepo, ctx, tc.date)
+			mockUserRepo.On("GetByID", ctx, tc.caller.ID).Return(tc.caller, nil)
+			if tc.reports != nil {
+				mockUserRepo.On("GetReports", ctx, tc.caller.ID).Return(tc.reports, nil)
+			}
+			mockAttendRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+				return assert.ObjectsAreEqual(tc.expectedUserIDs, filter.UserIDs)
+			})).Return(tc.mockRecords, len(tc.mockRecords), nil)
 
-			attendances, err := usecase.GetAttendanceByDate(ctx, tc.date)
-			assert.ErrorIs(t, err, tc.expectedError)
-			assert.Nil(t, attendances)
+			// A caller-supplied user list cannot widen the scope
+			attendances, total, err := usecase.ListAttendance(ctx, tc.caller.ID, domain.AttendanceFilter{UserIDs: []string{"other-id"}})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.mockRecords, attendances)
+			assert.Equal(t, len(tc.mockRecords), total)
+			mockUserRepo.AssertExpectations(t)
 			mockAttendRepo.AssertExpectations(t)
 		})
 	}
+
+	t.Run("Unknown Caller", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "deleted-id").Return(nil, nil)
+
+		attendances, _, err := usecase.ListAttendance(ctx, "deleted-id", domain.AttendanceFilter{})
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Nil(t, attendances)
+		mockAttendRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttendanceUsecase_ExportAttendance(t *testing.T) {
+	ctx := context.Background()
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+	rows := []domain.AttendanceExportRow{
+		{Attendance: domain.Attendance{ID: "1", UserID: "manager-id"}, UserName: "Manager"},
+		{Attendance: domain.Attendance{ID: "2", UserID: "report-id"}, UserName: "Report"},
+	}
+
+	t.Run("Streams Records Within The Caller's Scope", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+		mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "report-id"}}, nil)
+		mockAttendRepo.On("Export", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+			return assert.ObjectsAreEqual([]string{"manager-id", "report-id"}, filter.UserIDs) && filter.Status == domain.StatusLate
+		}), mock.Anything).Return(rows, nil)
+
+		var exported []string
+		err := usecase.ExportAttendance(ctx, manager.ID, domain.AttendanceFilter{Status: domain.StatusLate}, func(row domain.AttendanceExportRow) error {
+			exported = append(exported, row.UserName)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Manager", "Report"}, exported)
+		mockAttendRepo.AssertExpectations(t)
+	})
+
+	t.Run("Write Error Stops The Export", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+		mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+		mockAttendRepo.On("Export", ctx, mock.AnythingOfType("domain.AttendanceFilter"), mock.Anything).Return(rows, nil)
+
+		calls := 0
+		err := usecase.ExportAttendance(ctx, adminCaller.ID, domain.AttendanceFilter{}, func(row domain.AttendanceExportRow) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("Invalid Filter", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+		err := usecase.ExportAttendance(ctx, adminCaller.ID, domain.AttendanceFilter{Sort: "email"}, func(row domain.AttendanceExportRow) error {
+			t.Fatal("no rows expected")
+			return nil
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidAttendanceSort)
+		mockAttendRepo.AssertNotCalled(t, "Export", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestAttendanceUsecase_GetUserAttendance(t *testing.T) {
@@ -301,8 +576,8 @@ func TestAttendanceUsecase_GetUserAttendance(t *testing.T) {
 			mockUser: &domain.User{
 				ID: "test-user-id",
 			},
-			mockAttendances: []domain.Attendance{},
-			expectedError:   domain.ErrAttendanceNotFound,
+			mockAttendances:  []domain.Attendance{},
+			expectedResponse: []domain.Attendance{},
 		},
 	}
 
@@ -310,15 +585,18 @@ func TestAttendanceUsecase_GetUserAttendance(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockAttendanceRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendanceRepo, mockUserRepo)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendanceRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
 			mockUserRepo.On("GetByID", ctx, tc.userID).Return(tc.mockUser, nil)
 			if tc.mockUser != nil {
-				mockAttendanceRepo.On("GetByUserID", ctx, tc.userID).Return(tc.mockAttendances, nil)
+				mockAttendanceRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+					return len(filter.UserIDs) == 1 && filter.UserIDs[0] == tc.userID
+				})).Return(tc.mockAttendances, len(tc.mockAttendances), nil)
 			}
 
-			attendances, err := usecase.GetUserAttendance(ctx, tc.userID)
+			attendances, total, err := usecase.GetUserAttendance(ctx, tc.userID, tc.userID, domain.AttendanceFilter{})
 
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
@@ -326,6 +604,7 @@ func TestAttendanceUsecase_GetUserAttendance(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedResponse, attendances)
+				assert.Equal(t, len(tc.expectedResponse), total)
 			}
 			mockUserRepo.AssertExpectations(t)
 			mockAttendanceRepo.AssertExpectations(t)
@@ -337,6 +616,7 @@ func TestAttendanceUsecase_GetUserAttendance_Errors(t *testing.T) {
 	type testCase struct {
 		name          string
 		userID        string
+		filter        domain.AttendanceFilter
 		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, userID string)
 		expectedError error
 	}
@@ -351,38 +631,87 @@ func TestAttendanceUsecase_GetUserAttendance_Errors(t *testing.T) {
 			expectedError: domain.ErrDatabase,
 		},
 		{
-			name:   "Database Error on GetByUserID",
+			name:   "Database Error on List",
 			userID: "test-user-id",
 			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, userID string) {
 				mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
-				mockAttendRepo.On("GetByUserID", ctx, userID).Return([]domain.Attendance{}, domain.ErrDatabase)
+				mockAttendRepo.On("List", ctx, mock.AnythingOfType("domain.AttendanceFilter")).Return([]domain.Attendance{}, 0, domain.ErrDatabase)
 			},
 			expectedError: domain.ErrDatabase,
 		},
+		{
+			name:   "Invalid Status",
+			userID: "test-user-id",
+			filter: domain.AttendanceFilter{Status: "sick"},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context, userID string) {
+			},
+			expectedError: domain.ErrInvalidAttendanceStatus,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockAttendRepo := new(MockAttendanceRepository)
 			mockUserRepo := new(MockUserRepository)
-			usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 			ctx := context.Background()
 
 			tc.mockBehavior(mockAttendRepo, mockUserRepo, ctx, tc.userID)
 
-			attendances, err := usecase.GetUserAttendance(ctx, tc.userID)
+			attendances, _, err := usecase.GetUserAttendance(ctx, tc.userID, tc.userID, tc.filter)
 			assert.ErrorIs(t, err, tc.expectedError)
 			assert.Nil(t, attendances)
 			mockAttendRepo.AssertExpectations(t)
 			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestAttendanceUsecase_GetUserAttendance_Scope(t *testing.T) {
+	ctx := context.Background()
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+
+	t.Run("Report Of The Caller", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+		mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "lead-id"}, {ID: "engineer-id"}}, nil)
+		mockUserRepo.On("GetByID", ctx, "engineer-id").Return(&domain.User{ID: "engineer-id"}, nil)
+		mockAttendRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+			return len(filter.UserIDs) == 1 && filter.UserIDs[0] == "engineer-id"
+		})).Return([]domain.Attendance{{ID: "1", UserID: "engineer-id"}}, 1, nil)
+
+		attendances, _, err := usecase.GetUserAttendance(ctx, manager.ID, "engineer-id", domain.AttendanceFilter{})
+		assert.NoError(t, err)
+		assert.Len(t, attendances, 1)
+		mockUserRepo.AssertExpectations(t)
+		mockAttendRepo.AssertExpectations(t)
+	})
+
+	t.Run("Outside The Caller's Scope", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+		mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "lead-id"}}, nil)
+
+		attendances, _, err := usecase.GetUserAttendance(ctx, manager.ID, "colleague-id", domain.AttendanceFilter{})
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		assert.Nil(t, attendances)
+		mockAttendRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
+
 func TestAttendanceUsecase_MarkAttendance_DefaultStatus(t *testing.T) {
 	mockAttendRepo := new(MockAttendanceRepository)
 	mockUserRepo := new(MockUserRepository)
-	usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 	ctx := context.Background()
 
 	attendance := &domain.Attendance{
@@ -390,8 +719,9 @@ func TestAttendanceUsecase_MarkAttendance_DefaultStatus(t *testing.T) {
 		// Status is intentionally empty to test default status
 	}
 
-	mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+	mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
 	mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+	mockScheduleRepo.On("GetByUserID", ctx, attendance.UserID).Return(nil, nil)
 	mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
 
 	err := usecase.MarkAttendance(ctx, attendance)
@@ -401,16 +731,17 @@ func TestAttendanceUsecase_MarkAttendance_DefaultStatus(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
-func TestAttendanceUsecase_GetAttendanceByDate_DatabaseError(t *testing.T) {
+func TestAttendanceUsecase_ListAttendance_DatabaseError(t *testing.T) {
 	mockAttendRepo := new(MockAttendanceRepository)
 	mockUserRepo := new(MockUserRepository)
-	usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 	ctx := context.Background()
-	date := time.Now()
 
-	mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, domain.ErrDatabase)
+	mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+	mockAttendRepo.On("List", ctx, mock.AnythingOfType("domain.AttendanceFilter")).Return([]domain.Attendance{}, 0, domain.ErrDatabase)
 
-	attendances, err := usecase.GetAttendanceByDate(ctx, date)
+	attendances, _, err := usecase.ListAttendance(ctx, adminCaller.ID, domain.AttendanceFilter{})
 	assert.ErrorIs(t, err, domain.ErrDatabase)
 	assert.Nil(t, attendances)
 	mockAttendRepo.AssertExpectations(t)
@@ -419,7 +750,8 @@ func TestAttendanceUsecase_GetAttendanceByDate_DatabaseError(t *testing.T) {
 func TestAttendanceUsecase_MarkAttendance_UserNotFound(t *testing.T) {
 	mockAttendRepo := new(MockAttendanceRepository)
 	mockUserRepo := new(MockUserRepository)
-	usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 	ctx := context.Background()
 
 	attendance := &domain.Attendance{
@@ -434,34 +766,1388 @@ func TestAttendanceUsecase_MarkAttendance_UserNotFound(t *testing.T) {
 	mockUserRepo.AssertExpectations(t)
 }
 
-func TestAttendanceUsecase_GetUserAttendance_DatabaseErrorOnGetByID(t *testing.T) {
+func TestAttendanceUsecase_MarkAttendance_EmailNotVerified(t *testing.T) {
 	mockAttendRepo := new(MockAttendanceRepository)
 	mockUserRepo := new(MockUserRepository)
-	usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 	ctx := context.Background()
 
-	userID := "test-id"
-	mockUserRepo.On("GetByID", ctx, userID).Return(nil, domain.ErrDatabase)
+	attendance := &domain.Attendance{UserID: "unverified-id"}
 
-	attendances, err := usecase.GetUserAttendance(ctx, userID)
-	assert.ErrorIs(t, err, domain.ErrDatabase)
-	assert.Nil(t, attendances)
+	mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(&domain.User{ID: attendance.UserID}, nil)
+
+	err := usecase.MarkAttendance(ctx, attendance)
+	assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
 	mockUserRepo.AssertExpectations(t)
+	mockAttendRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
-func TestAttendanceUsecase_GetUserAttendance_DatabaseErrorOnGetByUserID(t *testing.T) {
+func TestAttendanceUsecase_GetUserAttendance_DatabaseErrorOnGetByID(t *testing.T) {
 	mockAttendRepo := new(MockAttendanceRepository)
 	mockUserRepo := new(MockUserRepository)
-	usecase := NewAttendanceUsecase(mockAttendRepo, mockUserRepo)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
 	ctx := context.Background()
 
 	userID := "test-id"
-	mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
-	mockAttendRepo.On("GetByUserID", ctx, userID).Return([]domain.Attendance{}, domain.ErrDatabase)
+	mockUserRepo.On("GetByID", ctx, userID).Return(nil, domain.ErrDatabase)
 
-	attendances, err := usecase.GetUserAttendance(ctx, userID)
+	attendances, _, err := usecase.GetUserAttendance(ctx, userID, userID, domain.AttendanceFilter{})
 	assert.ErrorIs(t, err, domain.ErrDatabase)
 	assert.Nil(t, attendances)
 	mockUserRepo.AssertExpectations(t)
-	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_ClockIn(t *testing.T) {
+	type testCase struct {
+		name          string
+		userID        string
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:   "Success New Record",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				mockUserRepo.On("GetByID", ctx, userID).Return(verifiedUser(userID), nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, mock.AnythingOfType("string")).Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Success Existing Record Without Clock In",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				mockUserRepo.On("GetByID", ctx, userID).Return(verifiedUser(userID), nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID, Status: domain.StatusPresent}, nil)
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "Already Clocked In",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				clockIn := time.Now().Add(-time.Hour)
+				mockUserRepo.On("GetByID", ctx, userID).Return(verifiedUser(userID), nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID, ClockIn: &clockIn}, nil)
+			},
+			expectedError: domain.ErrAlreadyClockedIn,
+		},
+		{
+			name:   "User Not Found",
+			userID: "non-existent-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				mockUserRepo.On("GetByID", ctx, userID).Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+		{
+			name:   "Email Not Verified",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+			},
+			expectedError: domain.ErrEmailNotVerified,
+		},
+		{
+			name:   "Database Error on Create",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, userID string) {
+				mockUserRepo.On("GetByID", ctx, userID).Return(verifiedUser(userID), nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, mock.AnythingOfType("string")).Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(domain.ErrDatabase)
+			},
+			expectedError: domain.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockAttendRepo, mockUserRepo, mockScheduleRepo, ctx, tc.userID)
+
+			attendance, err := usecase.ClockIn(ctx, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendance)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, attendance.ClockIn)
+				assert.Nil(t, attendance.ClockOut)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_ClockIn_MarkedRecord(t *testing.T) {
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	now := monday.Add(10 * time.Hour)
+	schedule := &domain.WorkSchedule{StartTime: "09:00", EndTime: "17:00", GracePeriodMinutes: 15, WorkDays: []int{1, 2, 3, 4, 5}}
+
+	type testCase struct {
+		name           string
+		status         string
+		expectedStatus string
+		expectedError  error
+	}
+
+	tests := []testCase{
+		{name: "Absent Arrives Late", status: domain.StatusAbsent, expectedStatus: domain.StatusLate},
+		{name: "Present Keeps Status", status: domain.StatusPresent, expectedStatus: domain.StatusPresent},
+		{name: "On Leave", status: domain.StatusLeave, expectedError: domain.ErrOnLeave},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+			uc.now = func() time.Time { return now }
+			ctx := context.Background()
+
+			mockUserRepo.On("GetByID", ctx, "user-id").Return(verifiedUser("user-id"), nil)
+			mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", monday).Return(&domain.Attendance{ID: "1", UserID: "user-id", Date: monday, Status: tc.status}, nil)
+			mockScheduleRepo.On("GetByUserID", ctx, "user-id").Return(schedule, nil).Maybe()
+			if tc.expectedError == nil {
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			}
+
+			attendance, err := uc.ClockIn(ctx, "user-id")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendance)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, attendance.Status)
+				assert.Equal(t, now, *attendance.ClockIn)
+			}
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_ClockOut(t *testing.T) {
+	now := time.Date(2024, 7, 1, 17, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		userID        string
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:   "Success",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string) {
+				clockIn := now.Add(-90 * time.Minute)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID, ClockIn: &clockIn}, nil)
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "No Attendance Record",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string) {
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(nil, nil)
+			},
+			expectedError: domain.ErrNotClockedIn,
+		},
+		{
+			name:   "Marked Without Clock In",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string) {
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID}, nil)
+			},
+			expectedError: domain.ErrNotClockedIn,
+		},
+		{
+			name:   "Already Clocked Out",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string) {
+				clockIn := now.Add(-2 * time.Hour)
+				clockOut := now.Add(-time.Hour)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID, ClockIn: &clockIn, ClockOut: &clockOut}, nil)
+			},
+			expectedError: domain.ErrAlreadyClockedOut,
+		},
+		{
+			name:   "Database Error on Update",
+			userID: "test-user-id",
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, ctx context.Context, userID string) {
+				clockIn := now.Add(-time.Hour)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, userID, mock.AnythingOfType("time.Time")).Return(&domain.Attendance{ID: "1", UserID: userID, ClockIn: &clockIn}, nil)
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(domain.ErrDatabase)
+			},
+			expectedError: domain.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+			usecase.now = func() time.Time { return now }
+			ctx := context.Background()
+
+			tc.mockBehavior(mockAttendRepo, ctx, tc.userID)
+
+			attendance, err := usecase.ClockOut(ctx, tc.userID)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendance)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, now, *attendance.ClockOut)
+				assert.Equal(t, 90, attendance.WorkedMinutes)
+			}
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_ClockOut_Overtime(t *testing.T) {
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	friday := monday.AddDate(0, 0, 4)
+	worked := func(day time.Time, minutes int) domain.Attendance {
+		return domain.Attendance{ID: day.Format(domain.DateFormat), UserID: "user-id", Date: day, WorkedMinutes: minutes}
+	}
+
+	type testCase struct {
+		name             string
+		policy           domain.OvertimePolicy
+		earlierDays      []domain.Attendance
+		expectedOvertime int
+	}
+
+	tests := []testCase{
+		{
+			name:             "Beyond The Daily Threshold",
+			policy:           domain.OvertimePolicy{DailyMinutes: 480, WeeklyMinutes: 2400},
+			earlierDays:      []domain.Attendance{},
+			expectedOvertime: 120,
+		},
+		{
+			name:   "Beyond The Weekly Threshold",
+			policy: domain.OvertimePolicy{DailyMinutes: 600, WeeklyMinutes: 2400},
+			earlierDays: []domain.Attendance{
+				worked(monday, 600),
+				worked(monday.AddDate(0, 0, 1), 600),
+				worked(monday.AddDate(0, 0, 2), 600),
+				worked(monday.AddDate(0, 0, 3), 540),
+			},
+			expectedOvertime: 540,
+		},
+		{
+			name:             "Thresholds Disabled",
+			policy:           domain.OvertimePolicy{},
+			expectedOvertime: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), tc.policy, nil).(*attendanceUsecase)
+			now := friday.Add(19 * time.Hour)
+			uc.now = func() time.Time { return now }
+			ctx := context.Background()
+
+			clockIn := friday.Add(9 * time.Hour)
+			mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", friday).Return(&domain.Attendance{ID: "friday-id", UserID: "user-id", Date: friday, ClockIn: &clockIn}, nil)
+			if tc.earlierDays != nil {
+				mockAttendRepo.On("List", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+					return filter.From.Equal(monday) && filter.To.Equal(monday.AddDate(0, 0, 6)) && filter.UserIDs[0] == "user-id"
+				})).Return(tc.earlierDays, len(tc.earlierDays), nil)
+			}
+			mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil).Once()
+
+			attendance, err := uc.ClockOut(ctx, "user-id")
+			assert.NoError(t, err)
+			assert.Equal(t, 600, attendance.WorkedMinutes)
+			assert.Equal(t, tc.expectedOvertime, attendance.OvertimeMinutes)
+			if tc.expectedOvertime > 0 {
+				assert.Equal(t, domain.OvertimeStatusPending, attendance.OvertimeStatus)
+			} else {
+				assert.Empty(t, attendance.OvertimeStatus)
+			}
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_UpdateAttendance_RecomputesLaterOvertime(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	policy := domain.OvertimePolicy{WeeklyMinutes: 900}
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), policy, nil)
+	ctx := context.Background()
+
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	reviewedAt := monday.AddDate(0, 0, 2)
+	existing := &domain.Attendance{ID: "monday-id", UserID: "user-id", Date: monday, Status: domain.StatusPresent, WorkedMinutes: 600}
+	tuesday := domain.Attendance{ID: "tuesday-id", UserID: "user-id", Date: monday.AddDate(0, 0, 1), WorkedMinutes: 600,
+		OvertimeMinutes: 300, OvertimeStatus: domain.OvertimeStatusApproved, OvertimeReviewedBy: "manager-id", OvertimeReviewedAt: &reviewedAt}
+
+	mockAttendRepo.On("GetByID", ctx, "monday-id").Return(existing, nil)
+	mockAttendRepo.On("AddHistory", ctx, mock.AnythingOfType("*domain.AttendanceHistory")).Return(nil)
+	mockAttendRepo.On("List", ctx, mock.AnythingOfType("domain.AttendanceFilter")).Return([]domain.Attendance{*existing, tuesday}, 2, nil)
+	// Monday shrinks to 5 hours, so Tuesday no longer passes the weekly threshold
+	mockAttendRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.ID == "tuesday-id" && a.OvertimeMinutes == 0 && a.OvertimeStatus == "" && a.OvertimeReviewedAt == nil
+	})).Return(nil).Once()
+	mockAttendRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.ID == "monday-id" && a.WorkedMinutes == 300 && a.OvertimeMinutes == 0
+	})).Return(nil).Once()
+
+	clockIn := monday.Add(9 * time.Hour)
+	clockOut := monday.Add(14 * time.Hour)
+	err := usecase.UpdateAttendance(ctx, &domain.Attendance{ID: "monday-id", Status: domain.StatusPresent, ClockIn: &clockIn, ClockOut: &clockOut}, "admin-id")
+	assert.NoError(t, err)
+	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_ReviewOvertime(t *testing.T) {
+	ctx := context.Background()
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+	pending := func() *domain.Attendance {
+		return &domain.Attendance{ID: "attendance-id", UserID: "user-id", OvertimeMinutes: 90, OvertimeStatus: domain.OvertimeStatusPending}
+	}
+
+	type testCase struct {
+		name           string
+		reviewerID     string
+		approve        bool
+		mockBehavior   func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository)
+		expectedStatus string
+		expectedError  error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Manager Approves",
+			reviewerID: manager.ID,
+			approve:    true,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(pending(), nil)
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user-id"}}, nil)
+				mockAttendRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.OvertimeStatus == domain.OvertimeStatusApproved && a.OvertimeReviewedBy == manager.ID && a.OvertimeReviewedAt != nil
+				})).Return(nil)
+			},
+			expectedStatus: domain.OvertimeStatusApproved,
+		},
+		{
+			name:       "Admin Rejects",
+			reviewerID: adminCaller.ID,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(pending(), nil)
+				mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			},
+			expectedStatus: domain.OvertimeStatusRejected,
+		},
+		{
+			name:       "Own Overtime",
+			reviewerID: "user-id",
+			approve:    true,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(pending(), nil)
+			},
+			expectedError: domain.ErrOwnOvertime,
+		},
+		{
+			name:       "Not One Of Their Reports",
+			reviewerID: manager.ID,
+			approve:    true,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(pending(), nil)
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "other-id"}}, nil)
+			},
+			expectedError: domain.ErrPermissionDenied,
+		},
+		{
+			name:       "Already Reviewed",
+			reviewerID: adminCaller.ID,
+			approve:    true,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				reviewed := pending()
+				reviewed.OvertimeStatus = domain.OvertimeStatusRejected
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(reviewed, nil)
+				mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+			},
+			expectedError: domain.ErrOvertimeNotPending,
+		},
+		{
+			name:       "Not Found",
+			reviewerID: adminCaller.ID,
+			approve:    true,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository) {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(nil, nil)
+			},
+			expectedError: domain.ErrAttendanceNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			tc.mockBehavior(mockAttendRepo, mockUserRepo)
+
+			review := usecase.RejectOvertime
+			if tc.approve {
+				review = usecase.ApproveOvertime
+			}
+			attendance, err := review(ctx, tc.reviewerID, "attendance-id", "")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendance)
+				mockAttendRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedStatus, attendance.OvertimeStatus)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_StartBreak(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	now := day.Add(12 * time.Hour)
+	clockIn := day.Add(9 * time.Hour)
+	clockOut := day.Add(11 * time.Hour)
+	breakStart := day.Add(10 * time.Hour)
+
+	type testCase struct {
+		name          string
+		breakType     string
+		attendance    *domain.Attendance
+		breaks        []domain.AttendanceBreak
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success",
+			breakType:  domain.BreakTypeLunch,
+			attendance: &domain.Attendance{ID: "attendance-id", ClockIn: &clockIn},
+			breaks:     []domain.AttendanceBreak{},
+		},
+		{
+			name:          "Invalid Type",
+			breakType:     "nap",
+			expectedError: domain.ErrInvalidBreakType,
+		},
+		{
+			name:          "Not Clocked In",
+			breakType:     domain.BreakTypeRest,
+			expectedError: domain.ErrNotClockedIn,
+		},
+		{
+			name:          "Already Clocked Out",
+			breakType:     domain.BreakTypeRest,
+			attendance:    &domain.Attendance{ID: "attendance-id", ClockIn: &clockIn, ClockOut: &clockOut},
+			expectedError: domain.ErrAlreadyClockedOut,
+		},
+		{
+			name:          "Break In Progress",
+			breakType:     domain.BreakTypeRest,
+			attendance:    &domain.Attendance{ID: "attendance-id", ClockIn: &clockIn},
+			breaks:        []domain.AttendanceBreak{{ID: "break-id", StartTime: breakStart}},
+			expectedError: domain.ErrBreakInProgress,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockBreakRepo := new(MockAttendanceBreakRepository)
+			uc := NewAttendanceUsecase(mockAttendRepo, mockBreakRepo, new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+			uc.now = func() time.Time { return now }
+
+			if tc.breakType != "nap" {
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", day).Return(tc.attendance, nil)
+			}
+			if tc.breakType != "nap" && tc.attendance == nil {
+				// An overnight shift begun yesterday could still be open
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", day.AddDate(0, 0, -1)).Return(nil, nil)
+			}
+			if tc.breaks != nil {
+				mockBreakRepo.On("GetByAttendanceIDs", ctx, []string{"attendance-id"}).Return(tc.breaks, nil)
+			}
+			if tc.expectedError == nil {
+				mockBreakRepo.On("Create", ctx, mock.MatchedBy(func(b *domain.AttendanceBreak) bool {
+					return b.AttendanceID == "attendance-id" && b.StartTime.Equal(now) && b.EndTime == nil
+				})).Return(nil)
+			}
+
+			attendanceBreak, err := uc.StartBreak(ctx, "user-id", tc.breakType)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendanceBreak)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.breakType, attendanceBreak.Type)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockBreakRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_ClockOut_EndsBreak(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	now := day.Add(16*time.Hour + 30*time.Minute)
+	clockIn := day.Add(9 * time.Hour)
+	lunchEnd := day.Add(12*time.Hour + 15*time.Minute)
+
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockBreakRepo := new(MockAttendanceBreakRepository)
+	rules := domain.BreakRules{{AfterMinutes: 360, MinimumMinutes: 30}}
+	uc := NewAttendanceUsecase(mockAttendRepo, mockBreakRepo, new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, rules).(*attendanceUsecase)
+	uc.now = func() time.Time { return now }
+
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", day).Return(&domain.Attendance{ID: "attendance-id", UserID: "user-id", Date: day, ClockIn: &clockIn}, nil)
+	mockBreakRepo.On("GetByAttendanceIDs", ctx, []string{"attendance-id"}).Return([]domain.AttendanceBreak{
+		{ID: "lunch-id", Type: domain.BreakTypeLunch, StartTime: day.Add(12 * time.Hour), EndTime: &lunchEnd},
+		{ID: "rest-id", Type: domain.BreakTypeRest, StartTime: day.Add(16*time.Hour + 20*time.Minute)},
+	}, nil)
+	mockBreakRepo.On("Update", ctx, mock.MatchedBy(func(b *domain.AttendanceBreak) bool {
+		return b.ID == "rest-id" && b.EndTime != nil && b.EndTime.Equal(now)
+	})).Return(nil)
+	mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+
+	attendance, err := uc.ClockOut(ctx, "user-id")
+	assert.NoError(t, err)
+	// 7.5 hours at work less 25 minutes of breaks, 5 minutes short of the 30 minute rule
+	assert.Equal(t, 25, attendance.BreakMinutes)
+	assert.Equal(t, 425, attendance.WorkedMinutes)
+	assert.Equal(t, 5, attendance.MissingBreakMinutes)
+	assert.Len(t, attendance.Breaks, 2)
+	mockAttendRepo.AssertExpectations(t)
+	mockBreakRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_AddBreak(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) *time.Time {
+		moment := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+		return &moment
+	}
+	record := func() *domain.Attendance {
+		return &domain.Attendance{ID: "attendance-id", UserID: "user-id", Date: day, ClockIn: at(9, 0), ClockOut: at(17, 0), WorkedMinutes: 480}
+	}
+	lunch := domain.AttendanceBreak{ID: "lunch-id", AttendanceID: "attendance-id", Type: domain.BreakTypeLunch, StartTime: *at(12, 0), EndTime: at(12, 30)}
+
+	type testCase struct {
+		name          string
+		attendance    *domain.Attendance
+		start, end    *time.Time
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success",
+			attendance: record(),
+			start:      at(15, 0),
+			end:        at(15, 15),
+		},
+		{
+			name:          "Ends Before It Starts",
+			start:         at(15, 0),
+			end:           at(14, 0),
+			expectedError: domain.ErrInvalidBreakTimes,
+		},
+		{
+			name:          "Before Clock In",
+			attendance:    record(),
+			start:         at(8, 45),
+			end:           at(9, 15),
+			expectedError: domain.ErrBreakOutsideWorkday,
+		},
+		{
+			name:          "After Clock Out",
+			attendance:    record(),
+			start:         at(16, 45),
+			end:           at(17, 15),
+			expectedError: domain.ErrBreakOutsideWorkday,
+		},
+		{
+			name:          "Overlaps Lunch",
+			attendance:    record(),
+			start:         at(12, 15),
+			end:           at(12, 45),
+			expectedError: domain.ErrBreakOverlap,
+		},
+		{
+			name:          "Someone Else's Record",
+			attendance:    &domain.Attendance{ID: "attendance-id", UserID: "other-id"},
+			start:         at(15, 0),
+			end:           at(15, 15),
+			expectedError: domain.ErrAttendanceNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockBreakRepo := new(MockAttendanceBreakRepository)
+			rules := domain.BreakRules{{AfterMinutes: 360, MinimumMinutes: 30}}
+			usecase := NewAttendanceUsecase(mockAttendRepo, mockBreakRepo, new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, rules)
+
+			if tc.attendance != nil {
+				mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(tc.attendance, nil)
+				mockBreakRepo.On("GetByAttendanceIDs", ctx, []string{"attendance-id"}).Return([]domain.AttendanceBreak{lunch}, nil).Maybe()
+			}
+			if tc.expectedError == nil {
+				mockBreakRepo.On("Create", ctx, mock.AnythingOfType("*domain.AttendanceBreak")).Return(nil)
+				mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			}
+
+			attendance, err := usecase.AddBreak(ctx, "user-id", &domain.AttendanceBreak{
+				AttendanceID: "attendance-id",
+				Type:         domain.BreakTypeRest,
+				StartTime:    *tc.start,
+				EndTime:      tc.end,
+			})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, attendance)
+				mockBreakRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 45, attendance.BreakMinutes)
+				assert.Equal(t, 435, attendance.WorkedMinutes)
+				assert.Zero(t, attendance.MissingBreakMinutes)
+				assert.Equal(t, "lunch-id", attendance.Breaks[0].ID)
+				assert.Equal(t, domain.BreakTypeRest, attendance.Breaks[1].Type)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockBreakRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_GetUserAttendance_IncludesBreaks(t *testing.T) {
+	ctx := context.Background()
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockBreakRepo := new(MockAttendanceBreakRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, mockBreakRepo, mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+
+	mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+	mockAttendRepo.On("List", ctx, mock.AnythingOfType("domain.AttendanceFilter")).Return([]domain.Attendance{{ID: "1"}, {ID: "2"}}, 2, nil)
+	mockBreakRepo.On("GetByAttendanceIDs", ctx, []string{"1", "2"}).Return([]domain.AttendanceBreak{
+		{ID: "a", AttendanceID: "2"},
+		{ID: "b", AttendanceID: "2"},
+	}, nil)
+
+	attendances, _, err := usecase.GetUserAttendance(ctx, "user-id", "user-id", domain.AttendanceFilter{})
+	assert.NoError(t, err)
+	assert.Empty(t, attendances[0].Breaks)
+	assert.Len(t, attendances[1].Breaks, 2)
+	mockBreakRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_MarkAttendance_ScheduleStatus(t *testing.T) {
+	schedule := &domain.WorkSchedule{
+		ID:                 "schedule-id",
+		StartTime:          "09:00",
+		EndTime:            "17:00",
+		GracePeriodMinutes: 10,
+		WorkDays:           []int{1, 2, 3, 4, 5},
+	}
+
+	tests := []struct {
+		name           string
+		now            time.Time
+		schedule       *domain.WorkSchedule
+		expectedStatus string
+	}{
+		{
+			name:           "On Time",
+			now:            time.Date(2024, 3, 4, 8, 55, 0, 0, time.UTC), // Monday
+			schedule:       schedule,
+			expectedStatus: domain.StatusPresent,
+		},
+		{
+			name:           "Within Grace Period",
+			now:            time.Date(2024, 3, 4, 9, 10, 0, 0, time.UTC),
+			schedule:       schedule,
+			expectedStatus: domain.StatusPresent,
+		},
+		{
+			name:           "After Grace Period",
+			now:            time.Date(2024, 3, 4, 9, 11, 0, 0, time.UTC),
+			schedule:       schedule,
+			expectedStatus: domain.StatusLate,
+		},
+		{
+			name:           "Non Working Day",
+			now:            time.Date(2024, 3, 9, 11, 0, 0, 0, time.UTC), // Saturday
+			schedule:       schedule,
+			expectedStatus: domain.StatusPresent,
+		},
+		{
+			name:           "No Schedule",
+			now:            time.Date(2024, 3, 4, 11, 0, 0, 0, time.UTC),
+			schedule:       nil,
+			expectedStatus: domain.StatusPresent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+			uc.now = func() time.Time { return tc.now }
+			ctx := context.Background()
+
+			// The client-supplied status must be ignored
+			attendance := &domain.Attendance{UserID: "test-user-id", Status: domain.StatusPresent}
+
+			mockUserRepo.On("GetByID", ctx, attendance.UserID).Return(verifiedUser(attendance.UserID), nil)
+			mockAttendRepo.On("GetByUserIDAndDate", ctx, attendance.UserID, tc.now.Truncate(24*time.Hour)).Return(nil, nil)
+			if tc.schedule != nil {
+				mockScheduleRepo.On("GetByUserID", ctx, attendance.UserID).Return(tc.schedule, nil)
+			} else {
+				mockScheduleRepo.On("GetByUserID", ctx, attendance.UserID).Return(nil, nil)
+			}
+			mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+
+			err := uc.MarkAttendance(ctx, attendance)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, attendance.Status)
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_MarkAbsences(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)
+	joined := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		date          time.Time
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time)
+		expectedCount int
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name: "Marks Only Users Without Record",
+			date: monday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{
+					{ID: "user1", CreatedAt: joined},
+					{ID: "user2", CreatedAt: joined},
+				}, nil)
+				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{{ID: "1", UserID: "user1"}}, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, "user2").Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.UserID == "user2" && a.Status == domain.StatusAbsent && a.Date.Equal(date)
+				})).Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Skips Weekend Without Schedule",
+			date: saturday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{{ID: "user1", CreatedAt: joined}}, nil)
+				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Uses Schedule Working Days",
+			date: saturday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{{ID: "user1", CreatedAt: joined}}, nil)
+				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, "user1").Return(&domain.WorkSchedule{WorkDays: []int{2, 3, 4, 5, 6}}, nil)
+				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "Skips Users Created After Date",
+			date: monday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{{ID: "user1", CreatedAt: date.AddDate(0, 0, 2)}}, nil)
+				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, nil)
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Concurrent Record Is Not Counted",
+			date: monday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{{ID: "user1", CreatedAt: joined}}, nil)
+				mockAttendRepo.On("GetByDate", ctx, date).Return([]domain.Attendance{}, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(domain.ErrAttendanceAlreadyMarked)
+			},
+			expectedCount: 0,
+		},
+		{
+			name: "Database Error on GetAll",
+			date: monday,
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, date time.Time) {
+				mockUserRepo.On("GetAll", ctx).Return([]domain.User{}, domain.ErrDatabase)
+			},
+			expectedError: domain.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockAttendRepo, mockUserRepo, mockScheduleRepo, ctx, tc.date)
+
+			count, err := usecase.MarkAbsences(ctx, tc.date)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCount, count)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_BackfillAbsences_InvalidRange(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	_, err := usecase.BackfillAbsences(ctx, from, from.AddDate(0, 0, -1))
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+
+	_, err = usecase.BackfillAbsences(ctx, from, time.Now().AddDate(0, 0, 2))
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+}
+
+func TestAttendanceUsecase_MarkAttendance_Holiday(t *testing.T) {
+	type testCase struct {
+		name          string
+		location      string
+		holidays      []domain.Holiday
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:          "Company-Wide Holiday",
+			holidays:      []domain.Holiday{{Name: "New Year"}},
+			expectedError: domain.ErrHoliday,
+		},
+		{
+			name:          "Holiday At User Location",
+			location:      "Jakarta",
+			holidays:      []domain.Holiday{{Name: "City Anniversary", Location: "Jakarta"}},
+			expectedError: domain.ErrHoliday,
+		},
+		{
+			name:     "Holiday At Other Location",
+			location: "Bandung",
+			holidays: []domain.Holiday{{Name: "City Anniversary", Location: "Jakarta"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			mockHolidayRepo := new(MockHolidayRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), mockHolidayRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			today := time.Now().Truncate(24 * time.Hour)
+			holidays := make([]domain.Holiday, len(tc.holidays))
+			for i, holiday := range tc.holidays {
+				holiday.Date = today.UTC()
+				holidays[i] = holiday
+			}
+
+			user := verifiedUser("user1")
+			user.Location = tc.location
+			mockUserRepo.On("GetByID", ctx, "user1").Return(user, nil)
+			mockHolidayRepo.On("GetByDate", ctx, today).Return(holidays, nil)
+			if tc.expectedError == nil {
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, "user1", today).Return(nil, nil)
+				mockScheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+			}
+
+			err := usecase.MarkAttendance(ctx, &domain.Attendance{UserID: "user1"})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
+			mockHolidayRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_MarkAbsences_SkipsHolidays(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	mockHolidayRepo := new(MockHolidayRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, newEmptyShiftRepository(), mockHolidayRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	joined := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mockUserRepo.On("GetAll", ctx).Return([]domain.User{
+		{ID: "user1", Location: "Jakarta", CreatedAt: joined},
+		{ID: "user2", Location: "Bandung", CreatedAt: joined},
+	}, nil)
+	mockAttendRepo.On("GetByDate", ctx, monday).Return([]domain.Attendance{}, nil)
+	mockHolidayRepo.On("GetByDate", ctx, monday).Return([]domain.Holiday{
+		{Name: "City Anniversary", Location: "Jakarta", Date: monday},
+	}, nil)
+	mockScheduleRepo.On("GetByUserID", ctx, "user2").Return(nil, nil)
+	mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.UserID == "user2" && a.Status == domain.StatusAbsent
+	})).Return(nil)
+
+	count, err := usecase.MarkAbsences(ctx, monday)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockAttendRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockScheduleRepo.AssertExpectations(t)
+	mockHolidayRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_MarkAbsences_LocalDate(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	// Local midnight east of UTC falls on the previous day in UTC
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	localMidnight := time.Date(2024, 3, 4, 0, 0, 0, 0, time.FixedZone("UTC+7", 7*60*60))
+
+	mockUserRepo.On("GetAll", ctx).Return([]domain.User{}, nil)
+	mockAttendRepo.On("GetByDate", ctx, monday).Return([]domain.Attendance{}, nil)
+
+	count, err := usecase.MarkAbsences(ctx, localMidnight)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_ClockIn_RosteredShift(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	mondayNight := domain.RosterEntry{ID: "entry-mon", UserID: "user-id", ShiftID: "night", Date: monday, Shift: nightShift}
+
+	type testCase struct {
+		name           string
+		now            time.Time
+		expectedDate   time.Time
+		expectedStatus string
+		expectedEntry  string
+	}
+
+	tests := []testCase{
+		{
+			name:           "Early Clock-In Belongs To Shift",
+			now:            monday.Add(21 * time.Hour),
+			expectedDate:   monday,
+			expectedStatus: domain.StatusPresent,
+			expectedEntry:  "entry-mon",
+		},
+		{
+			name:           "Late After Grace Period",
+			now:            monday.Add(22*time.Hour + 15*time.Minute),
+			expectedDate:   monday,
+			expectedStatus: domain.StatusLate,
+			expectedEntry:  "entry-mon",
+		},
+		{
+			name:           "After Midnight Belongs To Shift Of Previous Day",
+			now:            tuesday.Add(time.Hour),
+			expectedDate:   monday,
+			expectedStatus: domain.StatusLate,
+			expectedEntry:  "entry-mon",
+		},
+		{
+			name:           "Outside Shift Falls Back To Schedule",
+			now:            monday.Add(12 * time.Hour),
+			expectedDate:   monday.Add(12 * time.Hour),
+			expectedStatus: domain.StatusPresent,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			mockShiftRepo := new(MockShiftRepository)
+			uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, mockShiftRepo, newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+			uc.now = func() time.Time { return tc.now }
+
+			today := tc.now.Truncate(24 * time.Hour)
+			mockUserRepo.On("GetByID", ctx, "user-id").Return(verifiedUser("user-id"), nil)
+			mockShiftRepo.On("GetRoster", ctx, today.AddDate(0, 0, -1), today, []string{"user-id"}).Return([]domain.RosterEntry{mondayNight}, nil)
+			mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", tc.expectedDate.Truncate(24*time.Hour)).Return(nil, nil)
+			if tc.expectedEntry == "" {
+				mockScheduleRepo.On("GetByUserID", ctx, "user-id").Return(nil, nil)
+			}
+			mockAttendRepo.On("Create", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+
+			attendance, err := uc.ClockIn(ctx, "user-id")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDate, attendance.Date)
+			assert.Equal(t, tc.expectedStatus, attendance.Status)
+			assert.Equal(t, tc.expectedEntry, attendance.RosterEntryID)
+			mockAttendRepo.AssertExpectations(t)
+			mockScheduleRepo.AssertExpectations(t)
+			mockShiftRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_ClockOut_OvernightShift(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	now := tuesday.Add(6*time.Hour + 5*time.Minute)
+	clockIn := monday.Add(22 * time.Hour)
+
+	mockAttendRepo := new(MockAttendanceRepository)
+	uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+	uc.now = func() time.Time { return now }
+
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", tuesday).Return(nil, nil)
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", monday).Return(&domain.Attendance{
+		ID: "attendance-id", UserID: "user-id", Date: monday, RosterEntryID: "entry-mon", ClockIn: &clockIn,
+	}, nil)
+	mockAttendRepo.On("Update", ctx, mock.AnythingOfType("*domain.Attendance")).Return(nil)
+
+	attendance, err := uc.ClockOut(ctx, "user-id")
+	assert.NoError(t, err)
+	assert.Equal(t, monday, attendance.Date)
+	assert.Equal(t, 485, attendance.WorkedMinutes)
+	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_MarkShiftAbsences(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	now := tuesday.Add(7 * time.Hour)
+
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockShiftRepo := new(MockShiftRepository)
+	uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), mockShiftRepo, newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+	uc.now = func() time.Time { return now }
+
+	mockShiftRepo.On("GetRoster", ctx, monday, tuesday, []string(nil)).Return([]domain.RosterEntry{
+		{ID: "entry-1", UserID: "user1", Date: monday, Shift: nightShift},
+		{ID: "entry-2", UserID: "user2", Date: monday, Shift: nightShift},
+		{ID: "entry-3", UserID: "user1", Date: tuesday, Shift: nightShift},
+	}, nil)
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user1", monday).Return(nil, nil)
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user2", monday).Return(&domain.Attendance{ID: "attendance-id"}, nil)
+	mockUserRepo.On("GetByID", ctx, "user1").Return(verifiedUser("user1"), nil)
+	mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.UserID == "user1" && a.Date.Equal(monday) && a.RosterEntryID == "entry-1" && a.Status == domain.StatusAbsent
+	})).Return(nil).Once()
+
+	count, err := uc.MarkShiftAbsences(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	mockAttendRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockShiftRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_MarkAbsences_RosteredUsers(t *testing.T) {
+	ctx := context.Background()
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	wednesday := monday.AddDate(0, 0, 2)
+	joined := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dayShift := &domain.Shift{ID: "day", StartTime: "09:00", EndTime: "17:00"}
+
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	mockShiftRepo := new(MockShiftRepository)
+	uc := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, mockScheduleRepo, mockShiftRepo, newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil).(*attendanceUsecase)
+	uc.now = func() time.Time { return wednesday.Add(18 * time.Hour) }
+
+	// user1 is rostered in the week but off on Wednesday, user2 works a day
+	// shift on Wednesday and user3 is not rostered at all
+	mockUserRepo.On("GetAll", ctx).Return([]domain.User{
+		{ID: "user1", CreatedAt: joined},
+		{ID: "user2", CreatedAt: joined},
+		{ID: "user3", CreatedAt: joined},
+	}, nil)
+	mockAttendRepo.On("GetByDate", ctx, wednesday).Return([]domain.Attendance{}, nil)
+	mockShiftRepo.On("GetRoster", ctx, monday, monday.AddDate(0, 0, 6), []string(nil)).Return([]domain.RosterEntry{
+		{ID: "entry-1", UserID: "user1", Date: monday, Shift: dayShift},
+		{ID: "entry-2", UserID: "user2", Date: wednesday, Shift: dayShift},
+	}, nil)
+	mockScheduleRepo.On("GetByUserID", ctx, "user3").Return(nil, nil)
+	mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.UserID == "user3" && a.RosterEntryID == ""
+	})).Return(nil).Once()
+	mockAttendRepo.On("GetByUserIDAndDate", ctx, "user2", wednesday).Return(nil, nil)
+	mockUserRepo.On("GetByID", ctx, "user2").Return(verifiedUser("user2"), nil)
+	mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.UserID == "user2" && a.RosterEntryID == "entry-2"
+	})).Return(nil).Once()
+
+	count, err := uc.MarkAbsences(ctx, wednesday)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	mockAttendRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockScheduleRepo.AssertExpectations(t)
+	mockShiftRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_CreateAttendance(t *testing.T) {
+	date := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	clockIn := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	clockOut := time.Date(2024, 7, 1, 17, 30, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		attendance    *domain.Attendance
+		mockBehavior  func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success",
+			attendance: &domain.Attendance{UserID: "user-id", Date: date, Status: domain.StatusPresent, ClockIn: &clockIn, ClockOut: &clockOut},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", date).Return(nil, nil)
+				mockAttendRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+					return a.ID != "" && a.WorkedMinutes == 510
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Invalid Status",
+			attendance: &domain.Attendance{UserID: "user-id", Date: date, Status: "sick"},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+			},
+			expectedError: domain.ErrInvalidAttendanceStatus,
+		},
+		{
+			name:       "User Not Found",
+			attendance: &domain.Attendance{UserID: "missing", Date: date, Status: domain.StatusAbsent},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockUserRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+		{
+			name:       "Clock Out Before Clock In",
+			attendance: &domain.Attendance{UserID: "user-id", Date: date, Status: domain.StatusPresent, ClockIn: &clockOut, ClockOut: &clockIn},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+			},
+			expectedError: domain.ErrInvalidClockTimes,
+		},
+		{
+			name:       "Already Exists",
+			attendance: &domain.Attendance{UserID: "user-id", Date: date, Status: domain.StatusAbsent},
+			mockBehavior: func(mockAttendRepo *MockAttendanceRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+				mockAttendRepo.On("GetByUserIDAndDate", ctx, "user-id", date).Return(&domain.Attendance{ID: "existing"}, nil)
+			},
+			expectedError: domain.ErrAttendanceAlreadyMarked,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), mockUserRepo, new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockAttendRepo, mockUserRepo, ctx)
+
+			err := usecase.CreateAttendance(ctx, tc.attendance)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockAttendRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceUsecase_UpdateAttendance(t *testing.T) {
+	mockAttendRepo := new(MockAttendanceRepository)
+	usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	date := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	clockIn := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+	clockOut := time.Date(2024, 7, 1, 17, 0, 0, 0, time.UTC)
+	existing := &domain.Attendance{ID: "attendance-id", UserID: "user-id", Date: date, Status: domain.StatusAbsent}
+
+	mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(existing, nil)
+	mockAttendRepo.On("AddHistory", ctx, mock.MatchedBy(func(h *domain.AttendanceHistory) bool {
+		return h.AttendanceID == "attendance-id" && h.Status == domain.StatusAbsent &&
+			h.Action == domain.HistoryActionUpdate && h.ChangedBy == "admin-id"
+	})).Return(nil)
+	mockAttendRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+		return a.UserID == "user-id" && a.Date.Equal(date) && a.Status == domain.StatusPresent && a.WorkedMinutes == 480
+	})).Return(nil)
+
+	err := usecase.UpdateAttendance(ctx, &domain.Attendance{
+		ID:       "attendance-id",
+		UserID:   "other-user",
+		Status:   domain.StatusPresent,
+		ClockIn:  &clockIn,
+		ClockOut: &clockOut,
+	}, "admin-id")
+	assert.NoError(t, err)
+	mockAttendRepo.AssertExpectations(t)
+}
+
+func TestAttendanceUsecase_DeleteAttendance(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		existing := &domain.Attendance{ID: "attendance-id", UserID: "user-id", Status: domain.StatusLate}
+		mockAttendRepo.On("GetByID", ctx, "attendance-id").Return(existing, nil)
+		mockAttendRepo.On("AddHistory", ctx, mock.MatchedBy(func(h *domain.AttendanceHistory) bool {
+			return h.AttendanceID == "attendance-id" && h.Status == domain.StatusLate && h.Action == domain.HistoryActionDelete
+		})).Return(nil)
+		mockAttendRepo.On("Delete", ctx, "attendance-id").Return(nil)
+
+		err := usecase.DeleteAttendance(ctx, "attendance-id", "admin-id")
+		assert.NoError(t, err)
+		mockAttendRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockAttendRepo := new(MockAttendanceRepository)
+		usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockAttendRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		err := usecase.DeleteAttendance(ctx, "missing", "admin-id")
+		assert.ErrorIs(t, err, domain.ErrAttendanceNotFound)
+		mockAttendRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAttendanceUsecase_ListAttendanceInRange(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		to            time.Time
+		expectedError error
+	}
+
+	tests := []testCase{
+		{name: "Success", to: from.AddDate(0, 0, 30)},
+		{name: "Reversed Range", to: from.AddDate(0, 0, -1), expectedError: domain.ErrInvalidDateRange},
+		{name: "Range Too Long", to: from.AddDate(0, 0, domain.MaxAttendanceRangeDays+1), expectedError: domain.ErrInvalidDateRange},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttendRepo := new(MockAttendanceRepository)
+			usecase := NewAttendanceUsecase(mockAttendRepo, newEmptyBreakRepository(), new(MockUserRepository), new(MockWorkScheduleRepository), newEmptyShiftRepository(), newEmptyHolidayRepository(), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			if tc.expectedError == nil {
+				mockAttendRepo.On("GetInRange", ctx, from, tc.to).Return([]domain.Attendance{{ID: "1"}}, nil)
+			}
+
+			result, err := usecase.ListAttendanceInRange(ctx, from, tc.to)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result, 1)
+			}
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
 }
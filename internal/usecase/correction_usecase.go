@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type attendanceCorrectionUsecase struct {
+	correctionRepo domain.AttendanceCorrectionRepository
+	attendanceRepo domain.AttendanceRepository
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+	overtime       domain.OvertimePolicy
+	breakRules     domain.BreakRules
+	now            func() time.Time
+}
+
+func NewAttendanceCorrectionUsecase(correctionRepo domain.AttendanceCorrectionRepository, attendanceRepo domain.AttendanceRepository, userRepo domain.UserRepository, roleRepo domain.RoleRepository, overtime domain.OvertimePolicy, breakRules domain.BreakRules) domain.AttendanceCorrectionUsecase {
+	return &attendanceCorrectionUsecase{
+		correctionRepo: correctionRepo,
+		attendanceRepo: attendanceRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+		overtime:       overtime,
+		breakRules:     breakRules,
+		now:            time.Now,
+	}
+}
+
+func (u *attendanceCorrectionUsecase) RequestCorrection(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	attendance, err := u.attendanceRepo.GetByID(ctx, correction.AttendanceID)
+	if err != nil {
+		return err
+	}
+	if attendance == nil || attendance.UserID != correction.UserID {
+		return domain.ErrAttendanceNotFound
+	}
+
+	if correction.ProposedStatus == "" && correction.ProposedClockIn == nil && correction.ProposedClockOut == nil {
+		return domain.ErrEmptyCorrection
+	}
+	if correction.ProposedStatus != "" && !domain.CorrectableAttendanceStatuses[correction.ProposedStatus] {
+		return domain.ErrInvalidAttendanceStatus
+	}
+
+	// Validate the times the record would end up with, not just the proposed ones
+	corrected := *attendance
+	applyCorrection(&corrected, correction, u.breakRules)
+	if !validClockTimes(&corrected, u.now()) {
+		return domain.ErrInvalidClockTimes
+	}
+
+	pending, err := u.correctionRepo.GetPendingByAttendanceID(ctx, correction.AttendanceID)
+	if err != nil {
+		return err
+	}
+	if pending != nil {
+		return domain.ErrCorrectionPending
+	}
+
+	correction.ID = uuid.New().String()
+	correction.Status = domain.CorrectionStatusPending
+	correction.ReviewedBy = ""
+	correction.ReviewedAt = nil
+	correction.ReviewNote = ""
+
+	return u.correctionRepo.Create(ctx, correction)
+}
+
+func (u *attendanceCorrectionUsecase) CancelCorrection(ctx context.Context, userID, id string) error {
+	correction, err := u.correctionRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if correction == nil || correction.UserID != userID {
+		return domain.ErrCorrectionNotFound
+	}
+	if correction.Status != domain.CorrectionStatusPending {
+		return domain.ErrCorrectionNotPending
+	}
+
+	correction.Status = domain.CorrectionStatusCancelled
+	return u.correctionRepo.Update(ctx, correction)
+}
+
+func (u *attendanceCorrectionUsecase) ApproveCorrection(ctx context.Context, id, reviewerID, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := u.reviewableCorrection(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := u.attendanceRepo.GetByID(ctx, correction.AttendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
+		return nil, domain.ErrAttendanceNotFound
+	}
+
+	now := u.now()
+	history := newHistory(attendance, domain.HistoryActionCorrection, reviewerID, now)
+	history.CorrectionID = correction.ID
+
+	applyCorrection(attendance, correction, u.breakRules)
+	if !validClockTimes(attendance, now) {
+		return nil, domain.ErrInvalidClockTimes
+	}
+
+	// Update the request first so a partial failure below can never be applied twice
+	reviewCorrection(correction, domain.CorrectionStatusApproved, reviewerID, note, now)
+	if err := u.correctionRepo.Update(ctx, correction); err != nil {
+		return nil, err
+	}
+	if err := u.attendanceRepo.AddHistory(ctx, history); err != nil {
+		return nil, err
+	}
+	if err := applyWeekOvertime(ctx, u.attendanceRepo, u.overtime, attendance); err != nil {
+		return nil, err
+	}
+	if err := u.attendanceRepo.Update(ctx, attendance); err != nil {
+		return nil, err
+	}
+
+	return correction, nil
+}
+
+func (u *attendanceCorrectionUsecase) RejectCorrection(ctx context.Context, id, reviewerID, note string) (*domain.AttendanceCorrection, error) {
+	correction, err := u.reviewableCorrection(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewCorrection(correction, domain.CorrectionStatusRejected, reviewerID, note, u.now())
+	if err := u.correctionRepo.Update(ctx, correction); err != nil {
+		return nil, err
+	}
+	return correction, nil
+}
+
+func (u *attendanceCorrectionUsecase) GetUserCorrections(ctx context.Context, userID string) ([]domain.AttendanceCorrection, error) {
+	return u.correctionRepo.GetByUserID(ctx, userID)
+}
+
+// GetCorrectionsByStatus returns the requests with the status that the caller
+// may review: everyone's with attendance:write:all, otherwise their reports'
+func (u *attendanceCorrectionUsecase) GetCorrectionsByStatus(ctx context.Context, callerID, status string) ([]domain.AttendanceCorrection, error) {
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermAttendanceWriteAll)
+	if err != nil {
+		return nil, err
+	}
+	corrections, err := u.correctionRepo.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	if scope.All {
+		return corrections, nil
+	}
+
+	visible := []domain.AttendanceCorrection{}
+	for _, correction := range corrections {
+		if scope.Includes(correction.UserID) {
+			visible = append(visible, correction)
+		}
+	}
+	return visible, nil
+}
+
+func (u *attendanceCorrectionUsecase) GetHistory(ctx context.Context, attendanceID, ownerID string) ([]domain.AttendanceHistory, error) {
+	attendance, err := u.attendanceRepo.GetByID(ctx, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil || (ownerID != "" && attendance.UserID != ownerID) {
+		return nil, domain.ErrAttendanceNotFound
+	}
+	return u.attendanceRepo.GetHistory(ctx, attendanceID)
+}
+
+// reviewableCorrection returns a pending request the reviewer may decide on.
+// Users with attendance:write:all review anyone's requests, other approvers
+// their reports'.
+func (u *attendanceCorrectionUsecase) reviewableCorrection(ctx context.Context, id, reviewerID string) (*domain.AttendanceCorrection, error) {
+	correction, err := u.correctionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if correction == nil {
+		return nil, domain.ErrCorrectionNotFound
+	}
+	if correction.UserID == reviewerID {
+		return nil, domain.ErrOwnCorrection
+	}
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, reviewerID, domain.PermAttendanceWriteAll)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Includes(correction.UserID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if correction.Status != domain.CorrectionStatusPending {
+		return nil, domain.ErrCorrectionNotPending
+	}
+	return correction, nil
+}
+
+// applyCorrection copies the proposed values onto the record and recomputes the worked time
+func applyCorrection(attendance *domain.Attendance, correction *domain.AttendanceCorrection, breakRules domain.BreakRules) {
+	if correction.ProposedStatus != "" {
+		attendance.Status = correction.ProposedStatus
+	}
+	if correction.ProposedClockIn != nil {
+		attendance.ClockIn = correction.ProposedClockIn
+	}
+	if correction.ProposedClockOut != nil {
+		attendance.ClockOut = correction.ProposedClockOut
+	}
+	setWorkedTime(attendance, breakRules)
+}
+
+// validClockTimes reports whether the record's clock times are in order and not in the future
+func validClockTimes(attendance *domain.Attendance, now time.Time) bool {
+	if attendance.ClockIn != nil && attendance.ClockIn.After(now) {
+		return false
+	}
+	if attendance.ClockOut != nil {
+		if attendance.ClockIn == nil || !attendance.ClockOut.After(*attendance.ClockIn) || attendance.ClockOut.After(now) {
+			return false
+		}
+	}
+	return true
+}
+
+func reviewCorrection(correction *domain.AttendanceCorrection, status, reviewerID, note string, at time.Time) {
+	correction.Status = status
+	correction.ReviewedBy = reviewerID
+	correction.ReviewedAt = &at
+	correction.ReviewNote = note
+}
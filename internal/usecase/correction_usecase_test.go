@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttendanceCorrectionRepository is a mock type for domain.AttendanceCorrectionRepository
+type MockAttendanceCorrectionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttendanceCorrectionRepository) Create(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	args := m.Called(ctx, correction)
+	return args.Error(0)
+}
+
+func (m *MockAttendanceCorrectionRepository) GetByID(ctx context.Context, id string) (*domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockAttendanceCorrectionRepository) GetByUserID(ctx context.Context, userID string) ([]domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockAttendanceCorrectionRepository) GetByStatus(ctx context.Context, status string) ([]domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockAttendanceCorrectionRepository) GetPendingByAttendanceID(ctx context.Context, attendanceID string) (*domain.AttendanceCorrection, error) {
+	args := m.Called(ctx, attendanceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttendanceCorrection), args.Error(1)
+}
+
+func (m *MockAttendanceCorrectionRepository) Update(ctx context.Context, correction *domain.AttendanceCorrection) error {
+	args := m.Called(ctx, correction)
+	return args.Error(0)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestAttendanceCorrectionUsecase_RequestCorrection(t *testing.T) {
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	clockIn := day.Add(9 * time.Hour)
+	clockOut := day.Add(17 * time.Hour)
+
+	type testCase struct {
+		name          string
+		correction    *domain.AttendanceCorrection
+		mockBehavior  func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name: "Success",
+			correction: &domain.AttendanceCorrection{
+				AttendanceID:     "att1",
+				UserID:           "user1",
+				ProposedStatus:   domain.StatusPresent,
+				ProposedClockOut: timePtr(clockOut),
+				Reason:           "Forgot to clock out",
+			},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1", Date: day, Status: domain.StatusLate, ClockIn: &clockIn}, nil)
+				mockCorrectionRepo.On("GetPendingByAttendanceID", ctx, "att1").Return(nil, nil)
+				mockCorrectionRepo.On("Create", ctx, mock.AnythingOfType("*domain.AttendanceCorrection")).Return(nil)
+			},
+		},
+		{
+			name:       "Other User's Record",
+			correction: &domain.AttendanceCorrection{AttendanceID: "att1", UserID: "user1", ProposedStatus: domain.StatusPresent},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user2"}, nil)
+			},
+			expectedError: domain.ErrAttendanceNotFound,
+		},
+		{
+			name:       "No Changes",
+			correction: &domain.AttendanceCorrection{AttendanceID: "att1", UserID: "user1"},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1"}, nil)
+			},
+			expectedError: domain.ErrEmptyCorrection,
+		},
+		{
+			name:       "Leave Status Not Allowed",
+			correction: &domain.AttendanceCorrection{AttendanceID: "att1", UserID: "user1", ProposedStatus: domain.StatusLeave},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1"}, nil)
+			},
+			expectedError: domain.ErrInvalidAttendanceStatus,
+		},
+		{
+			name:       "Clock Out Before Existing Clock In",
+			correction: &domain.AttendanceCorrection{AttendanceID: "att1", UserID: "user1", ProposedClockOut: timePtr(clockIn.Add(-time.Hour))},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1", ClockIn: &clockIn}, nil)
+			},
+			expectedError: domain.ErrInvalidClockTimes,
+		},
+		{
+			name:       "Already Pending",
+			correction: &domain.AttendanceCorrection{AttendanceID: "att1", UserID: "user1", ProposedStatus: domain.StatusPresent},
+			mockBehavior: func(mockCorrectionRepo *MockAttendanceCorrectionRepository, mockAttendRepo *MockAttendanceRepository, ctx context.Context) {
+				mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1"}, nil)
+				mockCorrectionRepo.On("GetPendingByAttendanceID", ctx, "att1").Return(&domain.AttendanceCorrection{ID: "other"}, nil)
+			},
+			expectedError: domain.ErrCorrectionPending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+			mockAttendRepo := new(MockAttendanceRepository)
+			usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, new(MockUserRepository), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockCorrectionRepo, mockAttendRepo, ctx)
+
+			err := usecase.RequestCorrection(ctx, tc.correction)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tc.correction.ID)
+				assert.Equal(t, domain.CorrectionStatusPending, tc.correction.Status)
+			}
+			mockCorrectionRepo.AssertExpectations(t)
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceCorrectionUsecase_ApproveCorrection(t *testing.T) {
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	clockIn := day.Add(9*time.Hour + 20*time.Minute)
+	correctedIn := day.Add(8*time.Hour + 55*time.Minute)
+	clockOut := day.Add(17 * time.Hour)
+	reviewer := &domain.User{ID: "admin1", Role: domain.RoleAdmin}
+
+	t.Run("Success", func(t *testing.T) {
+		mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "admin1").Return(reviewer, nil)
+
+		correction := &domain.AttendanceCorrection{
+			ID:              "corr1",
+			AttendanceID:    "att1",
+			UserID:          "user1",
+			ProposedStatus:  domain.StatusPresent,
+			ProposedClockIn: &correctedIn,
+			Status:          domain.CorrectionStatusPending,
+		}
+		attendance := &domain.Attendance{
+			ID:            "att1",
+			UserID:        "user1",
+			Date:          day,
+			Status:        domain.StatusLate,
+			ClockIn:       &clockIn,
+			ClockOut:      &clockOut,
+			WorkedMinutes: 460,
+		}
+
+		mockCorrectionRepo.On("GetByID", ctx, "corr1").Return(correction, nil)
+		mockAttendRepo.On("GetByID", ctx, "att1").Return(attendance, nil)
+		mockCorrectionRepo.On("Update", ctx, correction).Return(nil)
+		mockAttendRepo.On("AddHistory", ctx, mock.MatchedBy(func(h *domain.AttendanceHistory) bool {
+			return h.AttendanceID == "att1" &&
+				h.Status == domain.StatusLate &&
+				h.ClockIn.Equal(clockIn) &&
+				h.WorkedMinutes == 460 &&
+				h.CorrectionID == "corr1" &&
+				h.ChangedBy == "admin1"
+		})).Return(nil)
+		mockAttendRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+			return a.Status == domain.StatusPresent && a.ClockIn.Equal(correctedIn) && a.WorkedMinutes == 485
+		})).Return(nil)
+
+		approved, err := usecase.ApproveCorrection(ctx, "corr1", "admin1", "ok")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CorrectionStatusApproved, approved.Status)
+		assert.Equal(t, "admin1", approved.ReviewedBy)
+		assert.NotNil(t, approved.ReviewedAt)
+		mockCorrectionRepo.AssertExpectations(t)
+		mockAttendRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Pending", func(t *testing.T) {
+		mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+		mockAttendRepo := new(MockAttendanceRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "admin1").Return(reviewer, nil)
+
+		mockCorrectionRepo.On("GetByID", ctx, "corr1").Return(&domain.AttendanceCorrection{ID: "corr1", UserID: "user1", Status: domain.CorrectionStatusApproved}, nil)
+
+		approved, err := usecase.ApproveCorrection(ctx, "corr1", "admin1", "")
+		assert.ErrorIs(t, err, domain.ErrCorrectionNotPending)
+		assert.Nil(t, approved)
+		mockCorrectionRepo.AssertExpectations(t)
+		mockAttendRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestAttendanceCorrectionUsecase_ReviewCorrection_Scope(t *testing.T) {
+	manager := &domain.User{ID: "manager1", Role: "team-lead"}
+
+	type testCase struct {
+		name          string
+		reviewerID    string
+		mockBehavior  func(mockUserRepo *MockUserRepository, ctx context.Context)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:          "Own Correction",
+			reviewerID:    "user1",
+			mockBehavior:  func(mockUserRepo *MockUserRepository, ctx context.Context) {},
+			expectedError: domain.ErrOwnCorrection,
+		},
+		{
+			name:       "Outside The Reviewer's Reports",
+			reviewerID: manager.ID,
+			mockBehavior: func(mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user2"}}, nil)
+			},
+			expectedError: domain.ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+			mockAttendRepo := new(MockAttendanceRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			mockCorrectionRepo.On("GetByID", ctx, "corr1").Return(&domain.AttendanceCorrection{ID: "corr1", AttendanceID: "att1", UserID: "user1", Status: domain.CorrectionStatusPending}, nil)
+			tc.mockBehavior(mockUserRepo, ctx)
+
+			approved, err := usecase.ApproveCorrection(ctx, "corr1", tc.reviewerID, "")
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, approved)
+			mockCorrectionRepo.AssertExpectations(t)
+			mockAttendRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAttendanceCorrectionUsecase_GetCorrectionsByStatus_Scope(t *testing.T) {
+	manager := &domain.User{ID: "manager1", Role: "team-lead"}
+	pending := []domain.AttendanceCorrection{
+		{ID: "corr1", UserID: "user1", Status: domain.CorrectionStatusPending},
+		{ID: "corr2", UserID: "user2", Status: domain.CorrectionStatusPending},
+	}
+
+	t.Run("Manager Sees Their Reports' Requests", func(t *testing.T) {
+		mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, new(MockAttendanceRepository), mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user2"}}, nil)
+		mockCorrectionRepo.On("GetByStatus", ctx, domain.CorrectionStatusPending).Return(pending, nil)
+
+		corrections, err := usecase.GetCorrectionsByStatus(ctx, manager.ID, domain.CorrectionStatusPending)
+		assert.NoError(t, err)
+		assert.Equal(t, pending[1:], corrections)
+		mockCorrectionRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Attendance Writer Sees Everyone's", func(t *testing.T) {
+		mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, new(MockAttendanceRepository), mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "admin1").Return(&domain.User{ID: "admin1", Role: domain.RoleAdmin}, nil)
+		mockCorrectionRepo.On("GetByStatus", ctx, domain.CorrectionStatusPending).Return(pending, nil)
+
+		corrections, err := usecase.GetCorrectionsByStatus(ctx, "admin1", domain.CorrectionStatusPending)
+		assert.NoError(t, err)
+		assert.Equal(t, pending, corrections)
+		mockCorrectionRepo.AssertExpectations(t)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestAttendanceCorrectionUsecase_RejectCorrection(t *testing.T) {
+	mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+	mockAttendRepo := new(MockAttendanceRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, mockUserRepo, newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, "admin1").Return(&domain.User{ID: "admin1", Role: domain.RoleAdmin}, nil)
+	correction := &domain.AttendanceCorrection{ID: "corr1", AttendanceID: "att1", UserID: "user1", Status: domain.CorrectionStatusPending}
+	mockCorrectionRepo.On("GetByID", ctx, "corr1").Return(correction, nil)
+	mockCorrectionRepo.On("Update", ctx, correction).Return(nil)
+
+	rejected, err := usecase.RejectCorrection(ctx, "corr1", "admin1", "no evidence")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.CorrectionStatusRejected, rejected.Status)
+	assert.Equal(t, "no evidence", rejected.ReviewNote)
+	mockCorrectionRepo.AssertExpectations(t)
+	mockAttendRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAttendanceCorrectionUsecase_GetHistory(t *testing.T) {
+	type testCase struct {
+		name          string
+		ownerID       string
+		expectedError error
+	}
+
+	tests := []testCase{
+		{name: "Owner", ownerID: "user1"},
+		{name: "Admin", ownerID: ""},
+		{name: "Other User", ownerID: "user2", expectedError: domain.ErrAttendanceNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCorrectionRepo := new(MockAttendanceCorrectionRepository)
+			mockAttendRepo := new(MockAttendanceRepository)
+			usecase := NewAttendanceCorrectionUsecase(mockCorrectionRepo, mockAttendRepo, new(MockUserRepository), newBuiltInRoleRepository(), domain.OvertimePolicy{}, nil)
+			ctx := context.Background()
+
+			mockAttendRepo.On("GetByID", ctx, "att1").Return(&domain.Attendance{ID: "att1", UserID: "user1"}, nil)
+			if tc.expectedError == nil {
+				mockAttendRepo.On("GetHistory", ctx, "att1").Return([]domain.AttendanceHistory{{ID: "h1"}}, nil)
+			}
+
+			history, err := usecase.GetHistory(ctx, "att1", tc.ownerID)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, history, 1)
+			}
+			mockAttendRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"golang-tes/internal/domain"
+	"golang-tes/pkg/ical"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type holidayUsecase struct {
+	holidayRepo domain.HolidayRepository
+	userRepo    domain.UserRepository
+}
+
+func NewHolidayUsecase(holidayRepo domain.HolidayRepository, userRepo domain.UserRepository) domain.HolidayUsecase {
+	return &holidayUsecase{
+		holidayRepo: holidayRepo,
+		userRepo:    userRepo,
+	}
+}
+
+func (u *holidayUsecase) CreateHoliday(ctx context.Context, holiday *domain.Holiday) error {
+	holiday.ID = uuid.New().String()
+	holiday.Date = calendarDay(holiday.Date)
+	return u.holidayRepo.Create(ctx, holiday)
+}
+
+func (u *holidayUsecase) GetHoliday(ctx context.Context, id string) (*domain.Holiday, error) {
+	holiday, err := u.holidayRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if holiday == nil {
+		return nil, domain.ErrHolidayNotFound
+	}
+	return holiday, nil
+}
+
+// ListHolidays returns the holidays of the year. A location limits the result to
+// the holidays observed there, including company-wide ones.
+func (u *holidayUsecase) ListHolidays(ctx context.Context, year int, location string) ([]domain.Holiday, error) {
+	holidays, err := u.holidayRepo.GetInRange(ctx, yearStart(year), yearStart(year+1).AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	if location == "" {
+		return holidays, nil
+	}
+	return filterObserved(holidays, location), nil
+}
+
+func (u *holidayUsecase) GetUserHolidays(ctx context.Context, userID string, year int) ([]domain.Holiday, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	holidays, err := u.holidayRepo.GetInRange(ctx, yearStart(year), yearStart(year+1).AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	return filterObserved(holidays, user.Location), nil
+}
+
+func (u *holidayUsecase) UpdateHoliday(ctx context.Context, holiday *domain.Holiday) error {
+	existing, err := u.holidayRepo.GetByID(ctx, holiday.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrHolidayNotFound
+	}
+
+	holiday.Date = calendarDay(holiday.Date)
+	holiday.CreatedAt = existing.CreatedAt
+	return u.holidayRepo.Update(ctx, holiday)
+}
+
+func (u *holidayUsecase) DeleteHoliday(ctx context.Context, id string) error {
+	existing, err := u.holidayRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrHolidayNotFound
+	}
+	return u.holidayRepo.Delete(ctx, id)
+}
+
+// ImportHolidays creates a holiday for every day covered by the calendar's events.
+// Days that already have a holiday at the location are skipped, so re-importing
+// the same calendar is harmless.
+func (u *holidayUsecase) ImportHolidays(ctx context.Context, calendar io.Reader, location string) (*domain.HolidayImportResult, error) {
+	events, err := ical.Parse(calendar)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCalendar, err)
+	}
+
+	// Validate the whole file before importing anything
+	var holidays []domain.Holiday
+	for _, event := range events {
+		days := event.Days()
+		if event.Summary == "" || len(days) > domain.MaxHolidayEventDays {
+			return nil, fmt.Errorf("%w: event on %s must have a summary and span at most %d days",
+				domain.ErrInvalidCalendar, event.Start.Format(domain.DateFormat), domain.MaxHolidayEventDays)
+		}
+		for _, day := range days {
+			holidays = append(holidays, domain.Holiday{Date: day, Name: event.Summary, Location: location})
+		}
+	}
+
+	result := &domain.HolidayImportResult{}
+	for i := range holidays {
+		err := u.CreateHoliday(ctx, &holidays[i])
+		if err == domain.ErrHolidayExists {
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		result.Imported++
+	}
+	return result, nil
+}
+
+func filterObserved(holidays []domain.Holiday, location string) []domain.Holiday {
+	var observed []domain.Holiday
+	for _, holiday := range holidays {
+		if holiday.ObservedAt(location) {
+			observed = append(observed, holiday)
+		}
+	}
+	return observed
+}
+
+// holidaySet holds the dates of the holidays observed at one location
+type holidaySet map[string]bool
+
+func observedHolidays(holidays []domain.Holiday, location string) holidaySet {
+	set := make(holidaySet, len(holidays))
+	for _, holiday := range filterObserved(holidays, location) {
+		set[holiday.Date.UTC().Format(domain.DateFormat)] = true
+	}
+	return set
+}
+
+func (s holidaySet) contains(day time.Time) bool {
+	return s[day.UTC().Format(domain.DateFormat)]
+}
+
+// calendarDay returns the date of t at midnight UTC, the form DATE columns are read back in
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func yearStart(year int) time.Time {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockHolidayRepository is a mock type for domain.HolidayRepository
+type MockHolidayRepository struct {
+	mock.Mock
+}
+
+func (m *MockHolidayRepository) Create(ctx context.Context, holiday *domain.Holiday) error {
+	args := m.Called(ctx, holiday)
+	return args.Error(0)
+}
+
+func (m *MockHolidayRepository) GetByID(ctx context.Context, id string) (*domain.Holiday, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Holiday), args.Error(1)
+}
+
+func (m *MockHolidayRepository) GetByDate(ctx context.Context, date time.Time) ([]domain.Holiday, error) {
+	args := m.Called(ctx, date)
+	return args.Get(0).([]domain.Holiday), args.Error(1)
+}
+
+func (m *MockHolidayRepository) GetInRange(ctx context.Context, from, to time.Time) ([]domain.Holiday, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.Holiday), args.Error(1)
+}
+
+func (m *MockHolidayRepository) Update(ctx context.Context, holiday *domain.Holiday) error {
+	args := m.Called(ctx, holiday)
+	return args.Error(0)
+}
+
+func (m *MockHolidayRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// newEmptyHolidayRepository returns a holiday repository mock for a calendar without holidays
+func newEmptyHolidayRepository() *MockHolidayRepository {
+	m := new(MockHolidayRepository)
+	m.On("GetByDate", mock.Anything, mock.Anything).Return([]domain.Holiday{}, nil).Maybe()
+	m.On("GetInRange", mock.Anything, mock.Anything, mock.Anything).Return([]domain.Holiday{}, nil).Maybe()
+	return m
+}
+
+const testCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Test//Holidays//EN\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241226\r\n" +
+	"SUMMARY:Christmas Day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20240410\r\n" +
+	"DTEND;VALUE=DATE:20240412\r\n" +
+	"SUMMARY:Eid al-Fitr\\, Day 1\r\n" +
+	"  and 2\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestHolidayUsecase_ImportHolidays(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockHolidayRepo := new(MockHolidayRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewHolidayUsecase(mockHolidayRepo, mockUserRepo)
+		ctx := context.Background()
+
+		christmas := time.Date(2024, 12, 25, 0, 0, 0, 0, time.UTC)
+		mockHolidayRepo.On("Create", ctx, mock.MatchedBy(func(h *domain.Holiday) bool {
+			return h.Date.Equal(christmas)
+		})).Return(domain.ErrHolidayExists)
+		mockHolidayRepo.On("Create", ctx, mock.MatchedBy(func(h *domain.Holiday) bool {
+			return !h.Date.Equal(christmas) && h.Name == "Eid al-Fitr, Day 1 and 2" && h.Location == "Jakarta"
+		})).Return(nil).Twice()
+
+		result, err := usecase.ImportHolidays(ctx, strings.NewReader(testCalendar), "Jakarta")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 1, result.Skipped)
+		mockHolidayRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Calendar", func(t *testing.T) {
+		mockHolidayRepo := new(MockHolidayRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewHolidayUsecase(mockHolidayRepo, mockUserRepo)
+
+		result, err := usecase.ImportHolidays(context.Background(), strings.NewReader("not a calendar"), "")
+		assert.ErrorIs(t, err, domain.ErrInvalidCalendar)
+		assert.Nil(t, result)
+		mockHolidayRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Event Too Long", func(t *testing.T) {
+		mockHolidayRepo := new(MockHolidayRepository)
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewHolidayUsecase(mockHolidayRepo, mockUserRepo)
+
+		calendar := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20240101\nDTEND;VALUE=DATE:20250101\nSUMMARY:Closed\nEND:VEVENT\nEND:VCALENDAR\n"
+		result, err := usecase.ImportHolidays(context.Background(), strings.NewReader(calendar), "")
+		assert.ErrorIs(t, err, domain.ErrInvalidCalendar)
+		assert.Nil(t, result)
+		mockHolidayRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHolidayUsecase_ListHolidays(t *testing.T) {
+	holidays := []domain.Holiday{
+		{ID: "1", Name: "New Year", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", Name: "Regional Day", Location: "Bandung", Date: time.Date(2024, 4, 25, 0, 0, 0, 0, time.UTC)},
+		{ID: "3", Name: "City Anniversary", Location: "Jakarta", Date: time.Date(2024, 6, 22, 0, 0, 0, 0, time.UTC)},
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name        string
+		location    string
+		expectedIDs []string
+	}
+
+	tests := []testCase{
+		{name: "All Locations", location: "", expectedIDs: []string{"1", "2", "3"}},
+		{name: "Single Location", location: "Jakarta", expectedIDs: []string{"1", "3"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockHolidayRepo := new(MockHolidayRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewHolidayUsecase(mockHolidayRepo, mockUserRepo)
+			ctx := context.Background()
+
+			mockHolidayRepo.On("GetInRange", ctx, from, to).Return(holidays, nil)
+
+			result, err := usecase.ListHolidays(ctx, 2024, tc.location)
+			assert.NoError(t, err)
+			var ids []string
+			for _, holiday := range result {
+				ids = append(ids, holiday.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+			mockHolidayRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHolidayUsecase_UpdateHoliday_NotFound(t *testing.T) {
+	mockHolidayRepo := new(MockHolidayRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewHolidayUsecase(mockHolidayRepo, mockUserRepo)
+	ctx := context.Background()
+
+	mockHolidayRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+	err := usecase.UpdateHoliday(ctx, &domain.Holiday{ID: "missing", Name: "Closed"})
+	assert.ErrorIs(t, err, domain.ErrHolidayNotFound)
+	mockHolidayRepo.AssertExpectations(t)
+}
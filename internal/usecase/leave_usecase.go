@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type leaveUsecase struct {
+	leaveRepo      domain.LeaveRepository
+	attendanceRepo domain.AttendanceRepository
+	userRepo       domain.UserRepository
+	scheduleRepo   domain.WorkScheduleRepository
+	holidayRepo    domain.HolidayRepository
+	roleRepo       domain.RoleRepository
+	entitlements   map[string]int
+	now            func() time.Time
+}
+
+// NewLeaveUsecase creates a leave usecase. entitlements holds the default yearly
+// days per balance-tracked leave type, used until an admin sets a user's entitlement.
+func NewLeaveUsecase(leaveRepo domain.LeaveRepository, attendanceRepo domain.AttendanceRepository, userRepo domain.UserRepository, scheduleRepo domain.WorkScheduleRepository, holidayRepo domain.HolidayRepository, roleRepo domain.RoleRepository, entitlements map[string]int) domain.LeaveUsecase {
+	return &leaveUsecase{
+		leaveRepo:      leaveRepo,
+		attendanceRepo: attendanceRepo,
+		userRepo:       userRepo,
+		scheduleRepo:   scheduleRepo,
+		holidayRepo:    holidayRepo,
+		roleRepo:       roleRepo,
+		entitlements:   entitlements,
+		now:            time.Now,
+	}
+}
+
+func (u *leaveUsecase) RequestLeave(ctx context.Context, leave *domain.LeaveRequest) error {
+	user, err := u.userRepo.GetByID(ctx, leave.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	if !domain.ValidLeaveTypes[leave.Type] {
+		return domain.ErrInvalidLeaveType
+	}
+
+	// Requests may not span years so they can be charged to a single yearly balance
+	leave.StartDate = leave.StartDate.Truncate(24 * time.Hour)
+	leave.EndDate = leave.EndDate.Truncate(24 * time.Hour)
+	if leave.EndDate.Before(leave.StartDate) || leave.StartDate.Year() != leave.EndDate.Year() {
+		return domain.ErrInvalidDateRange
+	}
+
+	overlapping, err := u.leaveRepo.GetActiveInRange(ctx, leave.UserID, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		return domain.ErrLeaveOverlap
+	}
+
+	days, err := u.workingDays(ctx, user, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return domain.ErrNoWorkingDays
+	}
+	leave.Days = len(days)
+
+	if domain.BalanceTrackedLeaveTypes[leave.Type] {
+		balance, err := u.balance(ctx, leave.UserID, leave.StartDate.Year(), leave.Type)
+		if err != nil {
+			return err
+		}
+		if balance.RemainingDays < leave.Days {
+			return domain.ErrInsufficientLeaveBalance
+		}
+	}
+
+	leave.ID = uuid.New().String()
+	leave.Status = domain.LeaveStatusPending
+	leave.ReviewedBy = ""
+	leave.ReviewedAt = nil
+	leave.ReviewNote = ""
+
+	return u.leaveRepo.Create(ctx, leave)
+}
+
+func (u *leaveUsecase) CancelLeave(ctx context.Context, userID, id string) error {
+	leave, err := u.leaveRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if leave == nil || leave.UserID != userID {
+		return domain.ErrLeaveNotFound
+	}
+	if leave.Status != domain.LeaveStatusPending {
+		return domain.ErrLeaveNotPending
+	}
+
+	leave.Status = domain.LeaveStatusCancelled
+	return u.leaveRepo.Update(ctx, leave)
+}
+
+// ApproveLeave approves a pending request, charging the balance with the
+// working days the range holds at approval time
+func (u *leaveUsecase) ApproveLeave(ctx context.Context, id, reviewerID, note string) (*domain.LeaveRequest, error) {
+	leave, err := u.reviewableLeave(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, leave.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	// Holidays or the schedule may have changed since the request was made
+	days, err := u.workingDays(ctx, user, leave.StartDate, leave.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, domain.ErrNoWorkingDays
+	}
+
+	var balance *domain.LeaveBalance
+	if domain.BalanceTrackedLeaveTypes[leave.Type] {
+		balance, err = u.balance(ctx, leave.UserID, leave.StartDate.Year(), leave.Type)
+		if err != nil {
+			return nil, err
+		}
+		if balance.RemainingDays < len(days) {
+			return nil, domain.ErrInsufficientLeaveBalance
+		}
+	}
+
+	// Update the request first so a partial failure below can never be approved twice
+	leave.Days = len(days)
+	reviewLeave(leave, domain.LeaveStatusApproved, reviewerID, note, u.now())
+	if err := u.leaveRepo.Update(ctx, leave); err != nil {
+		return nil, err
+	}
+
+	if balance != nil {
+		balance.UsedDays += leave.Days
+		if err := u.leaveRepo.SaveBalance(ctx, balance); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, day := range days {
+		if err := u.markLeaveDay(ctx, leave.UserID, day); err != nil {
+			return nil, err
+		}
+	}
+
+	return leave, nil
+}
+
+func (u *leaveUsecase) RejectLeave(ctx context.Context, id, reviewerID, note string) (*domain.LeaveRequest, error) {
+	leave, err := u.reviewableLeave(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewLeave(leave, domain.LeaveStatusRejected, reviewerID, note, u.now())
+	if err := u.leaveRepo.Update(ctx, leave); err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (u *leaveUsecase) GetUserLeaves(ctx context.Context, userID string) ([]domain.LeaveRequest, error) {
+	return u.leaveRepo.GetByUserID(ctx, userID)
+}
+
+// GetLeavesByStatus returns the requests with the status that the caller may
+// review: everyone's with leaves:manage, otherwise their reports'
+func (u *leaveUsecase) GetLeavesByStatus(ctx context.Context, callerID, status string) ([]domain.LeaveRequest, error) {
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermLeavesManage)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := u.leaveRepo.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	if scope.All {
+		return leaves, nil
+	}
+
+	visible := []domain.LeaveRequest{}
+	for _, leave := range leaves {
+		if scope.Includes(leave.UserID) {
+			visible = append(visible, leave)
+		}
+	}
+	return visible, nil
+}
+
+// GetBalances returns the user's balances. Callers other than the user need
+// leaves:manage or must manage the user.
+func (u *leaveUsecase) GetBalances(ctx context.Context, callerID, userID string, year int) ([]domain.LeaveBalance, error) {
+	if userID != callerID {
+		scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermLeavesManage)
+		if err != nil {
+			return nil, err
+		}
+		if !scope.Includes(userID) {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	var balances []domain.LeaveBalance
+	for _, leaveType := range []string{domain.LeaveTypeAnnual, domain.LeaveTypeSick} {
+		balance, err := u.balance(ctx, userID, year, leaveType)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, *balance)
+	}
+	return balances, nil
+}
+
+func (u *leaveUsecase) SetEntitlement(ctx context.Context, userID string, year int, leaveType string, days int) (*domain.LeaveBalance, error) {
+	if !domain.BalanceTrackedLeaveTypes[leaveType] {
+		return nil, domain.ErrInvalidLeaveType
+	}
+	if days < 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	balance, err := u.balance(ctx, userID, year, leaveType)
+	if err != nil {
+		return nil, err
+	}
+	balance.EntitledDays = days
+	if err := u.leaveRepo.SaveBalance(ctx, balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// reviewableLeave returns a pending request the reviewer may decide on. Users
+// with leaves:manage review anyone's requests, other approvers their reports'.
+func (u *leaveUsecase) reviewableLeave(ctx context.Context, id, reviewerID string) (*domain.LeaveRequest, error) {
+	leave, err := u.leaveRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if leave == nil {
+		return nil, domain.ErrLeaveNotFound
+	}
+	if leave.UserID == reviewerID {
+		return nil, domain.ErrOwnLeave
+	}
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, reviewerID, domain.PermLeavesManage)
+	if err != nil {
+		return nil, err
+	}
+	if !scope.Includes(leave.UserID) {
+		return nil, domain.ErrPermissionDenied
+	}
+	if leave.Status != domain.LeaveStatusPending {
+		return nil, domain.ErrLeaveNotPending
+	}
+	return leave, nil
+}
+
+// balance returns the stored balance or one built from the default entitlement
+func (u *leaveUsecase) balance(ctx context.Context, userID string, year int, leaveType string) (*domain.LeaveBalance, error) {
+	balance, err := u.leaveRepo.GetBalance(ctx, userID, year, leaveType)
+	if err != nil {
+		return nil, err
+	}
+	if balance == nil {
+		balance = &domain.LeaveBalance{
+			UserID:        userID,
+			Year:          year,
+			Type:          leaveType,
+			EntitledDays:  u.entitlements[leaveType],
+			RemainingDays: u.entitlements[leaveType],
+		}
+	}
+	return balance, nil
+}
+
+// workingDays returns the days in the inclusive range the user is scheduled to work,
+// leaving out holidays observed at the user's location
+func (u *leaveUsecase) workingDays(ctx context.Context, user *domain.User, from, to time.Time) ([]time.Time, error) {
+	schedule, err := u.scheduleRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := u.holidayRepo.GetInRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	closed := observedHolidays(holidays, user.Location)
+
+	var days []time.Time
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isWorkingDay(schedule, day) && !closed.contains(day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// markLeaveDay records the day as leave, replacing an automatic absence if present
+func (u *leaveUsecase) markLeaveDay(ctx context.Context, userID string, day time.Time) error {
+	existing, err := u.attendanceRepo.GetByUserIDAndDate(ctx, userID, day)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		err := u.attendanceRepo.Create(ctx, &domain.Attendance{
+			ID:     uuid.New().String(),
+			UserID: userID,
+			Date:   day,
+			Status: domain.StatusLeave,
+		})
+		if err == domain.ErrAttendanceAlreadyMarked {
+			return nil
+		}
+		return err
+	}
+	if existing.Status == domain.StatusAbsent {
+		existing.Status = domain.StatusLeave
+		return u.attendanceRepo.Update(ctx, existing)
+	}
+	return nil
+}
+
+func reviewLeave(leave *domain.LeaveRequest, status, reviewerID, note string, at time.Time) {
+	leave.Status = status
+	leave.ReviewedBy = reviewerID
+	leave.ReviewedAt = &at
+	leave.ReviewNote = note
+}
@@ -0,0 +1,575 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLeaveRepository is a mock type for domain.LeaveRepository
+type MockLeaveRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaveRepository) Create(ctx context.Context, leave *domain.LeaveRequest) error {
+	args := m.Called(ctx, leave)
+	return args.Error(0)
+}
+
+func (m *MockLeaveRepository) GetByID(ctx context.Context, id string) (*domain.LeaveRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveRequest), args.Error(1)
+}
+
+func (m *MockLeaveRepository) GetByUserID(ctx context.Context, userID string) ([]domain.LeaveRequest, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.LeaveRequest), args.Error(1)
+}
+
+func (m *MockLeaveRepository) GetByStatus(ctx context.Context, status string) ([]domain.LeaveRequest, error) {
+	args := m.Called(ctx, status)
+	return args.Get(0).([]domain.LeaveRequest), args.Error(1)
+}
+
+func (m *MockLeaveRepository) GetActiveInRange(ctx context.Context, userID string, from, to time.Time) ([]domain.LeaveRequest, error) {
+	args := m.Called(ctx, userID, from, to)
+	return args.Get(0).([]domain.LeaveRequest), args.Error(1)
+}
+
+func (m *MockLeaveRepository) Update(ctx context.Context, leave *domain.LeaveRequest) error {
+	args := m.Called(ctx, leave)
+	return args.Error(0)
+}
+
+func (m *MockLeaveRepository) GetBalance(ctx context.Context, userID string, year int, leaveType string) (*domain.LeaveBalance, error) {
+	args := m.Called(ctx, userID, year, leaveType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LeaveBalance), args.Error(1)
+}
+
+func (m *MockLeaveRepository) SaveBalance(ctx context.Context, balance *domain.LeaveBalance) error {
+	args := m.Called(ctx, balance)
+	return args.Error(0)
+}
+
+var testEntitlements = map[string]int{
+	domain.LeaveTypeAnnual: 12,
+	domain.LeaveTypeSick:   10,
+}
+
+type leaveMocks struct {
+	leaveRepo      *MockLeaveRepository
+	attendanceRepo *MockAttendanceRepository
+	userRepo       *MockUserRepository
+	scheduleRepo   *MockWorkScheduleRepository
+	holidayRepo    *MockHolidayRepository
+}
+
+func newLeaveMocks() *leaveMocks {
+	return &leaveMocks{
+		leaveRepo:      new(MockLeaveRepository),
+		attendanceRepo: new(MockAttendanceRepository),
+		userRepo:       new(MockUserRepository),
+		scheduleRepo:   new(MockWorkScheduleRepository),
+		holidayRepo:    newEmptyHolidayRepository(),
+	}
+}
+
+// leaveReviewTime is the time of every leave review in the tests
+var leaveReviewTime = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func (m *leaveMocks) usecase() domain.LeaveUsecase {
+	uc := NewLeaveUsecase(m.leaveRepo, m.attendanceRepo, m.userRepo, m.scheduleRepo, m.holidayRepo, newBuiltInRoleRepository(), testEntitlements).(*leaveUsecase)
+	uc.now = func() time.Time { return leaveReviewTime }
+	return uc
+}
+
+func (m *leaveMocks) assertExpectations(t *testing.T) {
+	m.leaveRepo.AssertExpectations(t)
+	m.attendanceRepo.AssertExpectations(t)
+	m.userRepo.AssertExpectations(t)
+	m.scheduleRepo.AssertExpectations(t)
+	m.holidayRepo.AssertExpectations(t)
+}
+
+func TestLeaveUsecase_RequestLeave(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	sunday := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		leave         *domain.LeaveRequest
+		mockBehavior  func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest)
+		expectedError error
+		expectedDays  int
+	}
+
+	tests := []testCase{
+		{
+			name:  "Success Annual Leave",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeAnnual, StartDate: monday, EndDate: sunday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				m.leaveRepo.On("GetActiveInRange", ctx, "user1", monday, sunday).Return([]domain.LeaveRequest{}, nil)
+				m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+				m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(nil, nil)
+				m.leaveRepo.On("Create", ctx, leave).Return(nil)
+			},
+			expectedDays: 5,
+		},
+		{
+			name:  "Success Unpaid Leave Skips Balance",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeUnpaid, StartDate: monday, EndDate: friday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				m.leaveRepo.On("GetActiveInRange", ctx, "user1", monday, friday).Return([]domain.LeaveRequest{}, nil)
+				m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+				m.leaveRepo.On("Create", ctx, leave).Return(nil)
+			},
+			expectedDays: 5,
+		},
+		{
+			name:  "Invalid Leave Type",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: "holiday", StartDate: monday, EndDate: friday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+			},
+			expectedError: domain.ErrInvalidLeaveType,
+		},
+		{
+			name:  "End Before Start",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeSick, StartDate: friday, EndDate: monday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+			},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name: "Spans Two Years",
+			leave: &domain.LeaveRequest{
+				UserID:    "user1",
+				Type:      domain.LeaveTypeAnnual,
+				StartDate: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+			},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name:  "Overlapping Request",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeAnnual, StartDate: monday, EndDate: friday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				m.leaveRepo.On("GetActiveInRange", ctx, "user1", monday, friday).Return([]domain.LeaveRequest{{ID: "other"}}, nil)
+			},
+			expectedError: domain.ErrLeaveOverlap,
+		},
+		{
+			name:  "Weekend Only",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeAnnual, StartDate: sunday, EndDate: sunday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				m.leaveRepo.On("GetActiveInRange", ctx, "user1", sunday, sunday).Return([]domain.LeaveRequest{}, nil)
+				m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+			},
+			expectedError: domain.ErrNoWorkingDays,
+		},
+		{
+			name:  "Insufficient Balance",
+			leave: &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeAnnual, StartDate: monday, EndDate: friday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				m.leaveRepo.On("GetActiveInRange", ctx, "user1", monday, friday).Return([]domain.LeaveRequest{}, nil)
+				m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+				m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(&domain.LeaveBalance{
+					EntitledDays: 12, UsedDays: 9, RemainingDays: 3,
+				}, nil)
+			},
+			expectedError: domain.ErrInsufficientLeaveBalance,
+		},
+		{
+			name:  "User Not Found",
+			leave: &domain.LeaveRequest{UserID: "missing", Type: domain.LeaveTypeAnnual, StartDate: monday, EndDate: friday},
+			mockBehavior: func(m *leaveMocks, ctx context.Context, leave *domain.LeaveRequest) {
+				m.userRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newLeaveMocks()
+			ctx := context.Background()
+
+			tc.mockBehavior(m, ctx, tc.leave)
+
+			err := m.usecase().RequestLeave(ctx, tc.leave)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, tc.leave.ID)
+				assert.Equal(t, domain.LeaveStatusPending, tc.leave.Status)
+				assert.Equal(t, tc.expectedDays, tc.leave.Days)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveUsecase_ApproveLeave(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	reviewer := &domain.User{ID: "admin1", Role: domain.RoleAdmin}
+
+	t.Run("Success", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+		leave := &domain.LeaveRequest{
+			ID: "leave1", UserID: "user1", Type: domain.LeaveTypeAnnual,
+			StartDate: monday, EndDate: tuesday, Days: 2, Status: domain.LeaveStatusPending,
+		}
+
+		m.leaveRepo.On("GetByID", ctx, "leave1").Return(leave, nil)
+		m.userRepo.On("GetByID", ctx, reviewer.ID).Return(reviewer, nil)
+		m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(&domain.LeaveBalance{
+			UserID: "user1", Year: 2024, Type: domain.LeaveTypeAnnual, EntitledDays: 12, UsedDays: 1, RemainingDays: 11,
+		}, nil)
+		m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+		m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+		m.leaveRepo.On("Update", ctx, leave).Return(nil)
+		m.leaveRepo.On("SaveBalance", ctx, mock.MatchedBy(func(b *domain.LeaveBalance) bool {
+			return b.UsedDays == 3
+		})).Return(nil)
+		// Monday has no record yet, Tuesday was auto-marked absent
+		m.attendanceRepo.On("GetByUserIDAndDate", ctx, "user1", monday).Return(nil, nil)
+		m.attendanceRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+			return a.Status == domain.StatusLeave && a.Date.Equal(monday)
+		})).Return(nil)
+		m.attendanceRepo.On("GetByUserIDAndDate", ctx, "user1", tuesday).Return(&domain.Attendance{ID: "a2", Status: domain.StatusAbsent}, nil)
+		m.attendanceRepo.On("Update", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+			return a.ID == "a2" && a.Status == domain.StatusLeave
+		})).Return(nil)
+
+		approved, err := m.usecase().ApproveLeave(ctx, "leave1", "admin1", "enjoy")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.LeaveStatusApproved, approved.Status)
+		assert.Equal(t, "admin1", approved.ReviewedBy)
+		assert.Equal(t, leaveReviewTime, *approved.ReviewedAt)
+		m.assertExpectations(t)
+	})
+
+	t.Run("Not Pending", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.leaveRepo.On("GetByID", ctx, "leave1").Return(&domain.LeaveRequest{ID: "leave1", UserID: "user1", Status: domain.LeaveStatusRejected}, nil)
+		m.userRepo.On("GetByID", ctx, reviewer.ID).Return(reviewer, nil)
+
+		approved, err := m.usecase().ApproveLeave(ctx, "leave1", "admin1", "")
+		assert.ErrorIs(t, err, domain.ErrLeaveNotPending)
+		assert.Nil(t, approved)
+		m.assertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.leaveRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		approved, err := m.usecase().ApproveLeave(ctx, "missing", "admin1", "")
+		assert.ErrorIs(t, err, domain.ErrLeaveNotFound)
+		assert.Nil(t, approved)
+		m.assertExpectations(t)
+	})
+
+	t.Run("Charges The Working Days Left", func(t *testing.T) {
+		m := newLeaveMocks()
+		m.holidayRepo = new(MockHolidayRepository)
+		ctx := context.Background()
+		// Requested as two days, Tuesday became a holiday before approval
+		leave := &domain.LeaveRequest{
+			ID: "leave1", UserID: "user1", Type: domain.LeaveTypeAnnual,
+			StartDate: monday, EndDate: tuesday, Days: 2, Status: domain.LeaveStatusPending,
+		}
+
+		m.leaveRepo.On("GetByID", ctx, "leave1").Return(leave, nil)
+		m.userRepo.On("GetByID", ctx, reviewer.ID).Return(reviewer, nil)
+		m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+		m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+		m.holidayRepo.On("GetInRange", ctx, monday, tuesday).Return([]domain.Holiday{{Name: "Founding Day", Date: tuesday}}, nil)
+		m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(&domain.LeaveBalance{
+			UserID: "user1", Year: 2024, Type: domain.LeaveTypeAnnual, EntitledDays: 12, RemainingDays: 12,
+		}, nil)
+		m.leaveRepo.On("Update", ctx, mock.MatchedBy(func(l *domain.LeaveRequest) bool {
+			return l.Days == 1
+		})).Return(nil)
+		m.leaveRepo.On("SaveBalance", ctx, mock.MatchedBy(func(b *domain.LeaveBalance) bool {
+			return b.UsedDays == 1
+		})).Return(nil)
+		m.attendanceRepo.On("GetByUserIDAndDate", ctx, "user1", monday).Return(nil, nil)
+		m.attendanceRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.Attendance) bool {
+			return a.Date.Equal(monday)
+		})).Return(nil).Once()
+
+		approved, err := m.usecase().ApproveLeave(ctx, "leave1", reviewer.ID, "")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, approved.Days)
+		m.assertExpectations(t)
+	})
+}
+
+func TestLeaveUsecase_ReviewLeave_Scope(t *testing.T) {
+	manager := &domain.User{ID: "manager1", Role: "team-lead"}
+
+	type testCase struct {
+		name          string
+		reviewerID    string
+		mockBehavior  func(m *leaveMocks, ctx context.Context)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Manager Rejects Report's Leave",
+			reviewerID: manager.ID,
+			mockBehavior: func(m *leaveMocks, ctx context.Context) {
+				m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user1"}}, nil)
+				m.leaveRepo.On("Update", ctx, mock.AnythingOfType("*domain.LeaveRequest")).Return(nil)
+			},
+		},
+		{
+			name:          "Own Leave",
+			reviewerID:    "user1",
+			mockBehavior:  func(m *leaveMocks, ctx context.Context) {},
+			expectedError: domain.ErrOwnLeave,
+		},
+		{
+			name:       "Outside The Reviewer's Reports",
+			reviewerID: manager.ID,
+			mockBehavior: func(m *leaveMocks, ctx context.Context) {
+				m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user2"}}, nil)
+			},
+			expectedError: domain.ErrPermissionDenied,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newLeaveMocks()
+			ctx := context.Background()
+
+			m.leaveRepo.On("GetByID", ctx, "leave1").Return(&domain.LeaveRequest{ID: "leave1", UserID: "user1", Status: domain.LeaveStatusPending}, nil)
+			tc.mockBehavior(m, ctx)
+
+			leave, err := m.usecase().RejectLeave(ctx, "leave1", tc.reviewerID, "")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, leave)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.LeaveStatusRejected, leave.Status)
+				assert.Equal(t, leaveReviewTime, *leave.ReviewedAt)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveUsecase_CancelLeave(t *testing.T) {
+	type testCase struct {
+		name          string
+		leave         *domain.LeaveRequest
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:  "Success",
+			leave: &domain.LeaveRequest{ID: "leave1", UserID: "user1", Status: domain.LeaveStatusPending},
+		},
+		{
+			name:          "Other User's Request",
+			leave:         &domain.LeaveRequest{ID: "leave1", UserID: "user2", Status: domain.LeaveStatusPending},
+			expectedError: domain.ErrLeaveNotFound,
+		},
+		{
+			name:          "Already Approved",
+			leave:         &domain.LeaveRequest{ID: "leave1", UserID: "user1", Status: domain.LeaveStatusApproved},
+			expectedError: domain.ErrLeaveNotPending,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newLeaveMocks()
+			ctx := context.Background()
+
+			m.leaveRepo.On("GetByID", ctx, "leave1").Return(tc.leave, nil)
+			if tc.expectedError == nil {
+				m.leaveRepo.On("Update", ctx, tc.leave).Return(nil)
+			}
+
+			err := m.usecase().CancelLeave(ctx, "user1", "leave1")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.LeaveStatusCancelled, tc.leave.Status)
+			}
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestLeaveUsecase_GetBalances_DefaultEntitlement(t *testing.T) {
+	m := newLeaveMocks()
+	ctx := context.Background()
+
+	m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(&domain.LeaveBalance{
+		UserID: "user1", Year: 2024, Type: domain.LeaveTypeAnnual, EntitledDays: 20, UsedDays: 5, RemainingDays: 15,
+	}, nil)
+	m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeSick).Return(nil, nil)
+
+	balances, err := m.usecase().GetBalances(ctx, "user1", "user1", 2024)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, 15, balances[0].RemainingDays)
+	assert.Equal(t, 10, balances[1].EntitledDays)
+	assert.Equal(t, 10, balances[1].RemainingDays)
+	m.assertExpectations(t)
+}
+
+func TestLeaveUsecase_SetEntitlement(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+		m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(nil, nil)
+		m.leaveRepo.On("SaveBalance", ctx, mock.AnythingOfType("*domain.LeaveBalance")).Return(nil)
+
+		balance, err := m.usecase().SetEntitlement(ctx, "user1", 2024, domain.LeaveTypeAnnual, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, 20, balance.EntitledDays)
+		m.assertExpectations(t)
+	})
+
+	t.Run("Unpaid Leave Is Not Tracked", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		balance, err := m.usecase().SetEntitlement(ctx, "user1", 2024, domain.LeaveTypeUnpaid, 5)
+		assert.ErrorIs(t, err, domain.ErrInvalidLeaveType)
+		assert.Nil(t, balance)
+		m.assertExpectations(t)
+	})
+}
+
+func TestLeaveUsecase_RequestLeave_ExcludesHolidays(t *testing.T) {
+	m := newLeaveMocks()
+	m.holidayRepo = new(MockHolidayRepository)
+	ctx := context.Background()
+
+	monday := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	leave := &domain.LeaveRequest{UserID: "user1", Type: domain.LeaveTypeAnnual, StartDate: monday, EndDate: friday}
+
+	m.userRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+	m.leaveRepo.On("GetActiveInRange", ctx, "user1", monday, friday).Return([]domain.LeaveRequest{}, nil)
+	m.scheduleRepo.On("GetByUserID", ctx, "user1").Return(nil, nil)
+	m.holidayRepo.On("GetInRange", ctx, monday, friday).Return([]domain.Holiday{
+		{Name: "Day of Silence", Date: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{Name: "Regional Day", Location: "Bandung", Date: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+	m.leaveRepo.On("GetBalance", ctx, "user1", 2024, domain.LeaveTypeAnnual).Return(nil, nil)
+	m.leaveRepo.On("Create", ctx, leave).Return(nil)
+
+	err := m.usecase().RequestLeave(ctx, leave)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, leave.Days)
+	m.assertExpectations(t)
+}
+
+func TestLeaveUsecase_GetLeavesByStatus_Scope(t *testing.T) {
+	manager := &domain.User{ID: "manager1", Role: "team-lead"}
+	pending := []domain.LeaveRequest{
+		{ID: "leave1", UserID: "user1", Status: domain.LeaveStatusPending},
+		{ID: "leave2", UserID: "user2", Status: domain.LeaveStatusPending},
+	}
+
+	t.Run("Manager Sees Their Reports' Requests", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user1"}}, nil)
+		m.leaveRepo.On("GetByStatus", ctx, domain.LeaveStatusPending).Return(pending, nil)
+
+		leaves, err := m.usecase().GetLeavesByStatus(ctx, manager.ID, domain.LeaveStatusPending)
+		assert.NoError(t, err)
+		assert.Equal(t, pending[:1], leaves)
+		m.assertExpectations(t)
+	})
+
+	t.Run("Leave Manager Sees Everyone's", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.userRepo.On("GetByID", ctx, "hr1").Return(&domain.User{ID: "hr1", Role: "hr"}, nil)
+		m.leaveRepo.On("GetByStatus", ctx, domain.LeaveStatusPending).Return(pending, nil)
+
+		leaves, err := m.usecase().GetLeavesByStatus(ctx, "hr1", domain.LeaveStatusPending)
+		assert.NoError(t, err)
+		assert.Equal(t, pending, leaves)
+		m.assertExpectations(t)
+	})
+}
+
+func TestLeaveUsecase_GetBalances_Scope(t *testing.T) {
+	manager := &domain.User{ID: "manager1", Role: "team-lead"}
+
+	t.Run("Not The User's Manager", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user2"}}, nil)
+
+		balances, err := m.usecase().GetBalances(ctx, manager.ID, "user1", 2024)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		assert.Nil(t, balances)
+		m.leaveRepo.AssertNotCalled(t, "GetBalance", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.assertExpectations(t)
+	})
+
+	t.Run("The User's Manager", func(t *testing.T) {
+		m := newLeaveMocks()
+		ctx := context.Background()
+
+		m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "user1"}}, nil)
+		m.leaveRepo.On("GetBalance", ctx, "user1", 2024, mock.Anything).Return(nil, nil)
+
+		balances, err := m.usecase().GetBalances(ctx, manager.ID, "user1", 2024)
+		assert.NoError(t, err)
+		assert.Len(t, balances, 2)
+		m.assertExpectations(t)
+	})
+}
@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"golang-tes/internal/domain"
+	"golang-tes/pkg/totp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// recoveryCodeCount is the number of recovery codes issued at a time
+	recoveryCodeCount = 10
+	// totpSkew is how many time steps a code may be off to allow for clock drift
+	totpSkew = 1
+)
+
+type mfaUsecase struct {
+	userRepo  domain.UserRepository
+	tokenRepo domain.TokenRepository
+	mfaRepo   domain.MFARepository
+	roleRepo  domain.RoleRepository
+	// issuer names the account in authenticator apps
+	issuer string
+	now    func() time.Time
+}
+
+func NewMFAUsecase(userRepo domain.UserRepository, tokenRepo domain.TokenRepository, mfaRepo domain.MFARepository, roleRepo domain.RoleRepository, issuer string) domain.MFAUsecase {
+	return &mfaUsecase{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		mfaRepo:   mfaRepo,
+		roleRepo:  roleRepo,
+		issuer:    issuer,
+		now:       time.Now,
+	}
+}
+
+func (u *mfaUsecase) GetStatus(ctx context.Context, userID string) (*domain.MFAStatus, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	required, err := mfaRequiredForRole(ctx, u.mfaRepo, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.MFAStatus{Enabled: mfa.IsEnabled(), Required: required}
+	if status.Enabled {
+		status.RecoveryCodesLeft, err = u.mfaRepo.CountRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func (u *mfaUsecase) Setup(ctx context.Context, userID string) (*domain.MFASetup, error) {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.mfaRepo.Save(ctx, &domain.UserMFA{UserID: userID, Secret: secret}); err != nil {
+		return nil, err
+	}
+	return &domain.MFASetup{
+		Secret: secret,
+		URI:    totp.URI(u.issuer, user.Email, secret),
+	}, nil
+}
+
+func (u *mfaUsecase) Enable(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, domain.ErrMFANotSetUp
+	}
+	if mfa.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	// A correct code proves the authenticator app was set up with the secret
+	now := u.now()
+	if err := checkMFACode(ctx, u.mfaRepo, mfa, code, now, false); err != nil {
+		return nil, err
+	}
+	mfa.EnabledAt = &now
+	if err := u.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, err
+	}
+	return u.replaceRecoveryCodes(ctx, userID)
+}
+
+func (u *mfaUsecase) Disable(ctx context.Context, userID, code string) error {
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !mfa.IsEnabled() {
+		return domain.ErrMFANotEnabled
+	}
+	required, err := mfaRequiredForRole(ctx, u.mfaRepo, user.Role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return domain.ErrMFARequired
+	}
+
+	if err := checkMFACode(ctx, u.mfaRepo, mfa, code, u.now(), true); err != nil {
+		return err
+	}
+	return u.mfaRepo.Delete(ctx, userID)
+}
+
+func (u *mfaUsecase) RegenerateRecoveryCodes(ctx context.Context, userID, code string) ([]string, error) {
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.IsEnabled() {
+		return nil, domain.ErrMFANotEnabled
+	}
+
+	if err := checkMFACode(ctx, u.mfaRepo, mfa, code, u.now(), false); err != nil {
+		return nil, err
+	}
+	return u.replaceRecoveryCodes(ctx, userID)
+}
+
+func (u *mfaUsecase) Reset(ctx context.Context, actorID, userID string) error {
+	// Admins use their own recovery codes instead, so the policy cannot be
+	// sidestepped by an admin resetting their own enrollment
+	if actorID == userID {
+		return domain.ErrSelfManagement
+	}
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return err
+	}
+	mfa, err := u.mfaRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return domain.ErrMFANotEnabled
+	}
+
+	if err := u.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	return u.tokenRepo.RevokeUserRefreshTokens(ctx, userID, u.now())
+}
+
+func (u *mfaUsecase) GetPolicy(ctx context.Context) (*domain.MFAPolicy, error) {
+	roles, err := u.mfaRepo.GetRequiredRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFAPolicy{RequiredRoles: roles}, nil
+}
+
+func (u *mfaUsecase) SetPolicy(ctx context.Context, policy *domain.MFAPolicy) error {
+	roles := []string{}
+	seen := map[string]bool{}
+	for _, role := range policy.RequiredRoles {
+		if seen[role] {
+			continue
+		}
+		existing, err := u.roleRepo.GetByName(ctx, role)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return domain.ErrInvalidRole
+		}
+		seen[role] = true
+		roles = append(roles, role)
+	}
+	if err := u.mfaRepo.SetRequiredRoles(ctx, roles); err != nil {
+		return err
+	}
+	policy.RequiredRoles = roles
+	return nil
+}
+
+func (u *mfaUsecase) getUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// replaceRecoveryCodes stores new recovery codes and returns them in plain
+// text. This is the only time they are available.
+func (u *mfaUsecase) replaceRecoveryCodes(ctx context.Context, userID string) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	records := make([]domain.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		records[i] = domain.RecoveryCode{
+			ID:       uuid.New().String(),
+			UserID:   userID,
+			CodeHash: hashToken(normalizeRecoveryCode(code)),
+		}
+	}
+	if err := u.mfaRepo.ReplaceRecoveryCodes(ctx, userID, records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// mfaRequiredForRole reports whether the MFA policy covers the role
+func mfaRequiredForRole(ctx context.Context, mfaRepo domain.MFARepository, role string) (bool, error) {
+	roles, err := mfaRepo.GetRequiredRoles(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, required := range roles {
+		if required == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkMFACode accepts a TOTP code that was not used before and, if allowed,
+// an unused recovery code. An accepted TOTP step is recorded on mfa.
+func checkMFACode(ctx context.Context, mfaRepo domain.MFARepository, mfa *domain.UserMFA, code string, now time.Time, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+	if step, ok := totp.Validate(mfa.Secret, code, now, totpSkew); ok {
+		if step <= mfa.LastStep {
+			return domain.ErrInvalidMFACode
+		}
+		fresh, err := mfaRepo.UseStep(ctx, mfa.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			// Used concurrently by another request
+			return domain.ErrInvalidMFACode
+		}
+		mfa.LastStep = step
+		return nil
+	}
+
+	if !allowRecovery || len(code) == totp.Digits {
+		return domain.ErrInvalidMFACode
+	}
+	unused, err := mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		return err
+	}
+	if !unused {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newRecoveryCode returns 50 random bits formatted as xxxxx-xxxxx
+func newRecoveryCode() (string, error) {
+	random := make([]byte, 7)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(random))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode lets users type recovery codes without the dash and in any case
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang-tes/internal/domain"
+	"golang-tes/pkg/totp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockMFARepository is a mock type for domain.MFARepository
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) GetByUserID(ctx context.Context, userID string) (*domain.UserMFA, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserMFA), args.Error(1)
+}
+
+func (m *MockMFARepository) Save(ctx context.Context, mfa *domain.UserMFA) error {
+	args := m.Called(ctx, mfa)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) Delete(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseStep(ctx context.Context, userID string, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID string, codes []domain.RecoveryCode) error {
+	args := m.Called(ctx, userID, codes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID, hash string, at time.Time) (bool, error) {
+	args := m.Called(ctx, userID, hash, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) CountRecoveryCodes(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMFARepository) GetRequiredRoles(ctx context.Context) ([]string, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockMFARepository) SetRequiredRoles(ctx context.Context, roles []string) error {
+	args := m.Called(ctx, roles)
+	return args.Error(0)
+}
+
+// newNoMFARepository returns an MFA repository without enrollments or policy
+func newNoMFARepository() *MockMFARepository {
+	m := new(MockMFARepository)
+	m.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	m.On("GetRequiredRoles", mock.Anything).Return([]string{}, nil).Maybe()
+	return m
+}
+
+// enabledMFA returns a confirmed enrollment with a fresh secret
+func enabledMFA(t *testing.T, userID string) *domain.UserMFA {
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+	enabledAt := time.Now().Add(-24 * time.Hour)
+	return &domain.UserMFA{UserID: userID, Secret: secret, EnabledAt: &enabledAt}
+}
+
+func currentCode(t *testing.T, secret string) string {
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	assert.NoError(t, err)
+	return code
+}
+
+func TestMFAUsecase_Setup(t *testing.T) {
+	t.Run("Starts Enrollment", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(mockUserRepo, new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Email: "john@example.com"}, nil)
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(nil, nil)
+		mockMFARepo.On("Save", ctx, mock.MatchedBy(func(mfa *domain.UserMFA) bool {
+			return mfa.UserID == "user-id" && mfa.Secret != "" && !mfa.IsEnabled()
+		})).Return(nil)
+
+		setup, err := usecase.Setup(ctx, "user-id")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, setup.Secret)
+		assert.Contains(t, setup.URI, "otpauth://totp/Attendance:john@example.com?")
+		assert.Contains(t, setup.URI, "secret="+setup.Secret)
+		mockMFARepo.AssertExpectations(t)
+	})
+
+	t.Run("Already Enabled", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(mockUserRepo, new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(enabledMFA(t, "user-id"), nil)
+
+		_, err := usecase.Setup(ctx, "user-id")
+		assert.ErrorIs(t, err, domain.ErrMFAAlreadyEnabled)
+		mockMFARepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMFAUsecase_Enable(t *testing.T) {
+	pending := func(t *testing.T) *domain.UserMFA {
+		mfa := enabledMFA(t, "user-id")
+		mfa.EnabledAt = nil
+		return mfa
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mfa := pending(t)
+		var stored []domain.RecoveryCode
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(mfa, nil)
+		mockMFARepo.On("UseStep", ctx, "user-id", mock.AnythingOfType("int64")).Return(true, nil)
+		mockMFARepo.On("Save", ctx, mock.MatchedBy(func(mfa *domain.UserMFA) bool {
+			return mfa.IsEnabled() && mfa.LastStep > 0
+		})).Return(nil)
+		mockMFARepo.On("ReplaceRecoveryCodes", ctx, "user-id", mock.AnythingOfType("[]domain.RecoveryCode")).Run(func(args mock.Arguments) {
+			stored = args.Get(2).([]domain.RecoveryCode)
+		}).Return(nil)
+
+		codes, err := usecase.Enable(ctx, "user-id", currentCode(t, mfa.Secret))
+		assert.NoError(t, err)
+		assert.Len(t, codes, recoveryCodeCount)
+		assert.Len(t, stored, recoveryCodeCount)
+		// Only hashes are stored, and dashes and case do not matter
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+		assert.Equal(t, hashToken(normalizeRecoveryCode(codes[0])), stored[0].CodeHash)
+		assert.Equal(t, normalizeRecoveryCode(codes[0]), normalizeRecoveryCode(" "+codes[0][:5]+codes[0][6:]+" "))
+		mockMFARepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mfa := pending(t)
+		staleCode, err := totp.Code(mfa.Secret, totp.Step(time.Now())-10)
+		assert.NoError(t, err)
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(mfa, nil)
+
+		_, err = usecase.Enable(ctx, "user-id", staleCode)
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+		mockMFARepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Setup Not Started", func(t *testing.T) {
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(nil, nil)
+
+		_, err := usecase.Enable(ctx, "user-id", "123456")
+		assert.ErrorIs(t, err, domain.ErrMFANotSetUp)
+	})
+}
+
+func TestMFAUsecase_Disable(t *testing.T) {
+	t.Run("With Recovery Code", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(mockUserRepo, new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(enabledMFA(t, "user-id"), nil)
+		mockMFARepo.On("GetRequiredRoles", ctx).Return([]string{domain.RoleAdmin}, nil)
+		mockMFARepo.On("UseRecoveryCode", ctx, "user-id", hashToken("abcdefghij"), mock.AnythingOfType("time.Time")).Return(true, nil)
+		mockMFARepo.On("Delete", ctx, "user-id").Return(nil)
+
+		err := usecase.Disable(ctx, "user-id", "ABCDE-FGHIJ")
+		assert.NoError(t, err)
+		mockMFARepo.AssertExpectations(t)
+	})
+
+	t.Run("Required By Policy", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(mockUserRepo, new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+		mockMFARepo.On("GetByUserID", ctx, "admin-id").Return(enabledMFA(t, "admin-id"), nil)
+		mockMFARepo.On("GetRequiredRoles", ctx).Return([]string{domain.RoleAdmin}, nil)
+
+		err := usecase.Disable(ctx, "admin-id", "123456")
+		assert.ErrorIs(t, err, domain.ErrMFARequired)
+		mockMFARepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMFAUsecase_RegenerateRecoveryCodes_RejectsReusedCode(t *testing.T) {
+	mockMFARepo := new(MockMFARepository)
+	usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+	ctx := context.Background()
+
+	mfa := enabledMFA(t, "user-id")
+	mfa.LastStep = totp.Step(time.Now())
+	mockMFARepo.On("GetByUserID", ctx, "user-id").Return(mfa, nil)
+
+	_, err := usecase.RegenerateRecoveryCodes(ctx, "user-id", currentCode(t, mfa.Secret))
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	mockMFARepo.AssertNotCalled(t, "UseStep", mock.Anything, mock.Anything, mock.Anything)
+	mockMFARepo.AssertNotCalled(t, "ReplaceRecoveryCodes", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMFAUsecase_Reset(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(mockUserRepo, mockTokenRepo, mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+		ctx := context.Background()
+
+		mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockMFARepo.On("GetByUserID", ctx, "user-id").Return(enabledMFA(t, "user-id"), nil)
+		mockMFARepo.On("Delete", ctx, "user-id").Return(nil)
+		mockTokenRepo.On("RevokeUserRefreshTokens", ctx, "user-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		err := usecase.Reset(ctx, "admin-id", "user-id")
+		assert.NoError(t, err)
+		mockMFARepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+
+		err := usecase.Reset(context.Background(), "admin-id", "admin-id")
+		assert.ErrorIs(t, err, domain.ErrSelfManagement)
+		mockMFARepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMFAUsecase_SetPolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		roles         []string
+		expectedRoles []string
+		expectedError error
+	}{
+		{
+			name:          "Require For Admins",
+			roles:         []string{domain.RoleAdmin, domain.RoleAdmin},
+			expectedRoles: []string{domain.RoleAdmin},
+		},
+		{
+			name:          "Clear",
+			roles:         nil,
+			expectedRoles: []string{},
+		},
+		{
+			name:          "Invalid Role",
+			roles:         []string{"owner"},
+			expectedError: domain.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockMFARepo := new(MockMFARepository)
+			usecase := NewMFAUsecase(new(MockUserRepository), new(MockTokenRepository), mockMFARepo, newBuiltInRoleRepository(), "Attendance")
+			ctx := context.Background()
+
+			if tc.expectedError == nil {
+				mockMFARepo.On("SetRequiredRoles", ctx, tc.expectedRoles).Return(nil)
+			}
+
+			policy := &domain.MFAPolicy{RequiredRoles: tc.roles}
+			err := usecase.SetPolicy(ctx, policy)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockMFARepo.AssertNotCalled(t, "SetRequiredRoles", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRoles, policy.RequiredRoles)
+			}
+			mockMFARepo.AssertExpectations(t)
+		})
+	}
+}
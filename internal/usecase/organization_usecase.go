@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type organizationUsecase struct {
+	departmentRepo domain.DepartmentRepository
+	teamRepo       domain.TeamRepository
+	userRepo       domain.UserRepository
+	roleRepo       domain.RoleRepository
+}
+
+func NewOrganizationUsecase(departmentRepo domain.DepartmentRepository, teamRepo domain.TeamRepository, userRepo domain.UserRepository, roleRepo domain.RoleRepository) domain.OrganizationUsecase {
+	return &organizationUsecase{
+		departmentRepo: departmentRepo,
+		teamRepo:       teamRepo,
+		userRepo:       userRepo,
+		roleRepo:       roleRepo,
+	}
+}
+
+func (u *organizationUsecase) CreateDepartment(ctx context.Context, department *domain.Department) error {
+	department.ID = uuid.New().String()
+	return u.departmentRepo.Create(ctx, department)
+}
+
+func (u *organizationUsecase) GetDepartment(ctx context.Context, id string) (*domain.Department, error) {
+	department, err := u.departmentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if department == nil {
+		return nil, domain.ErrDepartmentNotFound
+	}
+	return department, nil
+}
+
+func (u *organizationUsecase) ListDepartments(ctx context.Context) ([]domain.Department, error) {
+	return u.departmentRepo.GetAll(ctx)
+}
+
+func (u *organizationUsecase) UpdateDepartment(ctx context.Context, department *domain.Department) error {
+	existing, err := u.GetDepartment(ctx, department.ID)
+	if err != nil {
+		return err
+	}
+
+	department.CreatedAt = existing.CreatedAt
+	return u.departmentRepo.Update(ctx, department)
+}
+
+func (u *organizationUsecase) DeleteDepartment(ctx context.Context, id string) error {
+	if _, err := u.GetDepartment(ctx, id); err != nil {
+		return err
+	}
+	teams, err := u.teamRepo.GetAll(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(teams) > 0 {
+		return domain.ErrDepartmentHasTeams
+	}
+	return u.departmentRepo.Delete(ctx, id)
+}
+
+func (u *organizationUsecase) CreateTeam(ctx context.Context, team *domain.Team) error {
+	if _, err := u.GetDepartment(ctx, team.DepartmentID); err != nil {
+		return err
+	}
+
+	team.ID = uuid.New().String()
+	return u.teamRepo.Create(ctx, team)
+}
+
+func (u *organizationUsecase) GetTeam(ctx context.Context, id string) (*domain.Team, error) {
+	team, err := u.teamRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrTeamNotFound
+	}
+	return team, nil
+}
+
+func (u *organizationUsecase) ListTeams(ctx context.Context, departmentID string) ([]domain.Team, error) {
+	return u.teamRepo.GetAll(ctx, departmentID)
+}
+
+// UpdateTeam renames a team. Its department stays unchanged.
+func (u *organizationUsecase) UpdateTeam(ctx context.Context, team *domain.Team) error {
+	existing, err := u.GetTeam(ctx, team.ID)
+	if err != nil {
+		return err
+	}
+
+	team.DepartmentID = existing.DepartmentID
+	team.CreatedAt = existing.CreatedAt
+	return u.teamRepo.Update(ctx, team)
+}
+
+func (u *organizationUsecase) DeleteTeam(ctx context.Context, id string) error {
+	if _, err := u.GetTeam(ctx, id); err != nil {
+		return err
+	}
+	return u.teamRepo.Delete(ctx, id)
+}
+
+func (u *organizationUsecase) SetPlacement(ctx context.Context, actorID string, placement domain.Placement) (*domain.User, error) {
+	user, err := u.userRepo.GetByID(ctx, placement.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	// A manager sees the attendance of their reports, so only users who
+	// manage the account may place it
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return nil, err
+	}
+
+	departmentID := placement.DepartmentID
+	if placement.TeamID != "" {
+		team, err := u.GetTeam(ctx, placement.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if departmentID == "" {
+			departmentID = team.DepartmentID
+		}
+		if departmentID != team.DepartmentID {
+			return nil, domain.ErrTeamNotInDepartment
+		}
+	}
+	if departmentID != "" {
+		if _, err := u.GetDepartment(ctx, departmentID); err != nil {
+			return nil, err
+		}
+	}
+	if placement.ManagerID != "" {
+		if err := u.checkManager(ctx, user.ID, placement.ManagerID); err != nil {
+			return nil, err
+		}
+	}
+
+	user.DepartmentID = departmentID
+	user.TeamID = placement.TeamID
+	user.ManagerID = placement.ManagerID
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkManager rejects managers that do not exist and managers that would
+// make the hierarchy circular
+func (u *organizationUsecase) checkManager(ctx context.Context, userID, managerID string) error {
+	if managerID == userID {
+		return domain.ErrManagerCycle
+	}
+	manager, err := u.userRepo.GetByID(ctx, managerID)
+	if err != nil {
+		return err
+	}
+	if manager == nil {
+		return domain.ErrManagerNotFound
+	}
+
+	reports, err := u.userRepo.GetReports(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		if report.ID == managerID {
+			return domain.ErrManagerCycle
+		}
+	}
+	return nil
+}
+
+func (u *organizationUsecase) GetReports(ctx context.Context, managerID string) ([]domain.User, error) {
+	reports, err := u.userRepo.GetReports(ctx, managerID)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		reports = []domain.User{}
+	}
+	return reports, nil
+}
+
+// resolveScope returns the users whose records the caller may see. Callers
+// whose role grants allPermission see everyone's, other callers their own
+// and those of their direct and indirect reports.
+func resolveScope(ctx context.Context, userRepo domain.UserRepository, roleRepo domain.RoleRepository, callerID, allPermission string) (domain.Scope, error) {
+	caller, err := userRepo.GetByID(ctx, callerID)
+	if err != nil {
+		return domain.Scope{}, err
+	}
+	if caller == nil {
+		return domain.Scope{}, domain.ErrUserNotFound
+	}
+
+	role, err := loadRole(ctx, roleRepo, caller.Role)
+	if err != nil {
+		return domain.Scope{}, err
+	}
+	if role != nil && role.HasPermission(allPermission) {
+		return domain.Scope{All: true}, nil
+	}
+
+	reports, err := userRepo.GetReports(ctx, callerID)
+	if err != nil {
+		return domain.Scope{}, err
+	}
+	scope := domain.Scope{UserIDs: []string{callerID}}
+	for _, report := range reports {
+		scope.UserIDs = append(scope.UserIDs, report.ID)
+	}
+	return scope, nil
+}
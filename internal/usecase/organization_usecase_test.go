@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockDepartmentRepository is a mock type for domain.DepartmentRepository
+type MockDepartmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockDepartmentRepository) Create(ctx context.Context, department *domain.Department) error {
+	args := m.Called(ctx, department)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentRepository) GetByID(ctx context.Context, id string) (*domain.Department, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Department), args.Error(1)
+}
+
+func (m *MockDepartmentRepository) GetAll(ctx context.Context) ([]domain.Department, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Department), args.Error(1)
+}
+
+func (m *MockDepartmentRepository) Update(ctx context.Context, department *domain.Department) error {
+	args := m.Called(ctx, department)
+	return args.Error(0)
+}
+
+func (m *MockDepartmentRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockTeamRepository is a mock type for domain.TeamRepository
+type MockTeamRepository struct {
+	mock.Mock
+}
+
+func (m *MockTeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetByID(ctx context.Context, id string) (*domain.Team, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) GetAll(ctx context.Context, departmentID string) ([]domain.Team, error) {
+	args := m.Called(ctx, departmentID)
+	return args.Get(0).([]domain.Team), args.Error(1)
+}
+
+func (m *MockTeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	args := m.Called(ctx, team)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type organizationMocks struct {
+	departmentRepo *MockDepartmentRepository
+	teamRepo       *MockTeamRepository
+	userRepo       *MockUserRepository
+}
+
+func newOrganizationMocks() *organizationMocks {
+	return &organizationMocks{
+		departmentRepo: new(MockDepartmentRepository),
+		teamRepo:       new(MockTeamRepository),
+		userRepo:       new(MockUserRepository),
+	}
+}
+
+func (m *organizationMocks) usecase() domain.OrganizationUsecase {
+	return NewOrganizationUsecase(m.departmentRepo, m.teamRepo, m.userRepo, newBuiltInRoleRepository())
+}
+
+func TestOrganizationUsecase_DeleteDepartment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		m := newOrganizationMocks()
+		m.departmentRepo.On("GetByID", ctx, "dept-id").Return(&domain.Department{ID: "dept-id"}, nil)
+		m.teamRepo.On("GetAll", ctx, "dept-id").Return([]domain.Team{}, nil)
+		m.departmentRepo.On("Delete", ctx, "dept-id").Return(nil)
+
+		err := m.usecase().DeleteDepartment(ctx, "dept-id")
+		assert.NoError(t, err)
+		m.departmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("Has Teams", func(t *testing.T) {
+		m := newOrganizationMocks()
+		m.departmentRepo.On("GetByID", ctx, "dept-id").Return(&domain.Department{ID: "dept-id"}, nil)
+		m.teamRepo.On("GetAll", ctx, "dept-id").Return([]domain.Team{{ID: "team-id"}}, nil)
+
+		err := m.usecase().DeleteDepartment(ctx, "dept-id")
+		assert.ErrorIs(t, err, domain.ErrDepartmentHasTeams)
+		m.departmentRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		m := newOrganizationMocks()
+		m.departmentRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		err := m.usecase().DeleteDepartment(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
+	})
+}
+
+func TestOrganizationUsecase_CreateTeam_DepartmentNotFound(t *testing.T) {
+	ctx := context.Background()
+	m := newOrganizationMocks()
+	m.departmentRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+	err := m.usecase().CreateTeam(ctx, &domain.Team{DepartmentID: "missing", Name: "Platform"})
+	assert.ErrorIs(t, err, domain.ErrDepartmentNotFound)
+	m.teamRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestOrganizationUsecase_UpdateTeam_KeepsDepartment(t *testing.T) {
+	ctx := context.Background()
+	m := newOrganizationMocks()
+	m.teamRepo.On("GetByID", ctx, "team-id").Return(&domain.Team{ID: "team-id", DepartmentID: "dept-id"}, nil)
+	m.teamRepo.On("Update", ctx, mock.MatchedBy(func(team *domain.Team) bool {
+		return team.DepartmentID == "dept-id" && team.Name == "Platform"
+	})).Return(nil)
+
+	err := m.usecase().UpdateTeam(ctx, &domain.Team{ID: "team-id", DepartmentID: "other-id", Name: "Platform"})
+	assert.NoError(t, err)
+	m.teamRepo.AssertExpectations(t)
+}
+
+func TestOrganizationUsecase_SetPlacement(t *testing.T) {
+	ctx := context.Background()
+	employee := &domain.User{ID: "user-id", Role: domain.RoleUser}
+	team := &domain.Team{ID: "team-id", DepartmentID: "dept-id"}
+
+	type testCase struct {
+		name          string
+		placement     domain.Placement
+		mockBehavior  func(m *organizationMocks)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:      "Team Implies Department",
+			placement: domain.Placement{UserID: "user-id", TeamID: "team-id", ManagerID: "manager-id"},
+			mockBehavior: func(m *organizationMocks) {
+				m.teamRepo.On("GetByID", ctx, "team-id").Return(team, nil)
+				m.departmentRepo.On("GetByID", ctx, "dept-id").Return(&domain.Department{ID: "dept-id"}, nil)
+				m.userRepo.On("GetByID", ctx, "manager-id").Return(&domain.User{ID: "manager-id", Role: domain.RoleUser}, nil)
+				m.userRepo.On("GetReports", ctx, "user-id").Return([]domain.User{}, nil)
+				m.userRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+					return u.DepartmentID == "dept-id" && u.TeamID == "team-id" && u.ManagerID == "manager-id"
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "Clear Placement",
+			placement: domain.Placement{UserID: "user-id"},
+			mockBehavior: func(m *organizationMocks) {
+				m.userRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+					return u.DepartmentID == "" && u.TeamID == "" && u.ManagerID == ""
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "Team In Another Department",
+			placement: domain.Placement{UserID: "user-id", DepartmentID: "other-id", TeamID: "team-id"},
+			mockBehavior: func(m *organizationMocks) {
+				m.teamRepo.On("GetByID", ctx, "team-id").Return(team, nil)
+			},
+			expectedError: domain.ErrTeamNotInDepartment,
+		},
+		{
+			name:          "Reports To Themselves",
+			placement:     domain.Placement{UserID: "user-id", ManagerID: "user-id"},
+			mockBehavior:  func(m *organizationMocks) {},
+			expectedError: domain.ErrManagerCycle,
+		},
+		{
+			name:      "Reports To One Of Their Reports",
+			placement: domain.Placement{UserID: "user-id", ManagerID: "report-id"},
+			mockBehavior: func(m *organizationMocks) {
+				m.userRepo.On("GetByID", ctx, "report-id").Return(&domain.User{ID: "report-id"}, nil)
+				m.userRepo.On("GetReports", ctx, "user-id").Return([]domain.User{{ID: "direct-id"}, {ID: "report-id"}}, nil)
+			},
+			expectedError: domain.ErrManagerCycle,
+		},
+		{
+			name:      "Manager Not Found",
+			placement: domain.Placement{UserID: "user-id", ManagerID: "missing"},
+			mockBehavior: func(m *organizationMocks) {
+				m.userRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrManagerNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newOrganizationMocks()
+			target := *employee
+			m.userRepo.On("GetByID", ctx, "user-id").Return(&target, nil)
+			m.userRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+			tc.mockBehavior(m)
+
+			user, err := m.usecase().SetPlacement(ctx, adminCaller.ID, tc.placement)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, user)
+				m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, user)
+			}
+			m.userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrganizationUsecase_SetPlacement_RoleAboveTheActor(t *testing.T) {
+	ctx := context.Background()
+	m := newOrganizationMocks()
+
+	// Making someone an admin's manager would expose the admin's attendance
+	m.userRepo.On("GetByID", ctx, "admin-2").Return(&domain.User{ID: "admin-2", Role: domain.RoleAdmin}, nil)
+	m.userRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+
+	user, err := m.usecase().SetPlacement(ctx, "hr-id", domain.Placement{UserID: "admin-2", ManagerID: "hr-id"})
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	assert.Nil(t, user)
+	m.userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestResolveScope(t *testing.T) {
+	ctx := context.Background()
+
+	type testCase struct {
+		name          string
+		caller        *domain.User
+		reports       []domain.User
+		expectedScope domain.Scope
+	}
+
+	tests := []testCase{
+		{
+			name:          "Admin Sees Everyone",
+			caller:        adminCaller,
+			expectedScope: domain.Scope{All: true},
+		},
+		{
+			name:          "Employee Sees Themselves",
+			caller:        &domain.User{ID: "user-id", Role: domain.RoleUser},
+			reports:       []domain.User{},
+			expectedScope: domain.Scope{UserIDs: []string{"user-id"}},
+		},
+		{
+			name:          "Manager Sees Direct And Indirect Reports",
+			caller:        &domain.User{ID: "manager-id", Role: domain.RoleUser},
+			reports:       []domain.User{{ID: "lead-id"}, {ID: "engineer-id"}},
+			expectedScope: domain.Scope{UserIDs: []string{"manager-id", "lead-id", "engineer-id"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUserRepo := new(MockUserRepository)
+			mockUserRepo.On("GetByID", ctx, tc.caller.ID).Return(tc.caller, nil)
+			if tc.reports != nil {
+				mockUserRepo.On("GetReports", ctx, tc.caller.ID).Return(tc.reports, nil)
+			}
+
+			scope, err := resolveScope(ctx, mockUserRepo, newBuiltInRoleRepository(), tc.caller.ID, domain.PermAttendanceReadAll)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedScope, scope)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
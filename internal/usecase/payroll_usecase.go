@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"golang-tes/internal/domain"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type payrollUsecase struct {
+	exportRepo     domain.PayrollExportRepository
+	attendanceRepo domain.AttendanceRepository
+	leaveRepo      domain.LeaveRepository
+	formatters     map[string]domain.PayrollFormatter
+	columns        []domain.PayrollColumn
+}
+
+// NewPayrollUsecase exports payroll in the given formats, keyed by name, with
+// the configured column mapping
+func NewPayrollUsecase(exportRepo domain.PayrollExportRepository, attendanceRepo domain.AttendanceRepository, leaveRepo domain.LeaveRepository, formatters map[string]domain.PayrollFormatter, columns []domain.PayrollColumn) domain.PayrollUsecase {
+	return &payrollUsecase{
+		exportRepo:     exportRepo,
+		attendanceRepo: attendanceRepo,
+		leaveRepo:      leaveRepo,
+		formatters:     formatters,
+		columns:        columns,
+	}
+}
+
+func (u *payrollUsecase) ExportPayroll(ctx context.Context, request domain.PayrollExportRequest) (*domain.PayrollFile, error) {
+	formatter, ok := u.formatters[request.Format]
+	if !ok {
+		return nil, domain.ErrInvalidPayrollFormat
+	}
+	from, to := request.PeriodStart, request.PeriodEnd
+	if from.IsZero() || to.IsZero() || to.Before(from) || to.Sub(from) >= domain.MaxPayrollPeriodDays*24*time.Hour {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	// Exporting a period twice would pay its hours twice
+	overlapping, err := u.exportRepo.GetOverlapping(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 && !request.Force {
+		return nil, domain.ErrPayrollAlreadyExported
+	}
+
+	lines, err := u.payrollLines(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var content bytes.Buffer
+	if err := formatter.Write(&content, u.columns, lines); err != nil {
+		return nil, err
+	}
+
+	export := domain.PayrollExport{
+		ID:          uuid.New().String(),
+		PeriodStart: from,
+		PeriodEnd:   to,
+		Format:      request.Format,
+		LineCount:   len(lines),
+		Forced:      len(overlapping) > 0,
+		ExportedBy:  request.ExportedBy,
+	}
+	if err := u.exportRepo.Create(ctx, &export); err != nil {
+		return nil, err
+	}
+
+	return &domain.PayrollFile{
+		Export:      export,
+		ContentType: formatter.ContentType(),
+		Filename:    fmt.Sprintf("payroll-%s-%s.%s", from.Format(domain.DateFormat), to.Format(domain.DateFormat), formatter.FileExtension()),
+		Content:     content.Bytes(),
+	}, nil
+}
+
+func (u *payrollUsecase) ListExports(ctx context.Context) ([]domain.PayrollExport, error) {
+	return u.exportRepo.GetAll(ctx)
+}
+
+// payrollLines totals the records in the period per user, ordered by name.
+// Only approved overtime is paid.
+func (u *payrollUsecase) payrollLines(ctx context.Context, from, to time.Time) ([]domain.PayrollLine, error) {
+	lines := make(map[string]*domain.PayrollLine)
+	leaveDays := make(map[string][]time.Time)
+	err := u.attendanceRepo.Export(ctx, domain.AttendanceFilter{From: from, To: to, Sort: domain.AttendanceSortDate}, func(row domain.AttendanceExportRow) error {
+		line, ok := lines[row.UserID]
+		if !ok {
+			line = &domain.PayrollLine{
+				UserID:      row.UserID,
+				Name:        row.UserName,
+				Email:       row.UserEmail,
+				PeriodStart: from,
+				PeriodEnd:   to,
+			}
+			lines[row.UserID] = line
+		}
+		switch row.Status {
+		case domain.StatusPresent, domain.StatusLate:
+			line.DaysWorked++
+		case domain.StatusAbsent:
+			line.AbsentDays++
+		case domain.StatusLeave:
+			leaveDays[row.UserID] = append(leaveDays[row.UserID], row.Date)
+		}
+		line.WorkedMinutes += row.WorkedMinutes
+		if row.OvertimeStatus == domain.OvertimeStatusApproved {
+			line.OvertimeMinutes += row.OvertimeMinutes
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PayrollLine, 0, len(lines))
+	for userID, line := range lines {
+		if days := leaveDays[userID]; len(days) > 0 {
+			if err := u.countLeave(ctx, line, days); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, *line)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].UserID < result[j].UserID
+	})
+	return result, nil
+}
+
+// countLeave sorts the user's leave records by the type of the approved
+// request covering them
+func (u *payrollUsecase) countLeave(ctx context.Context, line *domain.PayrollLine, days []time.Time) error {
+	leaves, err := u.leaveRepo.GetActiveInRange(ctx, line.UserID, line.PeriodStart, line.PeriodEnd)
+	if err != nil {
+		return err
+	}
+	leaveTypes := approvedLeaveTypes(leaves)
+	for _, day := range days {
+		switch leaveTypes[day.Format(domain.DateFormat)] {
+		case domain.LeaveTypeAnnual:
+			line.AnnualLeaveDays++
+		case domain.LeaveTypeSick:
+			line.SickLeaveDays++
+		case domain.LeaveTypeUnpaid:
+			line.UnpaidLeaveDays++
+		default:
+			line.OtherLeaveDays++
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPayrollExportRepository is a mock type for domain.PayrollExportRepository
+type MockPayrollExportRepository struct {
+	mock.Mock
+}
+
+func (m *MockPayrollExportRepository) Create(ctx context.Context, export *domain.PayrollExport) error {
+	args := m.Called(ctx, export)
+	return args.Error(0)
+}
+
+func (m *MockPayrollExportRepository) GetOverlapping(ctx context.Context, from, to time.Time) ([]domain.PayrollExport, error) {
+	args := m.Called(ctx, from, to)
+	return args.Get(0).([]domain.PayrollExport), args.Error(1)
+}
+
+func (m *MockPayrollExportRepository) GetAll(ctx context.Context) ([]domain.PayrollExport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.PayrollExport), args.Error(1)
+}
+
+// recordingFormatter writes the employee ID of every line and keeps the lines
+type recordingFormatter struct {
+	lines []domain.PayrollLine
+}
+
+func (f *recordingFormatter) ContentType() string {
+	return "text/plain"
+}
+
+func (f *recordingFormatter) FileExtension() string {
+	return "txt"
+}
+
+func (f *recordingFormatter) Write(w io.Writer, columns []domain.PayrollColumn, lines []domain.PayrollLine) error {
+	f.lines = lines
+	for i := range lines {
+		if _, err := io.WriteString(w, lines[i].Value(columns[0].Field)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type payrollMocks struct {
+	exportRepo     *MockPayrollExportRepository
+	attendanceRepo *MockAttendanceRepository
+	leaveRepo      *MockLeaveRepository
+	formatter      *recordingFormatter
+}
+
+func newPayrollMocks() *payrollMocks {
+	return &payrollMocks{
+		exportRepo:     new(MockPayrollExportRepository),
+		attendanceRepo: new(MockAttendanceRepository),
+		leaveRepo:      new(MockLeaveRepository),
+		formatter:      &recordingFormatter{},
+	}
+}
+
+func (m *payrollMocks) usecase() domain.PayrollUsecase {
+	return NewPayrollUsecase(m.exportRepo, m.attendanceRepo, m.leaveRepo,
+		map[string]domain.PayrollFormatter{domain.PayrollFormatFixedWidth: m.formatter},
+		[]domain.PayrollColumn{{Field: domain.PayrollFieldEmployeeID}})
+}
+
+func TestPayrollUsecase_ExportPayroll(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return from.AddDate(0, 0, n-1) }
+	alice := func(date time.Time, status string, minutes int) domain.AttendanceExportRow {
+		return domain.AttendanceExportRow{
+			Attendance: domain.Attendance{UserID: "alice-id", Date: date, Status: status, WorkedMinutes: minutes},
+			UserName:   "Alice",
+		}
+	}
+	withOvertime := func(row domain.AttendanceExportRow, minutes int, status string) domain.AttendanceExportRow {
+		row.OvertimeMinutes, row.OvertimeStatus = minutes, status
+		return row
+	}
+
+	m := newPayrollMocks()
+	m.exportRepo.On("GetOverlapping", ctx, from, to).Return([]domain.PayrollExport{}, nil)
+	m.attendanceRepo.On("Export", ctx, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+		return filter.From.Equal(from) && filter.To.Equal(to) && len(filter.UserIDs) == 0
+	}), mock.Anything).Return([]domain.AttendanceExportRow{
+		// Overtime still pending review is not paid
+		{Attendance: domain.Attendance{UserID: "bob-id", Date: day(1), Status: domain.StatusPresent, WorkedMinutes: 600,
+			OvertimeMinutes: 120, OvertimeStatus: domain.OvertimeStatusPending}, UserName: "Bob"},
+		withOvertime(alice(day(1), domain.StatusPresent, 540), 60, domain.OvertimeStatusApproved),
+		withOvertime(alice(day(2), domain.StatusLate, 450), 30, domain.OvertimeStatusRejected),
+		alice(day(3), domain.StatusAbsent, 0),
+		alice(day(4), domain.StatusLeave, 0),
+		alice(day(5), domain.StatusLeave, 0),
+		alice(day(8), domain.StatusLeave, 0),
+	}, nil)
+	m.leaveRepo.On("GetActiveInRange", ctx, "alice-id", from, to).Return([]domain.LeaveRequest{
+		{Type: domain.LeaveTypeAnnual, Status: domain.LeaveStatusApproved, StartDate: day(4), EndDate: day(4)},
+		{Type: domain.LeaveTypeUnpaid, Status: domain.LeaveStatusApproved, StartDate: day(5), EndDate: day(5)},
+		{Type: domain.LeaveTypeSick, Status: domain.LeaveStatusPending, StartDate: day(8), EndDate: day(8)},
+	}, nil)
+	m.exportRepo.On("Create", ctx, mock.MatchedBy(func(export *domain.PayrollExport) bool {
+		return export.LineCount == 2 && !export.Forced && export.Format == domain.PayrollFormatFixedWidth && export.ExportedBy == "admin-id"
+	})).Return(nil)
+
+	file, err := m.usecase().ExportPayroll(ctx, domain.PayrollExportRequest{
+		PeriodStart: from,
+		PeriodEnd:   to,
+		Format:      domain.PayrollFormatFixedWidth,
+		ExportedBy:  "admin-id",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "payroll-2024-07-01-2024-07-31.txt", file.Filename)
+	assert.Equal(t, "alice-id\nbob-id\n", string(file.Content))
+	assert.NotEmpty(t, file.Export.ID)
+
+	assert.Len(t, m.formatter.lines, 2)
+	line := m.formatter.lines[0]
+	assert.Equal(t, "alice-id", line.UserID)
+	assert.Equal(t, 2, line.DaysWorked)
+	assert.Equal(t, 990, line.WorkedMinutes)
+	assert.Equal(t, 60, line.OvertimeMinutes)
+	assert.Equal(t, 1, line.AbsentDays)
+	assert.Equal(t, 1, line.AnnualLeaveDays)
+	assert.Equal(t, 1, line.UnpaidLeaveDays)
+	assert.Equal(t, 1, line.OtherLeaveDays, "leave without an approved request")
+	assert.Equal(t, 2, line.UnpaidAbsenceDays())
+	assert.Equal(t, 2, line.PaidLeaveDays())
+	assert.Equal(t, 0, m.formatter.lines[1].OvertimeMinutes)
+	m.exportRepo.AssertExpectations(t)
+}
+
+func TestPayrollUsecase_ExportPayroll_Rejected(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		request       domain.PayrollExportRequest
+		mockBehavior  func(m *payrollMocks)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:          "Unknown Format",
+			request:       domain.PayrollExportRequest{PeriodStart: from, PeriodEnd: from, Format: "xml"},
+			mockBehavior:  func(m *payrollMocks) {},
+			expectedError: domain.ErrInvalidPayrollFormat,
+		},
+		{
+			name:          "Period Ends Before It Starts",
+			request:       domain.PayrollExportRequest{PeriodStart: from, PeriodEnd: from.AddDate(0, 0, -1), Format: domain.PayrollFormatFixedWidth},
+			mockBehavior:  func(m *payrollMocks) {},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name:          "Period Too Long",
+			request:       domain.PayrollExportRequest{PeriodStart: from, PeriodEnd: from.AddDate(0, 0, domain.MaxPayrollPeriodDays), Format: domain.PayrollFormatFixedWidth},
+			mockBehavior:  func(m *payrollMocks) {},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name:    "Overlaps An Earlier Export",
+			request: domain.PayrollExportRequest{PeriodStart: from, PeriodEnd: from.AddDate(0, 0, 14), Format: domain.PayrollFormatFixedWidth},
+			mockBehavior: func(m *payrollMocks) {
+				m.exportRepo.On("GetOverlapping", ctx, from, from.AddDate(0, 0, 14)).Return([]domain.PayrollExport{{ID: "export-id"}}, nil)
+			},
+			expectedError: domain.ErrPayrollAlreadyExported,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newPayrollMocks()
+			tc.mockBehavior(m)
+
+			file, err := m.usecase().ExportPayroll(ctx, tc.request)
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Nil(t, file)
+			m.attendanceRepo.AssertNotCalled(t, "Export", mock.Anything, mock.Anything, mock.Anything)
+			m.exportRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPayrollUsecase_ExportPayroll_Forced(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	m := newPayrollMocks()
+	m.exportRepo.On("GetOverlapping", ctx, from, to).Return([]domain.PayrollExport{{ID: "export-id"}}, nil)
+	m.attendanceRepo.On("Export", ctx, mock.Anything, mock.Anything).Return([]domain.AttendanceExportRow{}, nil)
+	m.exportRepo.On("Create", ctx, mock.MatchedBy(func(export *domain.PayrollExport) bool {
+		return export.Forced && export.LineCount == 0
+	})).Return(nil)
+
+	file, err := m.usecase().ExportPayroll(ctx, domain.PayrollExportRequest{
+		PeriodStart: from,
+		PeriodEnd:   to,
+		Format:      domain.PayrollFormatFixedWidth,
+		Force:       true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, file.Export.Forced)
+	m.exportRepo.AssertExpectations(t)
+}
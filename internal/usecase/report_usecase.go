@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type reportUsecase struct {
+	reportRepo domain.ReportRepository
+	userRepo   domain.UserRepository
+	teamRepo   domain.TeamRepository
+	roleRepo   domain.RoleRepository
+}
+
+func NewReportUsecase(reportRepo domain.ReportRepository, userRepo domain.UserRepository, teamRepo domain.TeamRepository, roleRepo domain.RoleRepository) domain.ReportUsecase {
+	return &reportUsecase{
+		reportRepo: reportRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		roleRepo:   roleRepo,
+	}
+}
+
+func (u *reportUsecase) GetUserMonthlyReport(ctx context.Context, callerID, userID string, month time.Time, compare bool) (*domain.MonthlyReport, error) {
+	if userID != callerID {
+		scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermReportsRead)
+		if err != nil {
+			return nil, err
+		}
+		if !scope.Includes(userID) {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	report, err := u.monthlyReport(ctx, domain.ReportFilter{UserIDs: []string{userID}}, month, compare)
+	if err != nil {
+		return nil, err
+	}
+	// A user's report is their totals; listing them again adds nothing
+	for r := report; r != nil; r = r.Previous {
+		r.UserID = userID
+		r.Users = nil
+	}
+	return report, nil
+}
+
+func (u *reportUsecase) GetTeamMonthlyReport(ctx context.Context, teamID string, month time.Time, compare bool) (*domain.MonthlyReport, error) {
+	team, err := u.teamRepo.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return u.monthlyReport(ctx, domain.ReportFilter{TeamID: teamID}, month, compare)
+}
+
+func (u *reportUsecase) GetCompanyMonthlyReport(ctx context.Context, month time.Time, compare bool) (*domain.MonthlyReport, error) {
+	return u.monthlyReport(ctx, domain.ReportFilter{}, month, compare)
+}
+
+func (u *reportUsecase) ListMonthlySummaries(ctx context.Context, callerID string, month time.Time) ([]domain.AttendanceSummary, error) {
+	scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermReportsRead)
+	if err != nil {
+		return nil, err
+	}
+	var filter domain.ReportFilter
+	if !scope.All {
+		filter.UserIDs = scope.UserIDs
+	}
+
+	report, err := u.monthlyReport(ctx, filter, month, false)
+	if err != nil {
+		return nil, err
+	}
+	return report.Users, nil
+}
+
+// monthlyReport summarizes the month and, with compare, the month before
+func (u *reportUsecase) monthlyReport(ctx context.Context, filter domain.ReportFilter, month time.Time, compare bool) (*domain.MonthlyReport, error) {
+	start, _ := monthRange(month)
+	report, err := u.summarizeMonth(ctx, filter, start)
+	if err != nil {
+		return nil, err
+	}
+	if compare {
+		if report.Previous, err = u.summarizeMonth(ctx, filter, start.AddDate(0, -1, 0)); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+func (u *reportUsecase) summarizeMonth(ctx context.Context, filter domain.ReportFilter, start time.Time) (*domain.MonthlyReport, error) {
+	filter.From, filter.To = monthRange(start)
+	summaries, err := u.reportRepo.SummarizeByUser(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.MonthlyReport{
+		Month:  start.Format(domain.MonthFormat),
+		TeamID: filter.TeamID,
+		Users:  summaries,
+	}
+	for i := range summaries {
+		summaries[i].ComputeRates()
+		report.Totals.Add(summaries[i])
+	}
+	report.Totals.ComputeRates()
+	return report, nil
+}
+
+// monthRange returns the first and last day of the month, at midnight UTC
+func monthRange(month time.Time) (time.Time, time.Time) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 1, -1)
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportRepository is a mock type for domain.ReportRepository
+type MockReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockReportRepository) SummarizeByUser(ctx context.Context, filter domain.ReportFilter) ([]domain.AttendanceSummary, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.AttendanceSummary), args.Error(1)
+}
+
+func TestAttendanceSummary_ComputeRates(t *testing.T) {
+	summary := domain.AttendanceSummary{DaysPresent: 2, DaysLate: 1, WorkedMinutes: 1450}
+	summary.ComputeRates()
+	assert.Equal(t, 24.17, summary.WorkedHours)
+	assert.Equal(t, 66.7, summary.PunctualityRate)
+
+	// Nobody is punctual or late on days they did not attend
+	absent := domain.AttendanceSummary{DaysAbsent: 3, DaysOnLeave: 2}
+	absent.ComputeRates()
+	assert.Equal(t, 0.0, absent.PunctualityRate)
+}
+
+func TestReportUsecase_GetCompanyMonthlyReport(t *testing.T) {
+	ctx := context.Background()
+	july := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	mockReportRepo := new(MockReportRepository)
+	usecase := NewReportUsecase(mockReportRepo, new(MockUserRepository), new(MockTeamRepository), newBuiltInRoleRepository())
+
+	mockReportRepo.On("SummarizeByUser", ctx, domain.ReportFilter{From: july, To: july.AddDate(0, 0, 30)}).Return([]domain.AttendanceSummary{
+		{UserID: "alice-id", Name: "Alice", DaysPresent: 18, DaysLate: 2, DaysOnLeave: 1, WorkedMinutes: 9600},
+		{UserID: "bob-id", Name: "Bob", DaysPresent: 15, DaysLate: 5, DaysAbsent: 1, WorkedMinutes: 9000},
+	}, nil)
+	mockReportRepo.On("SummarizeByUser", ctx, domain.ReportFilter{From: june, To: june.AddDate(0, 0, 29)}).Return([]domain.AttendanceSummary{
+		{UserID: "alice-id", Name: "Alice", DaysPresent: 20, WorkedMinutes: 9600},
+	}, nil)
+
+	// Any day of the month selects the whole month
+	report, err := usecase.GetCompanyMonthlyReport(ctx, july.AddDate(0, 0, 14), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-07", report.Month)
+	assert.Equal(t, domain.AttendanceSummary{
+		DaysPresent:     33,
+		DaysLate:        7,
+		DaysAbsent:      1,
+		DaysOnLeave:     1,
+		WorkedMinutes:   18600,
+		WorkedHours:     310,
+		PunctualityRate: 82.5,
+	}, report.Totals)
+	assert.Len(t, report.Users, 2)
+	assert.Equal(t, 90.0, report.Users[0].PunctualityRate)
+	assert.Equal(t, 75.0, report.Users[1].PunctualityRate)
+
+	if assert.NotNil(t, report.Previous) {
+		assert.Equal(t, "2024-06", report.Previous.Month)
+		assert.Equal(t, 100.0, report.Previous.Totals.PunctualityRate)
+		assert.Nil(t, report.Previous.Previous)
+	}
+	mockReportRepo.AssertExpectations(t)
+}
+
+func TestReportUsecase_GetTeamMonthlyReport(t *testing.T) {
+	ctx := context.Background()
+	month := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Success", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		usecase := NewReportUsecase(mockReportRepo, new(MockUserRepository), mockTeamRepo, newBuiltInRoleRepository())
+
+		mockTeamRepo.On("GetByID", ctx, "team-id").Return(&domain.Team{ID: "team-id"}, nil)
+		mockReportRepo.On("SummarizeByUser", ctx, domain.ReportFilter{From: month, To: month.AddDate(0, 0, 28), TeamID: "team-id"}).Return([]domain.AttendanceSummary{}, nil)
+
+		report, err := usecase.GetTeamMonthlyReport(ctx, "team-id", month, false)
+		assert.NoError(t, err)
+		assert.Equal(t, "team-id", report.TeamID)
+		assert.Nil(t, report.Previous)
+		mockReportRepo.AssertExpectations(t)
+	})
+
+	t.Run("Team Not Found", func(t *testing.T) {
+		mockReportRepo := new(MockReportRepository)
+		mockTeamRepo := new(MockTeamRepository)
+		usecase := NewReportUsecase(mockReportRepo, new(MockUserRepository), mockTeamRepo, newBuiltInRoleRepository())
+
+		mockTeamRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		report, err := usecase.GetTeamMonthlyReport(ctx, "missing", month, false)
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		assert.Nil(t, report)
+		mockReportRepo.AssertNotCalled(t, "SummarizeByUser", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportUsecase_GetUserMonthlyReport(t *testing.T) {
+	ctx := context.Background()
+	month := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+
+	type testCase struct {
+		name          string
+		caller        *domain.User
+		userID        string
+		mockBehavior  func(mockUserRepo *MockUserRepository)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:   "Own Report",
+			caller: manager,
+			userID: manager.ID,
+			mockBehavior: func(mockUserRepo *MockUserRepository) {
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+			},
+		},
+		{
+			name:   "Report Of The Caller",
+			caller: manager,
+			userID: "engineer-id",
+			mockBehavior: func(mockUserRepo *MockUserRepository) {
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "engineer-id"}}, nil)
+				mockUserRepo.On("GetByID", ctx, "engineer-id").Return(&domain.User{ID: "engineer-id"}, nil)
+			},
+		},
+		{
+			name:   "Outside The Caller's Scope",
+			caller: manager,
+			userID: "colleague-id",
+			mockBehavior: func(mockUserRepo *MockUserRepository) {
+				mockUserRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+				mockUserRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{}, nil)
+			},
+			expectedError: domain.ErrPermissionDenied,
+		},
+		{
+			name:   "Anyone With reports:read",
+			caller: adminCaller,
+			userID: "colleague-id",
+			mockBehavior: func(mockUserRepo *MockUserRepository) {
+				mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+				mockUserRepo.On("GetByID", ctx, "colleague-id").Return(&domain.User{ID: "colleague-id"}, nil)
+			},
+		},
+		{
+			name:   "User Not Found",
+			caller: adminCaller,
+			userID: "missing",
+			mockBehavior: func(mockUserRepo *MockUserRepository) {
+				mockUserRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+				mockUserRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReportRepo := new(MockReportRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewReportUsecase(mockReportRepo, mockUserRepo, new(MockTeamRepository), newBuiltInRoleRepository())
+
+			tc.mockBehavior(mockUserRepo)
+			if tc.expectedError == nil {
+				mockReportRepo.On("SummarizeByUser", ctx, domain.ReportFilter{From: month, To: month.AddDate(0, 0, 30), UserIDs: []string{tc.userID}}).Return([]domain.AttendanceSummary{
+					{UserID: tc.userID, DaysPresent: 20, WorkedMinutes: 9600},
+				}, nil)
+			}
+
+			report, err := usecase.GetUserMonthlyReport(ctx, tc.caller.ID, tc.userID, month, false)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, report)
+				mockReportRepo.AssertNotCalled(t, "SummarizeByUser", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.userID, report.UserID)
+				assert.Equal(t, 160.0, report.Totals.WorkedHours)
+				assert.Empty(t, report.Users)
+			}
+			mockUserRepo.AssertExpectations(t)
+			mockReportRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReportUsecase_DatabaseError(t *testing.T) {
+	ctx := context.Background()
+	mockReportRepo := new(MockReportRepository)
+	usecase := NewReportUsecase(mockReportRepo, new(MockUserRepository), new(MockTeamRepository), newBuiltInRoleRepository())
+
+	mockReportRepo.On("SummarizeByUser", ctx, mock.AnythingOfType("domain.ReportFilter")).Return(nil, domain.ErrDatabase)
+
+	report, err := usecase.GetCompanyMonthlyReport(ctx, time.Now(), true)
+	assert.ErrorIs(t, err, domain.ErrDatabase)
+	assert.Nil(t, report)
+}
+
+func TestReportUsecase_ListMonthlySummaries(t *testing.T) {
+	ctx := context.Background()
+	month := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+
+	type testCase struct {
+		name            string
+		caller          *domain.User
+		reports         []domain.User
+		expectedUserIDs []string
+	}
+
+	tests := []testCase{
+		{
+			name:            "Manager Gets Own And Reports' Summaries",
+			caller:          manager,
+			reports:         []domain.User{{ID: "report-id"}},
+			expectedUserIDs: []string{"manager-id", "report-id"},
+		},
+		{
+			name:            "Anyone With reports:read Gets Everyone's",
+			caller:          adminCaller,
+			expectedUserIDs: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockReportRepo := new(MockReportRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewReportUsecase(mockReportRepo, mockUserRepo, new(MockTeamRepository), newBuiltInRoleRepository())
+
+			mockUserRepo.On("GetByID", ctx, tc.caller.ID).Return(tc.caller, nil)
+			if tc.reports != nil {
+				mockUserRepo.On("GetReports", ctx, tc.caller.ID).Return(tc.reports, nil)
+			}
+			mockReportRepo.On("SummarizeByUser", ctx, domain.ReportFilter{From: month, To: month.AddDate(0, 0, 30), UserIDs: tc.expectedUserIDs}).Return([]domain.AttendanceSummary{
+				{UserID: "report-id", DaysPresent: 3, DaysLate: 1, WorkedMinutes: 1920},
+			}, nil)
+
+			summaries, err := usecase.ListMonthlySummaries(ctx, tc.caller.ID, month)
+			assert.NoError(t, err)
+			if assert.Len(t, summaries, 1) {
+				assert.Equal(t, 32.0, summaries[0].WorkedHours)
+				assert.Equal(t, 75.0, summaries[0].PunctualityRate)
+			}
+			mockReportRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type roleUsecase struct {
+	roleRepo domain.RoleRepository
+	userRepo domain.UserRepository
+	now      func() time.Time
+}
+
+func NewRoleUsecase(roleRepo domain.RoleRepository, userRepo domain.UserRepository) domain.RoleUsecase {
+	return &roleUsecase{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+		now:      time.Now,
+	}
+}
+
+func (u *roleUsecase) ListRoles(ctx context.Context) ([]domain.Role, error) {
+	roles, err := u.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roles {
+		withEffectivePermissions(&roles[i])
+	}
+	return roles, nil
+}
+
+func (u *roleUsecase) GetRole(ctx context.Context, name string) (*domain.Role, error) {
+	role, err := loadRole(ctx, u.roleRepo, name)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return nil, domain.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (u *roleUsecase) CreateRole(ctx context.Context, actorID string, role *domain.Role) error {
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+	if err := checkActorCovers(ctx, u.userRepo, u.roleRepo, actorID, role); err != nil {
+		return err
+	}
+
+	now := u.now()
+	role.System = false
+	role.CreatedAt = now
+	role.UpdatedAt = now
+	return u.roleRepo.Create(ctx, role)
+}
+
+func (u *roleUsecase) UpdateRole(ctx context.Context, actorID string, role *domain.Role) error {
+	existing, err := u.GetRole(ctx, role.Name)
+	if err != nil {
+		return err
+	}
+	// The admin role always holds every permission
+	if existing.Name == domain.RoleAdmin {
+		return domain.ErrSystemRole
+	}
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+
+	updated := *existing
+	updated.Description = role.Description
+	updated.Permissions = permissions
+	updated.UpdatedAt = u.now()
+	if err := checkActorCovers(ctx, u.userRepo, u.roleRepo, actorID, existing, &updated); err != nil {
+		return err
+	}
+	if err := u.roleRepo.Update(ctx, &updated); err != nil {
+		return err
+	}
+	*role = updated
+	return nil
+}
+
+func (u *roleUsecase) DeleteRole(ctx context.Context, name string) error {
+	role, err := u.GetRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.System {
+		return domain.ErrSystemRole
+	}
+	_, users, err := u.userRepo.List(ctx, domain.UserFilter{Role: name, Page: 1, PageSize: 1})
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return domain.ErrRoleInUse
+	}
+	return u.roleRepo.Delete(ctx, name)
+}
+
+func (u *roleUsecase) GetPermissions(ctx context.Context, userID string) ([]string, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	role, err := loadRole(ctx, u.roleRepo, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if role == nil {
+		return []string{}, nil
+	}
+	return role.Permissions, nil
+}
+
+// loadRole returns the role with its effective permissions, or nil if it does not exist
+func loadRole(ctx context.Context, roleRepo domain.RoleRepository, name string) (*domain.Role, error) {
+	role, err := roleRepo.GetByName(ctx, name)
+	if err != nil || role == nil {
+		return nil, err
+	}
+	withEffectivePermissions(role)
+	return role, nil
+}
+
+// withEffectivePermissions lists every permission for the admin role, also
+// those added after its permissions were stored
+func withEffectivePermissions(role *domain.Role) {
+	if role.Name == domain.RoleAdmin {
+		role.Permissions = append([]string{}, domain.AllPermissions...)
+	}
+}
+
+// normalizePermissions checks the permissions and removes duplicates
+func normalizePermissions(permissions []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, permission := range permissions {
+		if !domain.IsValidPermission(permission) {
+			return nil, domain.ErrInvalidPermission
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized, nil
+}
+
+// checkActorCovers returns ErrPermissionDenied unless the actor's role holds
+// every permission of the given roles, so no one can grant or act on more
+// access than they have. An empty actor is the operator using the CLI.
+func checkActorCovers(ctx context.Context, userRepo domain.UserRepository, roleRepo domain.RoleRepository, actorID string, roles ...*domain.Role) error {
+	if actorID == "" {
+		return nil
+	}
+	actor, err := userRepo.GetByID(ctx, actorID)
+	if err != nil {
+		return err
+	}
+	if actor == nil {
+		return domain.ErrPermissionDenied
+	}
+	actorRole, err := loadRole(ctx, roleRepo, actor.Role)
+	if err != nil {
+		return err
+	}
+	if actorRole == nil {
+		return domain.ErrPermissionDenied
+	}
+	for _, role := range roles {
+		if !actorRole.Covers(role) {
+			return domain.ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+// checkActorManages returns ErrPermissionDenied unless the actor's role covers
+// the target user's role
+func checkActorManages(ctx context.Context, userRepo domain.UserRepository, roleRepo domain.RoleRepository, actorID string, target *domain.User) error {
+	role, err := loadRole(ctx, roleRepo, target.Role)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return nil
+	}
+	return checkActorCovers(ctx, userRepo, roleRepo, actorID, role)
+}
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoleRepository is a mock type for domain.RoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) List(ctx context.Context) ([]domain.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Delete(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+var (
+	userRole     = &domain.Role{Name: domain.RoleUser, System: true, Permissions: []string{domain.PermAttendanceReadOwn, domain.PermAttendanceWriteOwn, domain.PermLeavesWriteOwn}}
+	hrRole       = &domain.Role{Name: "hr", Permissions: []string{domain.PermAttendanceReadOwn, domain.PermAttendanceWriteOwn, domain.PermLeavesWriteOwn, domain.PermUsersRead, domain.PermUsersManage, domain.PermLeavesApprove, domain.PermLeavesManage, domain.PermRolesManage}}
+	teamLeadRole = &domain.Role{Name: "team-lead", Permissions: []string{domain.PermLeavesApprove, domain.PermCorrectionsApprove}}
+)
+
+// newBuiltInRoleRepository returns a role repository with the built-in roles
+// and the custom hr and team-lead roles
+func newBuiltInRoleRepository() *MockRoleRepository {
+	m := new(MockRoleRepository)
+	m.On("GetByName", mock.Anything, domain.RoleAdmin).Return(&domain.Role{Name: domain.RoleAdmin, System: true}, nil).Maybe()
+	m.On("GetByName", mock.Anything, domain.RoleUser).Return(userRole, nil).Maybe()
+	m.On("GetByName", mock.Anything, "hr").Return(hrRole, nil).Maybe()
+	m.On("GetByName", mock.Anything, "team-lead").Return(teamLeadRole, nil).Maybe()
+	m.On("GetByName", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	return m
+}
+
+func TestRole_HasPermission(t *testing.T) {
+	admin := &domain.Role{Name: domain.RoleAdmin}
+
+	assert.True(t, admin.HasPermission(domain.PermRolesManage))
+	assert.True(t, userRole.HasPermission(domain.PermAttendanceWriteOwn))
+	assert.False(t, userRole.HasPermission(domain.PermAttendanceReadAll))
+	assert.True(t, hrRole.Covers(userRole))
+	assert.False(t, hrRole.Covers(teamLeadRole))
+	assert.False(t, hrRole.Covers(admin))
+	assert.True(t, admin.Covers(admin))
+}
+
+func TestRoleUsecase_ListRoles(t *testing.T) {
+	mockRoleRepo := new(MockRoleRepository)
+	usecase := NewRoleUsecase(mockRoleRepo, new(MockUserRepository))
+	ctx := context.Background()
+
+	mockRoleRepo.On("List", ctx).Return([]domain.Role{
+		{Name: domain.RoleAdmin, System: true, Permissions: []string{domain.PermUsersRead}},
+		{Name: "team-lead", Permissions: []string{domain.PermLeavesApprove}},
+	}, nil)
+
+	roles, err := usecase.ListRoles(ctx)
+	assert.NoError(t, err)
+	// The admin role lists every permission, also those it was not stored with
+	assert.Equal(t, domain.AllPermissions, roles[0].Permissions)
+	assert.Equal(t, []string{domain.PermLeavesApprove}, roles[1].Permissions)
+}
+
+func TestRoleUsecase_CreateRole(t *testing.T) {
+	tests := []struct {
+		name          string
+		actorRole     string
+		permissions   []string
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:         "Admin Creates Role",
+			actorRole:    domain.RoleAdmin,
+			permissions:  []string{domain.PermLeavesApprove, domain.PermCorrectionsApprove, domain.PermLeavesApprove},
+			expectCreate: true,
+		},
+		{
+			name:         "Subset Of Actor's Permissions",
+			actorRole:    "hr",
+			permissions:  []string{domain.PermUsersRead, domain.PermLeavesApprove},
+			expectCreate: true,
+		},
+		{
+			name:          "Permission The Actor Lacks",
+			actorRole:     "hr",
+			permissions:   []string{domain.PermLeavesApprove, domain.PermAttendanceWriteAll},
+			expectedError: domain.ErrPermissionDenied,
+		},
+		{
+			name:          "Invalid Permission",
+			actorRole:     domain.RoleAdmin,
+			permissions:   []string{"everything"},
+			expectedError: domain.ErrInvalidPermission,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRoleRepo := newBuiltInRoleRepository()
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewRoleUsecase(mockRoleRepo, mockUserRepo)
+			ctx := context.Background()
+
+			mockUserRepo.On("GetByID", ctx, "actor-id").Return(&domain.User{ID: "actor-id", Role: tc.actorRole}, nil)
+			mockRoleRepo.On("Create", ctx, mock.AnythingOfType("*domain.Role")).Return(nil)
+
+			role := &domain.Role{Name: "approver", Permissions: tc.permissions, System: true}
+			err := usecase.CreateRole(ctx, "actor-id", role)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockRoleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, role.System)
+			assert.Len(t, role.Permissions, 2)
+			assert.False(t, role.CreatedAt.IsZero())
+			mockRoleRepo.AssertCalled(t, "Create", ctx, role)
+		})
+	}
+}
+
+func TestRoleUsecase_UpdateRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewRoleUsecase(mockRoleRepo, mockUserRepo)
+
+		mockUserRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+		mockRoleRepo.On("Update", ctx, mock.MatchedBy(func(r *domain.Role) bool {
+			return r.Name == "team-lead" && r.Description == "Leads" && len(r.Permissions) == 1
+		})).Return(nil)
+
+		role := &domain.Role{Name: "team-lead", Description: "Leads", Permissions: []string{domain.PermLeavesApprove}}
+		err := usecase.UpdateRole(ctx, "admin-id", role)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{domain.PermLeavesApprove}, role.Permissions)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("Admin Role", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		usecase := NewRoleUsecase(mockRoleRepo, new(MockUserRepository))
+
+		err := usecase.UpdateRole(ctx, "admin-id", &domain.Role{Name: domain.RoleAdmin})
+		assert.ErrorIs(t, err, domain.ErrSystemRole)
+		mockRoleRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Role Above The Actor", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewRoleUsecase(mockRoleRepo, mockUserRepo)
+
+		// hr cannot strip team-lead of corrections:approve, which hr does not hold
+		mockUserRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+
+		err := usecase.UpdateRole(ctx, "hr-id", &domain.Role{Name: "team-lead", Permissions: []string{domain.PermLeavesApprove}})
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		mockRoleRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		usecase := NewRoleUsecase(newBuiltInRoleRepository(), new(MockUserRepository))
+
+		err := usecase.UpdateRole(ctx, "admin-id", &domain.Role{Name: "missing"})
+		assert.ErrorIs(t, err, domain.ErrRoleNotFound)
+	})
+}
+
+func TestRoleUsecase_DeleteRole(t *testing.T) {
+	ctx := context.Background()
+	filter := domain.UserFilter{Role: "team-lead", Page: 1, PageSize: 1}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewRoleUsecase(mockRoleRepo, mockUserRepo)
+
+		mockUserRepo.On("List", ctx, filter).Return([]domain.User{}, 0, nil)
+		mockRoleRepo.On("Delete", ctx, "team-lead").Return(nil)
+
+		err := usecase.DeleteRole(ctx, "team-lead")
+		assert.NoError(t, err)
+		mockRoleRepo.AssertExpectations(t)
+	})
+
+	t.Run("In Use", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		mockUserRepo := new(MockUserRepository)
+		usecase := NewRoleUsecase(mockRoleRepo, mockUserRepo)
+
+		mockUserRepo.On("List", ctx, filter).Return([]domain.User{{ID: "lead-id"}}, 1, nil)
+
+		err := usecase.DeleteRole(ctx, "team-lead")
+		assert.ErrorIs(t, err, domain.ErrRoleInUse)
+		mockRoleRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Built-in Role", func(t *testing.T) {
+		mockRoleRepo := newBuiltInRoleRepository()
+		usecase := NewRoleUsecase(mockRoleRepo, new(MockUserRepository))
+
+		err := usecase.DeleteRole(ctx, domain.RoleUser)
+		assert.ErrorIs(t, err, domain.ErrSystemRole)
+		mockRoleRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleUsecase_GetPermissions(t *testing.T) {
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewRoleUsecase(newBuiltInRoleRepository(), mockUserRepo)
+	ctx := context.Background()
+
+	mockUserRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+	mockUserRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+
+	permissions, err := usecase.GetPermissions(ctx, "admin-id")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.AllPermissions, permissions)
+
+	permissions, err = usecase.GetPermissions(ctx, "user-id")
+	assert.NoError(t, err)
+	assert.Equal(t, userRole.Permissions, permissions)
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type workScheduleUsecase struct {
+	scheduleRepo domain.WorkScheduleRepository
+	userRepo     domain.UserRepository
+}
+
+func NewWorkScheduleUsecase(scheduleRepo domain.WorkScheduleRepository, userRepo domain.UserRepository) domain.WorkScheduleUsecase {
+	return &workScheduleUsecase{
+		scheduleRepo: scheduleRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (u *workScheduleUsecase) CreateSchedule(ctx context.Context, schedule *domain.WorkSchedule) error {
+	schedule.ID = uuid.New().String()
+	return u.scheduleRepo.Create(ctx, schedule)
+}
+
+func (u *workScheduleUsecase) GetSchedule(ctx context.Context, id string) (*domain.WorkSchedule, error) {
+	schedule, err := u.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, domain.ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+func (u *workScheduleUsecase) ListSchedules(ctx context.Context) ([]domain.WorkSchedule, error) {
+	return u.scheduleRepo.GetAll(ctx)
+}
+
+func (u *workScheduleUsecase) UpdateSchedule(ctx context.Context, schedule *domain.WorkSchedule) error {
+	existing, err := u.scheduleRepo.GetByID(ctx, schedule.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrScheduleNotFound
+	}
+
+	schedule.CreatedAt = existing.CreatedAt
+	return u.scheduleRepo.Update(ctx, schedule)
+}
+
+func (u *workScheduleUsecase) DeleteSchedule(ctx context.Context, id string) error {
+	existing, err := u.scheduleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return domain.ErrScheduleNotFound
+	}
+	return u.scheduleRepo.Delete(ctx, id)
+}
+
+func (u *workScheduleUsecase) AssignUsers(ctx context.Context, scheduleID string, userIDs []string) error {
+	schedule, err := u.scheduleRepo.GetByID(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return domain.ErrScheduleNotFound
+	}
+
+	// Validate every user before assigning anyone
+	for _, userID := range userIDs {
+		user, err := u.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return domain.ErrUserNotFound
+		}
+	}
+
+	for _, userID := range userIDs {
+		if err := u.scheduleRepo.AssignUser(ctx, scheduleID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *workScheduleUsecase) UnassignUser(ctx context.Context, userID string) error {
+	return u.scheduleRepo.UnassignUser(ctx, userID)
+}
+
+func (u *workScheduleUsecase) GetUserSchedule(ctx context.Context, userID string) (*domain.WorkSchedule, error) {
+	schedule, err := u.scheduleRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, domain.ErrScheduleNotFound
+	}
+	return schedule, nil
+}
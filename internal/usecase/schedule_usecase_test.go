@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWorkScheduleRepository is a mock type for domain.WorkScheduleRepository
+type MockWorkScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockWorkScheduleRepository) Create(ctx context.Context, schedule *domain.WorkSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockWorkScheduleRepository) GetByID(ctx context.Context, id string) (*domain.WorkSchedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkSchedule), args.Error(1)
+}
+
+func (m *MockWorkScheduleRepository) GetAll(ctx context.Context) ([]domain.WorkSchedule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.WorkSchedule), args.Error(1)
+}
+
+func (m *MockWorkScheduleRepository) GetByUserID(ctx context.Context, userID string) (*domain.WorkSchedule, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WorkSchedule), args.Error(1)
+}
+
+func (m *MockWorkScheduleRepository) Update(ctx context.Context, schedule *domain.WorkSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockWorkScheduleRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWorkScheduleRepository) AssignUser(ctx context.Context, scheduleID, userID string) error {
+	args := m.Called(ctx, scheduleID, userID)
+	return args.Error(0)
+}
+
+func (m *MockWorkScheduleRepository) UnassignUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestWorkScheduleUsecase_CreateSchedule(t *testing.T) {
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewWorkScheduleUsecase(mockScheduleRepo, mockUserRepo)
+	ctx := context.Background()
+
+	schedule := &domain.WorkSchedule{
+		Name:      "Office",
+		StartTime: "09:00",
+		EndTime:   "17:00",
+		WorkDays:  []int{1, 2, 3, 4, 5},
+	}
+
+	mockScheduleRepo.On("Create", ctx, schedule).Return(nil)
+
+	err := usecase.CreateSchedule(ctx, schedule)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, schedule.ID)
+	mockScheduleRepo.AssertExpectations(t)
+}
+
+func TestWorkScheduleUsecase_UpdateSchedule(t *testing.T) {
+	type testCase struct {
+		name          string
+		schedule      *domain.WorkSchedule
+		mockBehavior  func(mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, schedule *domain.WorkSchedule)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:     "Success",
+			schedule: &domain.WorkSchedule{ID: "schedule-id", Name: "Office"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, schedule *domain.WorkSchedule) {
+				mockScheduleRepo.On("GetByID", ctx, schedule.ID).Return(&domain.WorkSchedule{ID: schedule.ID}, nil)
+				mockScheduleRepo.On("Update", ctx, schedule).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "Schedule Not Found",
+			schedule: &domain.WorkSchedule{ID: "non-existent-id"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, schedule *domain.WorkSchedule) {
+				mockScheduleRepo.On("GetByID", ctx, schedule.ID).Return(nil, nil)
+			},
+			expectedError: domain.ErrScheduleNotFound,
+		},
+		{
+			name:     "Database Error",
+			schedule: &domain.WorkSchedule{ID: "schedule-id"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, ctx context.Context, schedule *domain.WorkSchedule) {
+				mockScheduleRepo.On("GetByID", ctx, schedule.ID).Return(nil, domain.ErrDatabase)
+			},
+			expectedError: domain.ErrDatabase,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewWorkScheduleUsecase(mockScheduleRepo, mockUserRepo)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockScheduleRepo, ctx, tc.schedule)
+
+			err := usecase.UpdateSchedule(ctx, tc.schedule)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockScheduleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkScheduleUsecase_AssignUsers(t *testing.T) {
+	type testCase struct {
+		name          string
+		scheduleID    string
+		userIDs       []string
+		mockBehavior  func(mockScheduleRepo *MockWorkScheduleRepository, mockUserRepo *MockUserRepository, ctx context.Context)
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name:       "Success",
+			scheduleID: "schedule-id",
+			userIDs:    []string{"user1", "user2"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockScheduleRepo.On("GetByID", ctx, "schedule-id").Return(&domain.WorkSchedule{ID: "schedule-id"}, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				mockUserRepo.On("GetByID", ctx, "user2").Return(&domain.User{ID: "user2"}, nil)
+				mockScheduleRepo.On("AssignUser", ctx, "schedule-id", "user1").Return(nil)
+				mockScheduleRepo.On("AssignUser", ctx, "schedule-id", "user2").Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "Schedule Not Found",
+			scheduleID: "non-existent-id",
+			userIDs:    []string{"user1"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockScheduleRepo.On("GetByID", ctx, "non-existent-id").Return(nil, nil)
+			},
+			expectedError: domain.ErrScheduleNotFound,
+		},
+		{
+			name:       "User Not Found Assigns Nobody",
+			scheduleID: "schedule-id",
+			userIDs:    []string{"user1", "missing"},
+			mockBehavior: func(mockScheduleRepo *MockWorkScheduleRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockScheduleRepo.On("GetByID", ctx, "schedule-id").Return(&domain.WorkSchedule{ID: "schedule-id"}, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				mockUserRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockScheduleRepo := new(MockWorkScheduleRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewWorkScheduleUsecase(mockScheduleRepo, mockUserRepo)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockScheduleRepo, mockUserRepo, ctx)
+
+			err := usecase.AssignUsers(ctx, tc.scheduleID, tc.userIDs)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockScheduleRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWorkScheduleUsecase_GetUserSchedule(t *testing.T) {
+	mockScheduleRepo := new(MockWorkScheduleRepository)
+	mockUserRepo := new(MockUserRepository)
+	usecase := NewWorkScheduleUsecase(mockScheduleRepo, mockUserRepo)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		expected := &domain.WorkSchedule{ID: "schedule-id"}
+		mockScheduleRepo.On("GetByUserID", ctx, "user1").Return(expected, nil)
+
+		schedule, err := usecase.GetUserSchedule(ctx, "user1")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, schedule)
+	})
+
+	t.Run("No Schedule", func(t *testing.T) {
+		mockScheduleRepo.On("GetByUserID", ctx, "user2").Return(nil, nil)
+
+		schedule, err := usecase.GetUserSchedule(ctx, "user2")
+		assert.ErrorIs(t, err, domain.ErrScheduleNotFound)
+		assert.Nil(t, schedule)
+	})
+
+	mockScheduleRepo.AssertExpectations(t)
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type shiftUsecase struct {
+	shiftRepo domain.ShiftRepository
+	userRepo  domain.UserRepository
+}
+
+func NewShiftUsecase(shiftRepo domain.ShiftRepository, userRepo domain.UserRepository) domain.ShiftUsecase {
+	return &shiftUsecase{
+		shiftRepo: shiftRepo,
+		userRepo:  userRepo,
+	}
+}
+
+func (u *shiftUsecase) CreateShift(ctx context.Context, shift *domain.Shift) error {
+	shift.ID = uuid.New().String()
+	return u.shiftRepo.Create(ctx, shift)
+}
+
+func (u *shiftUsecase) GetShift(ctx context.Context, id string) (*domain.Shift, error) {
+	shift, err := u.shiftRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, domain.ErrShiftNotFound
+	}
+	return shift, nil
+}
+
+func (u *shiftUsecase) ListShifts(ctx context.Context) ([]domain.Shift, error) {
+	return u.shiftRepo.GetAll(ctx)
+}
+
+func (u *shiftUsecase) UpdateShift(ctx context.Context, shift *domain.Shift) error {
+	existing, err := u.GetShift(ctx, shift.ID)
+	if err != nil {
+		return err
+	}
+
+	shift.CreatedAt = existing.CreatedAt
+	return u.shiftRepo.Update(ctx, shift)
+}
+
+// DeleteShift deletes a shift nobody has been rostered on. Rostered shifts
+// are kept so the attendance of past shifts can still be evaluated.
+func (u *shiftUsecase) DeleteShift(ctx context.Context, id string) error {
+	if _, err := u.GetShift(ctx, id); err != nil {
+		return err
+	}
+	rostered, err := u.shiftRepo.IsRostered(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rostered {
+		return domain.ErrShiftInUse
+	}
+	return u.shiftRepo.Delete(ctx, id)
+}
+
+func (u *shiftUsecase) RosterUsers(ctx context.Context, req domain.RosterRequest) ([]domain.RosterEntry, error) {
+	from := req.From.Truncate(24 * time.Hour)
+	to := req.To.Truncate(24 * time.Hour)
+	if err := validateRosterRange(from, to); err != nil {
+		return nil, err
+	}
+	workDays := map[time.Weekday]bool{}
+	for _, day := range req.WorkDays {
+		if day < int(time.Sunday) || day > int(time.Saturday) {
+			return nil, domain.ErrInvalidInput
+		}
+		workDays[time.Weekday(day)] = true
+	}
+
+	shift, err := u.GetShift(ctx, req.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate every user before rostering anyone
+	userIDs := []string{}
+	seen := map[string]bool{}
+	for _, userID := range req.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		user, err := u.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, domain.ErrUserNotFound
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	entries := []domain.RosterEntry{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if len(workDays) > 0 && !workDays[day.Weekday()] {
+			continue
+		}
+		for _, userID := range userIDs {
+			entries = append(entries, domain.RosterEntry{
+				ID:      uuid.New().String(),
+				UserID:  userID,
+				ShiftID: shift.ID,
+				Date:    day,
+				Shift:   shift,
+			})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, domain.ErrNothingToRoster
+	}
+
+	if err := u.shiftRepo.CreateRosterEntries(ctx, entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (u *shiftUsecase) GetRoster(ctx context.Context, from, to time.Time, userID string) ([]domain.RosterEntry, error) {
+	from = from.Truncate(24 * time.Hour)
+	to = to.Truncate(24 * time.Hour)
+	if err := validateRosterRange(from, to); err != nil {
+		return nil, err
+	}
+
+	var userIDs []string
+	if userID != "" {
+		userIDs = []string{userID}
+	}
+	return u.shiftRepo.GetRoster(ctx, from, to, userIDs)
+}
+
+func (u *shiftUsecase) DeleteRosterEntry(ctx context.Context, id string) error {
+	entry, err := u.shiftRepo.GetRosterEntry(ctx, id)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return domain.ErrRosterEntryNotFound
+	}
+	return u.shiftRepo.DeleteRosterEntry(ctx, id)
+}
+
+func validateRosterRange(from, to time.Time) error {
+	if to.Before(from) || to.Sub(from) >= domain.MaxRosterDays*24*time.Hour {
+		return domain.ErrInvalidDateRange
+	}
+	return nil
+}
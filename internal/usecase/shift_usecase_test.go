@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockShiftRepository is a mock type for domain.ShiftRepository
+type MockShiftRepository struct {
+	mock.Mock
+}
+
+func (m *MockShiftRepository) Create(ctx context.Context, shift *domain.Shift) error {
+	args := m.Called(ctx, shift)
+	return args.Error(0)
+}
+
+func (m *MockShiftRepository) GetByID(ctx context.Context, id string) (*domain.Shift, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Shift), args.Error(1)
+}
+
+func (m *MockShiftRepository) GetAll(ctx context.Context) ([]domain.Shift, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Shift), args.Error(1)
+}
+
+func (m *MockShiftRepository) Update(ctx context.Context, shift *domain.Shift) error {
+	args := m.Called(ctx, shift)
+	return args.Error(0)
+}
+
+func (m *MockShiftRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockShiftRepository) IsRostered(ctx context.Context, shiftID string) (bool, error) {
+	args := m.Called(ctx, shiftID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShiftRepository) CreateRosterEntries(ctx context.Context, entries []domain.RosterEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *MockShiftRepository) GetRosterEntry(ctx context.Context, id string) (*domain.RosterEntry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RosterEntry), args.Error(1)
+}
+
+func (m *MockShiftRepository) GetRoster(ctx context.Context, from, to time.Time, userIDs []string) ([]domain.RosterEntry, error) {
+	args := m.Called(ctx, from, to, userIDs)
+	return args.Get(0).([]domain.RosterEntry), args.Error(1)
+}
+
+func (m *MockShiftRepository) DeleteRosterEntry(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// newEmptyShiftRepository returns a shift repository in which nobody is rostered
+func newEmptyShiftRepository() *MockShiftRepository {
+	m := new(MockShiftRepository)
+	m.On("GetRoster", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.RosterEntry{}, nil).Maybe()
+	return m
+}
+
+// nightShift runs from 22:00 to 06:00 the next day
+var nightShift = &domain.Shift{ID: "night", Name: "Night", StartTime: "22:00", EndTime: "06:00", GracePeriodMinutes: 10}
+
+func TestShift_Window(t *testing.T) {
+	day := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	dayShift := &domain.Shift{StartTime: "09:00", EndTime: "17:00"}
+
+	end, err := nightShift.EndAt(day)
+	assert.NoError(t, err)
+	assert.True(t, nightShift.Overnight())
+	assert.Equal(t, time.Date(2024, 7, 2, 6, 0, 0, 0, time.UTC), end)
+
+	end, err = dayShift.EndAt(day)
+	assert.NoError(t, err)
+	assert.False(t, dayShift.Overnight())
+	assert.Equal(t, time.Date(2024, 7, 1, 17, 0, 0, 0, time.UTC), end)
+
+	entry := &domain.RosterEntry{Date: day, Shift: nightShift}
+	for _, tc := range []struct {
+		at     time.Time
+		covers bool
+	}{
+		{time.Date(2024, 7, 1, 19, 59, 0, 0, time.UTC), false},
+		{time.Date(2024, 7, 1, 20, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, 7, 2, 5, 59, 0, 0, time.UTC), true},
+		{time.Date(2024, 7, 2, 6, 0, 0, 0, time.UTC), false},
+	} {
+		covers, err := entry.Covers(tc.at)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.covers, covers, tc.at.String())
+	}
+}
+
+func TestShiftUsecase_RosterUsers(t *testing.T) {
+	monday := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		name          string
+		req           domain.RosterRequest
+		mockBehavior  func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context)
+		expectedDates []string
+		expectedError error
+	}
+
+	tests := []testCase{
+		{
+			name: "Success On Weekdays",
+			req: domain.RosterRequest{
+				ShiftID:  "night",
+				UserIDs:  []string{"user1", "user1"},
+				From:     monday,
+				To:       monday.AddDate(0, 0, 6),
+				WorkDays: []int{1, 3, 5},
+			},
+			mockBehavior: func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockShiftRepo.On("GetByID", ctx, "night").Return(nightShift, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil).Once()
+				mockShiftRepo.On("CreateRosterEntries", ctx, mock.Anything).Return(nil)
+			},
+			expectedDates: []string{"2024-07-01", "2024-07-03", "2024-07-05"},
+		},
+		{
+			name: "Range Too Long",
+			req: domain.RosterRequest{
+				ShiftID: "night",
+				UserIDs: []string{"user1"},
+				From:    monday,
+				To:      monday.AddDate(0, 0, domain.MaxRosterDays),
+			},
+			mockBehavior:  func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {},
+			expectedError: domain.ErrInvalidDateRange,
+		},
+		{
+			name: "Invalid Weekday",
+			req: domain.RosterRequest{
+				ShiftID:  "night",
+				UserIDs:  []string{"user1"},
+				From:     monday,
+				To:       monday,
+				WorkDays: []int{7},
+			},
+			mockBehavior:  func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {},
+			expectedError: domain.ErrInvalidInput,
+		},
+		{
+			name: "No Date On The Weekdays",
+			req: domain.RosterRequest{
+				ShiftID:  "night",
+				UserIDs:  []string{"user1"},
+				From:     monday,
+				To:       monday,
+				WorkDays: []int{0},
+			},
+			mockBehavior: func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockShiftRepo.On("GetByID", ctx, "night").Return(nightShift, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+			},
+			expectedError: domain.ErrNothingToRoster,
+		},
+		{
+			name: "Shift Not Found",
+			req:  domain.RosterRequest{ShiftID: "missing", UserIDs: []string{"user1"}, From: monday, To: monday},
+			mockBehavior: func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockShiftRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrShiftNotFound,
+		},
+		{
+			name: "User Not Found Rosters Nobody",
+			req:  domain.RosterRequest{ShiftID: "night", UserIDs: []string{"user1", "missing"}, From: monday, To: monday},
+			mockBehavior: func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockShiftRepo.On("GetByID", ctx, "night").Return(nightShift, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				mockUserRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+			},
+			expectedError: domain.ErrUserNotFound,
+		},
+		{
+			name: "Already Rostered",
+			req:  domain.RosterRequest{ShiftID: "night", UserIDs: []string{"user1"}, From: monday, To: monday},
+			mockBehavior: func(mockShiftRepo *MockShiftRepository, mockUserRepo *MockUserRepository, ctx context.Context) {
+				mockShiftRepo.On("GetByID", ctx, "night").Return(nightShift, nil)
+				mockUserRepo.On("GetByID", ctx, "user1").Return(&domain.User{ID: "user1"}, nil)
+				mockShiftRepo.On("CreateRosterEntries", ctx, mock.Anything).Return(domain.ErrRosterConflict)
+			},
+			expectedError: domain.ErrRosterConflict,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftRepo := new(MockShiftRepository)
+			mockUserRepo := new(MockUserRepository)
+			usecase := NewShiftUsecase(mockShiftRepo, mockUserRepo)
+			ctx := context.Background()
+
+			tc.mockBehavior(mockShiftRepo, mockUserRepo, ctx)
+
+			entries, err := usecase.RosterUsers(ctx, tc.req)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				var dates []string
+				for _, entry := range entries {
+					assert.Equal(t, "user1", entry.UserID)
+					assert.Equal(t, nightShift, entry.Shift)
+					dates = append(dates, entry.Date.Format(domain.DateFormat))
+				}
+				assert.Equal(t, tc.expectedDates, dates)
+			}
+			mockShiftRepo.AssertExpectations(t)
+			mockUserRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShiftUsecase_DeleteShift(t *testing.T) {
+	tests := []struct {
+		name          string
+		rostered      bool
+		expectedError error
+	}{
+		{name: "Success", rostered: false},
+		{name: "Rostered Shift Is Kept", rostered: true, expectedError: domain.ErrShiftInUse},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftRepo := new(MockShiftRepository)
+			usecase := NewShiftUsecase(mockShiftRepo, new(MockUserRepository))
+			ctx := context.Background()
+
+			mockShiftRepo.On("GetByID", ctx, "night").Return(nightShift, nil)
+			mockShiftRepo.On("IsRostered", ctx, "night").Return(tc.rostered, nil)
+			if !tc.rostered {
+				mockShiftRepo.On("Delete", ctx, "night").Return(nil)
+			}
+
+			err := usecase.DeleteShift(ctx, "night")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockShiftRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockShiftRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"time"
+)
+
+type timesheetUsecase struct {
+	attendanceRepo domain.AttendanceRepository
+	userRepo       domain.UserRepository
+	teamRepo       domain.TeamRepository
+	holidayRepo    domain.HolidayRepository
+	leaveRepo      domain.LeaveRepository
+	roleRepo       domain.RoleRepository
+}
+
+func NewTimesheetUsecase(attendanceRepo domain.AttendanceRepository, userRepo domain.UserRepository, teamRepo domain.TeamRepository, holidayRepo domain.HolidayRepository, leaveRepo domain.LeaveRepository, roleRepo domain.RoleRepository) domain.TimesheetUsecase {
+	return &timesheetUsecase{
+		attendanceRepo: attendanceRepo,
+		userRepo:       userRepo,
+		teamRepo:       teamRepo,
+		holidayRepo:    holidayRepo,
+		leaveRepo:      leaveRepo,
+		roleRepo:       roleRepo,
+	}
+}
+
+func (u *timesheetUsecase) GetTimesheet(ctx context.Context, callerID, userID string, month time.Time) (*domain.Timesheet, error) {
+	if userID != callerID {
+		scope, err := resolveScope(ctx, u.userRepo, u.roleRepo, callerID, domain.PermReportsRead)
+		if err != nil {
+			return nil, err
+		}
+		if !scope.Includes(userID) {
+			return nil, domain.ErrPermissionDenied
+		}
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	from, to := monthRange(month)
+	holidays, err := u.holidayRepo.GetInRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return u.buildTimesheet(ctx, user, from, to, holidays)
+}
+
+func (u *timesheetUsecase) EachTimesheet(ctx context.Context, teamID string, month time.Time, fn func(timesheet *domain.Timesheet) error) error {
+	if teamID != "" {
+		team, err := u.teamRepo.GetByID(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return domain.ErrTeamNotFound
+		}
+	}
+
+	users, err := u.userRepo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	from, to := monthRange(month)
+	holidays, err := u.holidayRepo.GetInRange(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	for i := range users {
+		user := &users[i]
+		if teamID != "" && user.TeamID != teamID {
+			continue
+		}
+		timesheet, err := u.buildTimesheet(ctx, user, from, to, holidays)
+		if err != nil {
+			return err
+		}
+		if !user.IsActive() && !timesheet.HasRecords() {
+			continue
+		}
+		if err := fn(timesheet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTimesheet lists every day from from to to with the user's record and
+// the holiday or approved leave covering it
+func (u *timesheetUsecase) buildTimesheet(ctx context.Context, user *domain.User, from, to time.Time, holidays []domain.Holiday) (*domain.Timesheet, error) {
+	// A month has at most 31 days, so a single page holds all its records
+	attendances, _, err := u.attendanceRepo.List(ctx, domain.AttendanceFilter{
+		UserIDs:  []string{user.ID},
+		From:     from,
+		To:       to,
+		Sort:     domain.AttendanceSortDate,
+		Page:     1,
+		PageSize: 31,
+	})
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := u.leaveRepo.GetActiveInRange(ctx, user.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make(map[string]*domain.Attendance, len(attendances))
+	for i := range attendances {
+		records[attendances[i].Date.Format(domain.DateFormat)] = &attendances[i]
+	}
+	holidayNames := make(map[string]string)
+	for _, holiday := range holidays {
+		if holiday.ObservedAt(user.Location) {
+			holidayNames[holiday.Date.Format(domain.DateFormat)] = holiday.Name
+		}
+	}
+	leaveTypes := approvedLeaveTypes(leaves)
+
+	timesheet := &domain.Timesheet{User: *user, Month: from.Format(domain.MonthFormat)}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		key := day.Format(domain.DateFormat)
+		entry := domain.TimesheetDay{
+			Date:       day,
+			Attendance: records[key],
+			Holiday:    holidayNames[key],
+			LeaveType:  leaveTypes[key],
+		}
+		if entry.Attendance != nil {
+			timesheet.Totals.Add(summarizeAttendance(entry.Attendance))
+		}
+		timesheet.Days = append(timesheet.Days, entry)
+	}
+	timesheet.Totals.ComputeRates()
+	return timesheet, nil
+}
+
+// approvedLeaveTypes maps the days, in DateFormat, covered by approved leave
+// requests to the type of their leave
+func approvedLeaveTypes(leaves []domain.LeaveRequest) map[string]string {
+	leaveTypes := make(map[string]string)
+	for _, leave := range leaves {
+		if leave.Status != domain.LeaveStatusApproved {
+			continue
+		}
+		for day := leave.StartDate; !day.After(leave.EndDate); day = day.AddDate(0, 0, 1) {
+			leaveTypes[day.Format(domain.DateFormat)] = leave.Type
+		}
+	}
+	return leaveTypes
+}
+
+// summarizeAttendance counts a single record towards a summary
+func summarizeAttendance(attendance *domain.Attendance) domain.AttendanceSummary {
+	summary := domain.AttendanceSummary{
+		WorkedMinutes:   attendance.WorkedMinutes,
+		OvertimeMinutes: attendance.OvertimeMinutes,
+	}
+	if attendance.OvertimeStatus == domain.OvertimeStatusApproved {
+		summary.ApprovedOvertimeMinutes = attendance.OvertimeMinutes
+	}
+	switch attendance.Status {
+	case domain.StatusPresent:
+		summary.DaysPresent = 1
+	case domain.StatusLate:
+		summary.DaysLate = 1
+	case domain.StatusAbsent:
+		summary.DaysAbsent = 1
+	case domain.StatusLeave:
+		summary.DaysOnLeave = 1
+	}
+	return summary
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"golang-tes/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type timesheetMocks struct {
+	attendanceRepo *MockAttendanceRepository
+	userRepo       *MockUserRepository
+	teamRepo       *MockTeamRepository
+	holidayRepo    *MockHolidayRepository
+	leaveRepo      *MockLeaveRepository
+}
+
+func newTimesheetMocks() *timesheetMocks {
+	return &timesheetMocks{
+		attendanceRepo: new(MockAttendanceRepository),
+		userRepo:       new(MockUserRepository),
+		teamRepo:       new(MockTeamRepository),
+		holidayRepo:    new(MockHolidayRepository),
+		leaveRepo:      new(MockLeaveRepository),
+	}
+}
+
+func (m *timesheetMocks) usecase() domain.TimesheetUsecase {
+	return NewTimesheetUsecase(m.attendanceRepo, m.userRepo, m.teamRepo, m.holidayRepo, m.leaveRepo, newBuiltInRoleRepository())
+}
+
+// expectMonth stubs the records and approved leave of the user in the month
+func (m *timesheetMocks) expectMonth(userID string, records []domain.Attendance, leaves []domain.LeaveRequest) {
+	m.attendanceRepo.On("List", mock.Anything, mock.MatchedBy(func(filter domain.AttendanceFilter) bool {
+		return len(filter.UserIDs) == 1 && filter.UserIDs[0] == userID
+	})).Return(records, len(records), nil)
+	m.leaveRepo.On("GetActiveInRange", mock.Anything, userID, mock.Anything, mock.Anything).Return(leaves, nil)
+}
+
+func TestTimesheetUsecase_GetTimesheet(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: "user-id", Name: "Alice", Location: "Berlin", Role: domain.RoleUser}
+
+	m := newTimesheetMocks()
+	m.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	m.holidayRepo.On("GetInRange", ctx, from, to).Return([]domain.Holiday{
+		{Date: from.AddDate(0, 0, 2), Name: "Company Day"},
+		{Date: from.AddDate(0, 0, 3), Name: "Munich Day", Location: "Munich"},
+	}, nil)
+	m.expectMonth(user.ID, []domain.Attendance{
+		{UserID: user.ID, Date: from, Status: domain.StatusPresent, WorkedMinutes: 480},
+		{UserID: user.ID, Date: from.AddDate(0, 0, 1), Status: domain.StatusLate, WorkedMinutes: 450},
+	}, []domain.LeaveRequest{
+		{Type: domain.LeaveTypeAnnual, Status: domain.LeaveStatusApproved, StartDate: from.AddDate(0, 0, 9), EndDate: from.AddDate(0, 0, 10)},
+		{Type: domain.LeaveTypeSick, Status: domain.LeaveStatusPending, StartDate: from.AddDate(0, 0, 20), EndDate: from.AddDate(0, 0, 20)},
+	})
+
+	// Any day of the month selects the whole month
+	timesheet, err := m.usecase().GetTimesheet(ctx, user.ID, user.ID, from.AddDate(0, 0, 14))
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-07", timesheet.Month)
+	assert.Len(t, timesheet.Days, 31)
+	assert.Equal(t, domain.StatusLate, timesheet.Days[1].Attendance.Status)
+	assert.Nil(t, timesheet.Days[2].Attendance)
+	assert.Equal(t, "Company Day", timesheet.Days[2].Holiday)
+	assert.Empty(t, timesheet.Days[3].Holiday, "holidays of other locations are not observed")
+	assert.Equal(t, domain.LeaveTypeAnnual, timesheet.Days[9].LeaveType)
+	assert.Equal(t, domain.LeaveTypeAnnual, timesheet.Days[10].LeaveType)
+	assert.Empty(t, timesheet.Days[20].LeaveType, "pending leave is not annotated")
+	assert.Equal(t, 1, timesheet.Totals.DaysPresent)
+	assert.Equal(t, 1, timesheet.Totals.DaysLate)
+	assert.Equal(t, 15.5, timesheet.Totals.WorkedHours)
+	assert.Equal(t, 50.0, timesheet.Totals.PunctualityRate)
+}
+
+func TestTimesheetUsecase_GetTimesheet_Scope(t *testing.T) {
+	ctx := context.Background()
+	manager := &domain.User{ID: "manager-id", Role: domain.RoleUser}
+
+	t.Run("Outside The Caller's Scope", func(t *testing.T) {
+		m := newTimesheetMocks()
+		m.userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+		m.userRepo.On("GetReports", ctx, manager.ID).Return([]domain.User{{ID: "report-id"}}, nil)
+
+		timesheet, err := m.usecase().GetTimesheet(ctx, manager.ID, "colleague-id", time.Now())
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		assert.Nil(t, timesheet)
+		m.attendanceRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		m := newTimesheetMocks()
+		m.userRepo.On("GetByID", ctx, adminCaller.ID).Return(adminCaller, nil)
+		m.userRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		timesheet, err := m.usecase().GetTimesheet(ctx, adminCaller.ID, "missing", time.Now())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		assert.Nil(t, timesheet)
+	})
+}
+
+func TestTimesheetUsecase_EachTimesheet(t *testing.T) {
+	ctx := context.Background()
+	month := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	deactivatedAt := month.AddDate(0, -1, 0)
+
+	newMocks := func() *timesheetMocks {
+		m := newTimesheetMocks()
+		m.holidayRepo = newEmptyHolidayRepository()
+		m.userRepo.On("GetAll", ctx).Return([]domain.User{
+			{ID: "alice-id", TeamID: "team-id"},
+			{ID: "bob-id", TeamID: "other-team-id"},
+			{ID: "carol-id", TeamID: "team-id", DeactivatedAt: &deactivatedAt},
+			{ID: "dave-id", DeactivatedAt: &deactivatedAt},
+		}, nil)
+		m.expectMonth("alice-id", []domain.Attendance{}, []domain.LeaveRequest{})
+		m.expectMonth("bob-id", []domain.Attendance{}, []domain.LeaveRequest{})
+		m.expectMonth("carol-id", []domain.Attendance{}, []domain.LeaveRequest{})
+		m.expectMonth("dave-id", []domain.Attendance{{UserID: "dave-id", Date: month, Status: domain.StatusPresent}}, []domain.LeaveRequest{})
+		return m
+	}
+
+	type testCase struct {
+		name          string
+		teamID        string
+		expectedUsers []string
+	}
+
+	tests := []testCase{
+		{
+			name:          "Team Members",
+			teamID:        "team-id",
+			expectedUsers: []string{"alice-id"},
+		},
+		{
+			name:          "Every User With Deactivated Users Only If They Have Records",
+			expectedUsers: []string{"alice-id", "bob-id", "dave-id"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newMocks()
+			if tc.teamID != "" {
+				m.teamRepo.On("GetByID", ctx, tc.teamID).Return(&domain.Team{ID: tc.teamID}, nil)
+			}
+
+			var users []string
+			err := m.usecase().EachTimesheet(ctx, tc.teamID, month, func(timesheet *domain.Timesheet) error {
+				users = append(users, timesheet.User.ID)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUsers, users)
+		})
+	}
+
+	t.Run("Team Not Found", func(t *testing.T) {
+		m := newTimesheetMocks()
+		m.teamRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		err := m.usecase().EachTimesheet(ctx, "missing", month, func(timesheet *domain.Timesheet) error {
+			t.Fatal("no timesheets expected")
+			return nil
+		})
+		assert.ErrorIs(t, err, domain.ErrTeamNotFound)
+		m.userRepo.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+
+	t.Run("Write Error Stops The Export", func(t *testing.T) {
+		m := newMocks()
+
+		calls := 0
+		err := m.usecase().EachTimesheet(ctx, "", month, func(timesheet *domain.Timesheet) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+}
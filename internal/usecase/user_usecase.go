@@ -2,27 +2,133 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"golang-tes/internal/domain"
+	"golang-tes/internal/utils/logger"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Purposes of the signed tokens that are not access tokens
+const (
+	verificationPurpose = "email_verification"
+	mfaChallengePurpose = "mfa_challenge"
+)
+
+// AuthConfig holds the token settings of the user usecase
+type AuthConfig struct {
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	ResetTokenTTL   time.Duration
+	VerifyTokenTTL  time.Duration
+	// MFATokenTTL limits how long a login may wait for its MFA code
+	MFATokenTTL time.Duration
+	// AppURL is the base URL of the frontend that emailed links point to
+	AppURL string
+	// Lockout limits password and MFA code guessing
+	Lockout LockoutPolicy
+}
+
+// LockoutPolicy limits failed logins per account and per client IP. A limit
+// of zero disables tracking for that scope.
+type LockoutPolicy struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	// BaseLockout is the lock on reaching a limit. It doubles with every
+	// further failure up to MaxLockout.
+	BaseLockout time.Duration
+	MaxLockout  time.Duration
+	// Window is how long failures are remembered
+	Window time.Duration
+}
+
+// lockoutFor returns how long to lock after the given number of failures at
+// or over the limit
+func (p LockoutPolicy) lockoutFor(excess int) time.Duration {
+	lockout := p.BaseLockout
+	for i := 1; i < excess && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+	return lockout
+}
+
 type userUsecase struct {
-	userRepo  domain.UserRepository
-	jwtSecret string
+	userRepo         domain.UserRepository
+	tokenRepo        domain.TokenRepository
+	mfaRepo          domain.MFARepository
+	loginAttemptRepo domain.LoginAttemptRepository
+	roleRepo         domain.RoleRepository
+	mailer           domain.Mailer
+	config           AuthConfig
+	now              func() time.Time
 }
 
-func NewUserUsecase(userRepo domain.UserRepository, jwtSecret string) domain.UserUsecase {
+func NewUserUsecase(userRepo domain.UserRepository, tokenRepo domain.TokenRepository, mfaRepo domain.MFARepository, loginAttemptRepo domain.LoginAttemptRepository, roleRepo domain.RoleRepository, mailer domain.Mailer, config AuthConfig) domain.UserUsecase {
 	return &userUsecase{
-		userRepo:  userRepo,
-		jwtSecret: jwtSecret,
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		mfaRepo:          mfaRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		roleRepo:         roleRepo,
+		mailer:           mailer,
+		config:           config,
+		now:              time.Now,
 	}
 }
 
 func (u *userUsecase) Register(ctx context.Context, user *domain.User) error {
+	// Admins are only created by other admins or the bootstrap
+	user.Role = domain.RoleUser
+	return u.create(ctx, user)
+}
+
+func (u *userUsecase) CreateUser(ctx context.Context, actorID string, user *domain.User) error {
+	role, err := loadRole(ctx, u.roleRepo, user.Role)
+	if err != nil {
+		return err
+	}
+	if role == nil {
+		return domain.ErrInvalidRole
+	}
+	if err := checkActorCovers(ctx, u.userRepo, u.roleRepo, actorID, role); err != nil {
+		return err
+	}
+	return u.create(ctx, user)
+}
+
+func (u *userUsecase) BootstrapAdmin(ctx context.Context, user *domain.User) (bool, error) {
+	_, admins, err := u.userRepo.List(ctx, domain.UserFilter{Role: domain.RoleAdmin, Page: 1, PageSize: 1})
+	if err != nil {
+		return false, err
+	}
+	if admins > 0 {
+		return false, nil
+	}
+
+	// The bootstrap email comes from the operator's configuration
+	verifiedAt := u.now()
+	user.Role = domain.RoleAdmin
+	user.EmailVerifiedAt = &verifiedAt
+	if err := u.create(ctx, user); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (u *userUsecase) create(ctx context.Context, user *domain.User) error {
 	// Check if email already exists
 	existingUser, err := u.userRepo.GetByEmail(ctx, user.Email)
 	if err != nil {
@@ -41,42 +147,559 @@ func (u *userUsecase) Register(ctx context.Context, user *domain.User) error {
 	// Set user ID and hashed password
 	user.ID = uuid.New().String()
 	user.Password = string(hashedPassword)
-	if user.Role == "" {
-		user.Role = domain.RoleUser
+
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		u.sendVerification(ctx, user, user.Email)
+	}
+	return nil
+}
+
+func (u *userUsecase) Login(ctx context.Context, email, password, ip string) (*domain.LoginResult, error) {
+	keys := u.loginKeys(email, ip)
+	if err := u.checkLockout(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	// Check password
+	if user == nil || bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+		if err := u.recordLoginFailure(ctx, keys); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrInvalidCredentials
+	}
+	if !user.IsActive() {
+		return nil, domain.ErrUserInactive
+	}
+
+	mfa, err := u.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		// Failures are only cleared once the MFA code is correct too, so
+		// knowing the password does not allow unlimited code guesses
+		token, err := u.signPurposeToken(mfaChallengePurpose, jwt.MapClaims{"user_id": user.ID}, u.config.MFATokenTTL)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{MFARequired: true, MFAToken: token}, nil
+	}
+
+	required, err := mfaRequiredForRole(ctx, u.mfaRepo, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.clearLoginFailures(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	// Every login starts a new refresh token family
+	pair, err := u.issueTokens(ctx, user, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.LoginResult{TokenPair: pair, MFAEnrollmentRequired: required}, nil
+}
+
+func (u *userUsecase) VerifyMFA(ctx context.Context, mfaToken, code string) (*domain.TokenPair, error) {
+	claims, err := u.parsePurposeToken(mfaChallengePurpose, mfaToken)
+	if err != nil {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	userID, _ := claims["user_id"].(string)
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive() {
+		return nil, domain.ErrInvalidMFAToken
+	}
+	mfa, err := u.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.IsEnabled() {
+		// Reset by an admin after the challenge was issued
+		return nil, domain.ErrInvalidMFAToken
+	}
+
+	keys := u.loginKeys(user.Email, "")
+	if err := u.checkLockout(ctx, keys); err != nil {
+		return nil, err
+	}
+	if err := checkMFACode(ctx, u.mfaRepo, mfa, code, u.now(), true); err != nil {
+		if err == domain.ErrInvalidMFACode {
+			if err := u.recordLoginFailure(ctx, keys); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+	if err := u.clearLoginFailures(ctx, user.Email); err != nil {
+		return nil, err
+	}
+	return u.issueTokens(ctx, user, uuid.New().String())
+}
+
+// loginKey is a scope and key under which failed logins are counted
+type loginKey struct {
+	scope string
+	key   string
+	limit int
+}
+
+// loginKeys returns the keys a login for the email from the IP counts
+// towards. The IP is left out where it is not known.
+func (u *userUsecase) loginKeys(email, ip string) []loginKey {
+	policy := u.config.Lockout
+	keys := []loginKey{}
+	if policy.MaxAccountFailures > 0 {
+		keys = append(keys, loginKey{domain.LoginScopeAccount, normalizeEmail(email), policy.MaxAccountFailures})
+	}
+	if policy.MaxIPFailures > 0 && ip != "" {
+		keys = append(keys, loginKey{domain.LoginScopeIP, ip, policy.MaxIPFailures})
+	}
+	return keys
+}
+
+// checkLockout returns a *domain.LockoutError while any of the keys is locked
+func (u *userUsecase) checkLockout(ctx context.Context, keys []loginKey) error {
+	now := u.now()
+	var retryAfter time.Duration
+	for _, k := range keys {
+		throttle, err := u.loginAttemptRepo.Get(ctx, k.scope, k.key)
+		if err != nil {
+			return err
+		}
+		if throttle == nil || throttle.LockedUntil == nil || !throttle.LockedUntil.After(now) {
+			continue
+		}
+		logger.Warn("Login attempt while locked",
+			zap.String("scope", k.scope),
+			zap.String("key", k.key),
+			zap.Time("locked_until", *throttle.LockedUntil),
+		)
+		if wait := throttle.LockedUntil.Sub(now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	if retryAfter > 0 {
+		return &domain.LockoutError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login for each key and locks the keys
+// that reached their limit, for longer with every further failure
+func (u *userUsecase) recordLoginFailure(ctx context.Context, keys []loginKey) error {
+	now := u.now()
+	policy := u.config.Lockout
+	for _, k := range keys {
+		failures, err := u.loginAttemptRepo.RecordFailure(ctx, k.scope, k.key, now, now.Add(-policy.Window))
+		if err != nil {
+			return err
+		}
+		if failures < k.limit {
+			continue
+		}
+
+		lockout := policy.lockoutFor(failures - k.limit + 1)
+		if err := u.loginAttemptRepo.Lock(ctx, k.scope, k.key, now.Add(lockout)); err != nil {
+			return err
+		}
+		logger.Warn("Login locked after repeated failures",
+			zap.String("scope", k.scope),
+			zap.String("key", k.key),
+			zap.Int("failures", failures),
+			zap.Duration("lockout", lockout),
+		)
+	}
+	return nil
+}
+
+// clearLoginFailures resets the account's failures after a successful login.
+// Failures of the IP are kept, as one valid account must not reset the
+// count of guesses made against others. Stale entries are removed on the way.
+func (u *userUsecase) clearLoginFailures(ctx context.Context, email string) error {
+	if err := u.loginAttemptRepo.Reset(ctx, domain.LoginScopeAccount, normalizeEmail(email)); err != nil {
+		return err
+	}
+	return u.loginAttemptRepo.DeleteStale(ctx, u.now().Add(-u.config.Lockout.Window))
+}
+
+// normalizeEmail returns the key failed logins for an email are counted under
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func (u *userUsecase) Refresh(ctx context.Context, refreshToken string) (*domain.TokenPair, error) {
+	token, err := u.tokenRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	now := u.now()
+	if token == nil || !token.ExpiresAt.After(now) {
+		return nil, domain.ErrInvalidToken
+	}
+	if token.RevokedAt != nil {
+		return nil, u.revokeReusedFamily(ctx, token, now)
+	}
+
+	user, err := u.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive() {
+		return nil, domain.ErrInvalidToken
+	}
+
+	pair, next, err := u.newTokenPair(user, token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := u.tokenRepo.RevokeRefreshToken(ctx, token.ID, next.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		// Rotated concurrently by another request with the same token
+		return nil, u.revokeReusedFamily(ctx, token, now)
+	}
+	if err := u.tokenRepo.CreateRefreshToken(ctx, next); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+func (u *userUsecase) Logout(ctx context.Context, jti string, accessExpiresAt time.Time, refreshToken string) error {
+	if err := u.tokenRepo.RevokeAccessToken(ctx, jti, accessExpiresAt); err != nil {
+		return err
+	}
+
+	if refreshToken != "" {
+		token, err := u.tokenRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if token != nil {
+			if err := u.tokenRepo.RevokeFamily(ctx, token.FamilyID, u.now()); err != nil {
+				return err
+			}
+		}
 	}
 
-	return u.userRepo.Create(ctx, user)
+	// Logouts are infrequent enough to piggyback the denylist cleanup on
+	return u.tokenRepo.DeleteExpired(ctx, u.now())
 }
 
-func (u *userUsecase) Login(ctx context.Context, email, password string) (string, error) {
+func (u *userUsecase) RequestPasswordReset(ctx context.Context, email string) error {
 	user, err := u.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return "", err
+		return err
+	}
+	// Respond the same way for unknown and deactivated accounts so the
+	// endpoint cannot be used to find out which emails are registered
+	if user == nil || !user.IsActive() {
+		return nil
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	if err := u.tokenRepo.InvalidateOneTimeTokens(ctx, user.ID, domain.TokenPurposePasswordReset, now); err != nil {
+		return err
+	}
+	err = u.tokenRepo.CreateOneTimeToken(ctx, &domain.OneTimeToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		Purpose:   domain.TokenPurposePasswordReset,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(u.config.ResetTokenTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = u.mailer.Send(ctx, domain.Email{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Use the link below to choose a new password. It expires in %s and can be used once.\n\n"+
+			"%s/reset-password?token=%s\n\n"+
+			"If you did not ask to reset your password, you can ignore this email.\n",
+			user.Name, u.config.ResetTokenTTL, strings.TrimRight(u.config.AppURL, "/"), token),
+	})
+	if err != nil {
+		// Only registered emails get this far, so a failure must not reach
+		// the response. The user can ask for another link.
+		logger.Error("Failed to send password reset email",
+			zap.Error(err),
+			zap.String("user_id", user.ID))
+	}
+	return nil
+}
+
+func (u *userUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	reset, err := u.tokenRepo.GetOneTimeToken(ctx, domain.TokenPurposePasswordReset, hashToken(token))
+	if err != nil {
+		return err
+	}
+	now := u.now()
+	if reset == nil || reset.UsedAt != nil || !reset.ExpiresAt.After(now) {
+		return domain.ErrInvalidReset
+	}
+
+	user, err := u.userRepo.GetByID(ctx, reset.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive() {
+		return domain.ErrInvalidReset
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	unused, err := u.tokenRepo.UseOneTimeToken(ctx, reset.ID, now)
+	if err != nil {
+		return err
+	}
+	if !unused {
+		// Used concurrently by another request
+		return domain.ErrInvalidReset
+	}
+
+	// Truncated to match the second precision of the iat claim
+	changedAt := now.Truncate(time.Second)
+	user.Password = string(hashedPassword)
+	user.PasswordChangedAt = &changedAt
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	return u.tokenRepo.RevokeUserRefreshTokens(ctx, user.ID, now)
+}
+
+func (u *userUsecase) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	claims, err := u.parsePurposeToken(verificationPurpose, token)
+	if err != nil {
+		return nil, domain.ErrInvalidVerification
+	}
+	userID, _ := claims["user_id"].(string)
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return nil, domain.ErrInvalidVerification
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 	if user == nil {
-		return "", domain.ErrInvalidCredentials
+		return nil, domain.ErrInvalidVerification
 	}
 
-	// Check password
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	now := u.now()
+	switch {
+	case user.PendingEmail != "" && strings.EqualFold(user.PendingEmail, email):
+		// The address may have been registered since the change was requested
+		existingUser, err := u.userRepo.GetByEmail(ctx, user.PendingEmail)
+		if err != nil {
+			return nil, err
+		}
+		if existingUser != nil && existingUser.ID != user.ID {
+			return nil, domain.ErrEmailExists
+		}
+		user.Email = user.PendingEmail
+		user.PendingEmail = ""
+	case strings.EqualFold(user.Email, email):
+		if user.IsEmailVerified() {
+			return user, nil
+		}
+	default:
+		// Links for an address the user has since moved away from
+		return nil, domain.ErrInvalidVerification
+	}
+
+	user.EmailVerifiedAt = &now
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userUsecase) ResendVerification(ctx context.Context, id string) error {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+
+	switch {
+	case user.PendingEmail != "":
+		return u.mailVerification(ctx, user, user.PendingEmail)
+	case !user.IsEmailVerified():
+		return u.mailVerification(ctx, user, user.Email)
+	default:
+		return domain.ErrAlreadyVerified
+	}
+}
+
+// sendVerification emails a verification link without failing the caller.
+// The change it belongs to is already saved and the user can ask for a new link.
+func (u *userUsecase) sendVerification(ctx context.Context, user *domain.User, email string) {
+	if err := u.mailVerification(ctx, user, email); err != nil {
+		logger.Warn("Failed to send verification email",
+			zap.Error(err),
+			zap.String("user_id", user.ID))
+	}
+}
+
+func (u *userUsecase) mailVerification(ctx context.Context, user *domain.User, email string) error {
+	// Nothing is stored: the token is bound to the address, so it stops
+	// working once the user's email or pending email changes
+	token, err := u.signPurposeToken(verificationPurpose, jwt.MapClaims{"user_id": user.ID, "email": email}, u.config.VerifyTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, domain.Email{
+		To:      email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm that %s is your email address by opening the link below. It expires in %s.\n\n"+
+			"%s/verify-email?token=%s\n\n"+
+			"If you did not sign up or change your email, you can ignore this email.\n",
+			user.Name, email, u.config.VerifyTokenTTL, strings.TrimRight(u.config.AppURL, "/"), token),
+	})
+}
+
+// signPurposeToken signs a short-lived token for one purpose, such as email
+// verification. Each purpose has its own key derived from the JWT secret, so
+// these tokens are never accepted as access tokens or for another purpose.
+func (u *userUsecase) signPurposeToken(purpose string, claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	now := u.now()
+	claims["purpose"] = purpose
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(u.purposeKey(purpose))
+}
+
+// parsePurposeToken verifies a token from signPurposeToken and returns its
+// claims. The user_id claim is always present.
+func (u *userUsecase) parsePurposeToken(purpose, tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return u.purposeKey(purpose), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(u.now),
+	)
 	if err != nil {
-		return "", domain.ErrInvalidCredentials
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+	tokenPurpose, _ := claims["purpose"].(string)
+	userID, _ := claims["user_id"].(string)
+	if tokenPurpose != purpose || userID == "" {
+		return nil, domain.ErrUnauthorized
 	}
+	return claims, nil
+}
 
-	// Generate JWT token
+func (u *userUsecase) purposeKey(purpose string) []byte {
+	sum := sha256.Sum256([]byte(purpose + ":" + u.config.JWTSecret))
+	return sum[:]
+}
+
+// revokeReusedFamily handles a rotated refresh token being presented again.
+// Either the token leaked or the client misbehaves, so the whole family is revoked.
+func (u *userUsecase) revokeReusedFamily(ctx context.Context, token *domain.RefreshToken, now time.Time) error {
+	if err := u.tokenRepo.RevokeFamily(ctx, token.FamilyID, now); err != nil {
+		return err
+	}
+	return domain.ErrTokenReused
+}
+
+// issueTokens signs an access token and stores a new refresh token in the family
+func (u *userUsecase) issueTokens(ctx context.Context, user *domain.User, familyID string) (*domain.TokenPair, error) {
+	pair, refreshToken, err := u.newTokenPair(user, familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.tokenRepo.CreateRefreshToken(ctx, refreshToken); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+func (u *userUsecase) newTokenPair(user *domain.User, familyID string) (*domain.TokenPair, *domain.RefreshToken, error) {
+	now := u.now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti":     uuid.New().String(),
 		"user_id": user.ID,
 		"email":   user.Email,
 		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Token expires in 24 hours
+		"iat":     now.Unix(),
+		"exp":     now.Add(u.config.AccessTokenTTL).Unix(),
 	})
+	accessToken, err := token.SignedString([]byte(u.config.JWTSecret))
+	if err != nil {
+		return nil, nil, err
+	}
 
-	tokenString, err := token.SignedString([]byte(u.jwtSecret))
+	refreshToken, err := randomToken()
 	if err != nil {
+		return nil, nil, err
+	}
+
+	pair := &domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(u.config.AccessTokenTTL.Seconds()),
+	}
+	record := &domain.RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(u.config.RefreshTokenTTL),
+	}
+	return pair, record, nil
+}
+
+// randomToken returns 256 random bits, URL-safe encoded
+func randomToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
 		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
 
-	return tokenString, nil
+// hashToken returns the hex SHA-256 of a token. Tokens are random, so a fast
+// hash is enough and allows lookup by hash.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func (u *userUsecase) GetProfile(ctx context.Context, id string) (*domain.User, error) {
@@ -100,15 +723,197 @@ func (u *userUsecase) UpdateProfile(ctx context.Context, user *domain.User) erro
 	}
 
 	// If password is provided, hash it
-	if user.Password != "" {
+	passwordChanged := user.Password != ""
+	if passwordChanged {
 		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 		if err != nil {
 			return err
 		}
 		user.Password = string(hashedPassword)
+		changedAt := u.now().Truncate(time.Second)
+		user.PasswordChangedAt = &changedAt
 	} else {
 		user.Password = existingUser.Password
+		user.PasswordChangedAt = existingUser.PasswordChangedAt
+	}
+
+	// Fields left empty keep their current value
+	if user.Name == "" {
+		user.Name = existingUser.Name
+	}
+
+	// A new email only replaces the current one once it is verified
+	newEmail := ""
+	user.PendingEmail = existingUser.PendingEmail
+	if user.Email != "" && !strings.EqualFold(user.Email, existingUser.Email) {
+		emailOwner, err := u.userRepo.GetByEmail(ctx, user.Email)
+		if err != nil {
+			return err
+		}
+		if emailOwner != nil {
+			return domain.ErrEmailExists
+		}
+		newEmail = user.Email
+		user.PendingEmail = newEmail
+	}
+	user.Email = existingUser.Email
+	user.EmailVerifiedAt = existingUser.EmailVerifiedAt
+
+	// Role, status, location and placement are managed by admins
+	user.Role = existingUser.Role
+	user.DeactivatedAt = existingUser.DeactivatedAt
+	user.Location = existingUser.Location
+	user.DepartmentID = existingUser.DepartmentID
+	user.TeamID = existingUser.TeamID
+	user.ManagerID = existingUser.ManagerID
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+	if newEmail != "" {
+		u.sendVerification(ctx, user, newEmail)
+	}
+
+	// A new password ends every session. Access tokens issued before the change
+	// are rejected by the auth middleware through PasswordChangedAt.
+	if passwordChanged {
+		return u.tokenRepo.RevokeUserRefreshTokens(ctx, user.ID, u.now())
+	}
+	return nil
+}
+
+func (u *userUsecase) SetLocation(ctx context.Context, actorID, id, location string) (*domain.User, error) {
+	if actorID == id {
+		return nil, domain.ErrSelfManagement
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return nil, err
+	}
+
+	user.Location = location
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (u *userUsecase) ListUsers(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	if filter.Role != "" {
+		role, err := u.roleRepo.GetByName(ctx, filter.Role)
+		if err != nil {
+			return nil, 0, err
+		}
+		if role == nil {
+			return nil, 0, domain.ErrInvalidRole
+		}
+	}
+	filter.Normalize()
+	return u.userRepo.List(ctx, filter)
+}
+
+func (u *userUsecase) UpdateRole(ctx context.Context, actorID, id, role string) (*domain.User, error) {
+	newRole, err := loadRole(ctx, u.roleRepo, role)
+	if err != nil {
+		return nil, err
+	}
+	if newRole == nil {
+		return nil, domain.ErrInvalidRole
+	}
+	if actorID == id {
+		return nil, domain.ErrSelfManagement
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return nil, err
+	}
+	if err := checkActorCovers(ctx, u.userRepo, u.roleRepo, actorID, newRole); err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetActive deactivates or reactivates an account. Deactivated users cannot
+// log in and their existing tokens are rejected.
+func (u *userUsecase) SetActive(ctx context.Context, actorID, id string, active bool) (*domain.User, error) {
+	if actorID == id {
+		return nil, domain.ErrSelfManagement
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
 	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return nil, err
+	}
+	if user.IsActive() == active {
+		return user, nil
+	}
+
+	if active {
+		user.DeactivatedAt = nil
+	} else {
+		now := u.now()
+		user.DeactivatedAt = &now
+	}
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
 
-	return u.userRepo.Update(ctx, user)
+func (u *userUsecase) DeleteUser(ctx context.Context, actorID, id string) error {
+	if actorID == id {
+		return domain.ErrSelfManagement
+	}
+
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+	if err := checkActorManages(ctx, u.userRepo, u.roleRepo, actorID, user); err != nil {
+		return err
+	}
+	return u.userRepo.Delete(ctx, id)
+}
+
+func (u *userUsecase) Unlock(ctx context.Context, id string) error {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrUserNotFound
+	}
+	if err := u.loginAttemptRepo.Reset(ctx, domain.LoginScopeAccount, normalizeEmail(user.Email)); err != nil {
+		return err
+	}
+	logger.Info("Login lock cleared", zap.String("user_id", user.ID))
+	return nil
 }
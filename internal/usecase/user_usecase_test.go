@@ -3,8 +3,11 @@ package usecase
 import (
 	"context"
 	"golang-tes/internal/domain"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -36,11 +39,165 @@ func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.Us
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetAll(ctx context.Context) ([]domain.User, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
 func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
 	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) List(ctx context.Context, filter domain.UserFilter) ([]domain.User, int, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]domain.User), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetReports(ctx context.Context, managerID string) ([]domain.User, error) {
+	args := m.Called(ctx, managerID)
+	return args.Get(0).([]domain.User), args.Error(1)
+}
+
+// MockTokenRepository is a mock type for domain.TokenRepository
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RefreshToken), args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeRefreshToken(ctx context.Context, id, replacedBy string, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, replacedBy, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeFamily(ctx context.Context, familyID string, at time.Time) error {
+	args := m.Called(ctx, familyID, at)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userID string, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	args := m.Called(ctx, jti, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) CreateOneTimeToken(ctx context.Context, token *domain.OneTimeToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetOneTimeToken(ctx context.Context, purpose, hash string) (*domain.OneTimeToken, error) {
+	args := m.Called(ctx, purpose, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OneTimeToken), args.Error(1)
+}
+
+func (m *MockTokenRepository) UseOneTimeToken(ctx context.Context, id string, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) InvalidateOneTimeTokens(ctx context.Context, userID, purpose string, at time.Time) error {
+	args := m.Called(ctx, userID, purpose, at)
+	return args.Error(0)
+}
+
+// MockLoginAttemptRepository is a mock type for domain.LoginAttemptRepository
+type MockLoginAttemptRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginAttemptRepository) Get(ctx context.Context, scope, key string) (*domain.LoginThrottle, error) {
+	args := m.Called(ctx, scope, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LoginThrottle), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) RecordFailure(ctx context.Context, scope, key string, at, windowStart time.Time) (int, error) {
+	args := m.Called(ctx, scope, key, at, windowStart)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLoginAttemptRepository) Lock(ctx context.Context, scope, key string, until time.Time) error {
+	args := m.Called(ctx, scope, key, until)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) Reset(ctx context.Context, scope, key string) error {
+	args := m.Called(ctx, scope, key)
+	return args.Error(0)
+}
+
+func (m *MockLoginAttemptRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+// newNoLoginAttemptRepository returns a login attempt repository without failures
+func newNoLoginAttemptRepository() *MockLoginAttemptRepository {
+	m := new(MockLoginAttemptRepository)
+	m.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	m.On("RecordFailure", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	m.On("Reset", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.On("DeleteStale", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+// MockMailer is a mock type for domain.Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, email domain.Email) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+var testAuthConfig = AuthConfig{
+	JWTSecret:       "test-secret",
+	AccessTokenTTL:  time.Hour,
+	RefreshTokenTTL: 24 * time.Hour,
+	ResetTokenTTL:   time.Hour,
+	VerifyTokenTTL:  48 * time.Hour,
+	MFATokenTTL:     5 * time.Minute,
+	AppURL:          "https://attendance.example.com/",
+}
+
 func TestUserUsecase_Register(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -63,6 +220,22 @@ func TestUserUsecase_Register(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "Admin Role Ignored",
+			user: &domain.User{
+				Email:    "sneaky@example.com",
+				Password: "password123",
+				Name:     "Sneaky User",
+				Role:     domain.RoleAdmin,
+			},
+			mockBehavior: func(mockRepo *MockUserRepository, ctx context.Context, user *domain.User) {
+				mockRepo.On("GetByEmail", ctx, user.Email).Return(nil, nil)
+				mockRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+					return u.Role == domain.RoleUser
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
 		{
 			name: "Email Already Exists",
 			user: &domain.User{
@@ -80,11 +253,14 @@ func TestUserUsecase_Register(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
 			mockRepo := new(MockUserRepository)
-			usecase := NewUserUsecase(mockRepo, "test-secret")
+			mockTokenRepo := new(MockTokenRepository)
+			mockMailer := new(MockMailer)
+			usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
 			ctx := context.Background()
 
 			// Set mock behavior
 			tc.mockBehavior(mockRepo, ctx, tc.user)
+			mockMailer.On("Send", ctx, mock.AnythingOfType("domain.Email")).Return(nil).Maybe()
 
 			// Execute
 			err := usecase.Register(ctx, tc.user)
@@ -92,10 +268,15 @@ func TestUserUsecase_Register(t *testing.T) {
 			// Assert
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
+				mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
 			} else {
 				assert.NoError(t, err)
 				assert.NotEmpty(t, tc.user.ID)
 				assert.NotEqual(t, "password123", tc.user.Password)
+				assert.False(t, tc.user.IsEmailVerified())
+				mockMailer.AssertCalled(t, "Send", ctx, mock.MatchedBy(func(email domain.Email) bool {
+					return email.To == tc.user.Email && strings.Contains(email.Body, "https://attendance.example.com/verify-email?token=")
+				}))
 			}
 			mockRepo.AssertExpectations(t)
 		})
@@ -129,6 +310,24 @@ func TestUserUsecase_Login(t *testing.T) {
 			expectedError: nil,
 			expectToken:   true,
 		},
+		{
+			name:     "Deactivated User",
+			email:    "inactive@example.com",
+			password: "password123",
+			mockBehavior: func(mockRepo *MockUserRepository, ctx context.Context, email string) {
+				hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+				deactivatedAt := time.Now()
+				mockRepo.On("GetByEmail", ctx, email).Return(&domain.User{
+					ID:            "inactive-id",
+					Email:         email,
+					Password:      string(hashedPassword),
+					Role:          domain.RoleUser,
+					DeactivatedAt: &deactivatedAt,
+				}, nil)
+			},
+			expectedError: domain.ErrUserInactive,
+			expectToken:   false,
+		},
 		{
 			name:     "Invalid Credentials",
 			email:    "wrong@example.com",
@@ -145,31 +344,252 @@ func TestUserUsecase_Login(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
 			mockRepo := new(MockUserRepository)
-			usecase := NewUserUsecase(mockRepo, "test-secret")
+			mockTokenRepo := new(MockTokenRepository)
+			usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
 			ctx := context.Background()
 
 			// Set mock behavior
 			tc.mockBehavior(mockRepo, ctx, tc.email)
+			mockTokenRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Maybe()
 
 			// Execute
-			token, err := usecase.Login(ctx, tc.email, tc.password)
+			result, err := usecase.Login(ctx, tc.email, tc.password, "192.0.2.1")
 
 			// Assert
 			if tc.expectedError != nil {
 				assert.ErrorIs(t, err, tc.expectedError)
-				assert.Empty(t, token)
+				assert.Nil(t, result)
+				mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
 			} else {
 				assert.NoError(t, err)
-				assert.NotEmpty(t, token)
+				assert.False(t, result.MFARequired)
+				assert.NotEmpty(t, result.AccessToken)
+				assert.NotEmpty(t, result.RefreshToken)
+				assert.Equal(t, 3600, result.ExpiresIn)
 			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestUserUsecase_Login_MFA(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &domain.User{ID: "admin-id", Email: "admin@example.com", Password: string(hashedPassword), Role: domain.RoleAdmin}
+
+	t.Run("Returns Challenge", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, mockMFARepo, newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mfa := enabledMFA(t, "admin-id")
+		mockRepo.On("GetByEmail", ctx, "admin@example.com").Return(user, nil)
+		mockRepo.On("GetByID", ctx, "admin-id").Return(user, nil)
+		mockMFARepo.On("GetByUserID", ctx, "admin-id").Return(mfa, nil)
+		mockMFARepo.On("UseStep", ctx, "admin-id", mock.AnythingOfType("int64")).Return(true, nil)
+		mockMFARepo.On("UseRecoveryCode", ctx, "admin-id", hashToken("wrong"), mock.AnythingOfType("time.Time")).Return(false, nil)
+		mockTokenRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		result, err := usecase.Login(ctx, "admin@example.com", "password123", "192.0.2.1")
+		assert.NoError(t, err)
+		assert.True(t, result.MFARequired)
+		assert.Nil(t, result.TokenPair)
+		assert.NotEmpty(t, result.MFAToken)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+
+		// The challenge token is not an email verification token
+		_, err = usecase.VerifyEmail(ctx, result.MFAToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidVerification)
+
+		_, err = usecase.VerifyMFA(ctx, result.MFAToken, "wrong")
+		assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+
+		tokens, err := usecase.VerifyMFA(ctx, result.MFAToken, currentCode(t, mfa.Secret))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Enrollment Required", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMFARepo := new(MockMFARepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, mockMFARepo, newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByEmail", ctx, "admin@example.com").Return(user, nil)
+		mockMFARepo.On("GetByUserID", ctx, "admin-id").Return(nil, nil)
+		mockMFARepo.On("GetRequiredRoles", ctx).Return([]string{domain.RoleAdmin}, nil)
+		mockTokenRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+
+		result, err := usecase.Login(ctx, "admin@example.com", "password123", "192.0.2.1")
+		assert.NoError(t, err)
+		assert.False(t, result.MFARequired)
+		assert.True(t, result.MFAEnrollmentRequired)
+		assert.NotEmpty(t, result.AccessToken)
+	})
+}
+
+func TestUserUsecase_VerifyMFA_InvalidToken(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig).(*userUsecase)
+	ctx := context.Background()
+
+	// An access token cannot stand in for the challenge
+	pair, _, err := usecase.newTokenPair(&domain.User{ID: "user-id"}, "family-id")
+	assert.NoError(t, err)
+
+	_, err = usecase.VerifyMFA(ctx, pair.AccessToken, "123456")
+	assert.ErrorIs(t, err, domain.ErrInvalidMFAToken)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestUserUsecase_Login_Lockout(t *testing.T) {
+	now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	config := testAuthConfig
+	config.Lockout = LockoutPolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      20,
+		BaseLockout:        time.Minute,
+		MaxLockout:         time.Hour,
+		Window:             24 * time.Hour,
+	}
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &domain.User{ID: "test-id", Email: "test@example.com", Password: string(hashedPassword), Role: domain.RoleUser}
+
+	newUsecase := func(mockRepo *MockUserRepository, mockTokenRepo *MockTokenRepository, mockAttemptRepo *MockLoginAttemptRepository) *userUsecase {
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), mockAttemptRepo, newBuiltInRoleRepository(), new(MockMailer), config).(*userUsecase)
+		usecase.now = func() time.Time { return now }
+		return usecase
+	}
+
+	t.Run("Locked Account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockAttemptRepo := new(MockLoginAttemptRepository)
+		usecase := newUsecase(mockRepo, new(MockTokenRepository), mockAttemptRepo)
+		ctx := context.Background()
+
+		lockedUntil := now.Add(90 * time.Second)
+		mockAttemptRepo.On("Get", ctx, domain.LoginScopeAccount, "test@example.com").Return(&domain.LoginThrottle{Failures: 5, LockedUntil: &lockedUntil}, nil)
+		mockAttemptRepo.On("Get", ctx, domain.LoginScopeIP, "192.0.2.1").Return(nil, nil)
+
+		// Even the correct password is refused while locked
+		_, err := usecase.Login(ctx, " Test@Example.com", "password123", "192.0.2.1")
+		assert.ErrorIs(t, err, domain.ErrTooManyAttempts)
+		var lockout *domain.LockoutError
+		assert.ErrorAs(t, err, &lockout)
+		assert.Equal(t, 90*time.Second, lockout.RetryAfter)
+		mockRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired Lock", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockAttemptRepo := new(MockLoginAttemptRepository)
+		usecase := newUsecase(mockRepo, mockTokenRepo, mockAttemptRepo)
+		ctx := context.Background()
+
+		lockedUntil := now.Add(-time.Second)
+		mockAttemptRepo.On("Get", ctx, domain.LoginScopeAccount, "test@example.com").Return(&domain.LoginThrottle{Failures: 5, LockedUntil: &lockedUntil}, nil)
+		mockAttemptRepo.On("Get", ctx, domain.LoginScopeIP, "192.0.2.1").Return(nil, nil)
+		mockRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+		mockTokenRepo.On("CreateRefreshToken", ctx, mock.AnythingOfType("*domain.RefreshToken")).Return(nil)
+		mockAttemptRepo.On("Reset", ctx, domain.LoginScopeAccount, "test@example.com").Return(nil)
+		mockAttemptRepo.On("DeleteStale", ctx, now.Add(-24*time.Hour)).Return(nil)
+
+		result, err := usecase.Login(ctx, "test@example.com", "password123", "192.0.2.1")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.AccessToken)
+		mockAttemptRepo.AssertExpectations(t)
+		// Failures of the IP are not cleared by a successful login
+		mockAttemptRepo.AssertNotCalled(t, "Reset", ctx, domain.LoginScopeIP, mock.Anything)
+	})
+
+	t.Run("Failure Reaching Limit Locks", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockAttemptRepo := new(MockLoginAttemptRepository)
+		usecase := newUsecase(mockRepo, new(MockTokenRepository), mockAttemptRepo)
+		ctx := context.Background()
+
+		windowStart := now.Add(-24 * time.Hour)
+		mockAttemptRepo.On("Get", ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		mockRepo.On("GetByEmail", ctx, "test@example.com").Return(user, nil)
+		mockAttemptRepo.On("RecordFailure", ctx, domain.LoginScopeAccount, "test@example.com", now, windowStart).Return(7, nil)
+		mockAttemptRepo.On("RecordFailure", ctx, domain.LoginScopeIP, "192.0.2.1", now, windowStart).Return(7, nil)
+		mockAttemptRepo.On("Lock", ctx, domain.LoginScopeAccount, "test@example.com", now.Add(4*time.Minute)).Return(nil)
+
+		_, err := usecase.Login(ctx, "test@example.com", "wrongpass", "192.0.2.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		mockAttemptRepo.AssertExpectations(t)
+		mockAttemptRepo.AssertNotCalled(t, "Lock", ctx, domain.LoginScopeIP, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown Email Counts", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockAttemptRepo := new(MockLoginAttemptRepository)
+		usecase := newUsecase(mockRepo, new(MockTokenRepository), mockAttemptRepo)
+		ctx := context.Background()
+
+		mockAttemptRepo.On("Get", ctx, mock.Anything, mock.Anything).Return(nil, nil)
+		mockRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, nil)
+		mockAttemptRepo.On("RecordFailure", ctx, domain.LoginScopeAccount, "nobody@example.com", now, mock.Anything).Return(1, nil)
+		mockAttemptRepo.On("RecordFailure", ctx, domain.LoginScopeIP, "192.0.2.1", now, mock.Anything).Return(1, nil)
+
+		_, err := usecase.Login(ctx, "nobody@example.com", "password123", "192.0.2.1")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+}
+
+func TestLockoutPolicy_LockoutFor(t *testing.T) {
+	policy := LockoutPolicy{BaseLockout: time.Minute, MaxLockout: time.Hour}
+
+	tests := []struct {
+		excess   int
+		expected time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{7, time.Hour},
+		{100, time.Hour},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, policy.lockoutFor(tc.excess), "excess %d", tc.excess)
+	}
+}
+
+func TestUserUsecase_VerifyMFA_CountsFailures(t *testing.T) {
+	user := &domain.User{ID: "admin-id", Email: "admin@example.com", Role: domain.RoleAdmin}
+	mockRepo := new(MockUserRepository)
+	mockMFARepo := new(MockMFARepository)
+	mockAttemptRepo := new(MockLoginAttemptRepository)
+	config := testAuthConfig
+	config.Lockout = LockoutPolicy{MaxAccountFailures: 5, BaseLockout: time.Minute, MaxLockout: time.Hour, Window: time.Hour}
+	usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), mockMFARepo, mockAttemptRepo, newBuiltInRoleRepository(), new(MockMailer), config).(*userUsecase)
+	ctx := context.Background()
+
+	mfaToken, err := usecase.signPurposeToken(mfaChallengePurpose, jwt.MapClaims{"user_id": "admin-id"}, time.Minute)
+	assert.NoError(t, err)
+	mockRepo.On("GetByID", ctx, "admin-id").Return(user, nil)
+	mockMFARepo.On("GetByUserID", ctx, "admin-id").Return(enabledMFA(t, "admin-id"), nil)
+	mockMFARepo.On("UseRecoveryCode", ctx, "admin-id", mock.Anything, mock.Anything).Return(false, nil)
+	mockAttemptRepo.On("Get", ctx, domain.LoginScopeAccount, "admin@example.com").Return(nil, nil)
+	mockAttemptRepo.On("RecordFailure", ctx, domain.LoginScopeAccount, "admin@example.com", mock.Anything, mock.Anything).Return(5, nil)
+	mockAttemptRepo.On("Lock", ctx, domain.LoginScopeAccount, "admin@example.com", mock.Anything).Return(nil)
+
+	_, err = usecase.VerifyMFA(ctx, mfaToken, "wrong-code")
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	mockAttemptRepo.AssertExpectations(t)
+}
+
 func TestUserUsecase_GetProfile(t *testing.T) {
 	mockRepo := new(MockUserRepository)
-	usecase := NewUserUsecase(mockRepo, "test-secret")
+	mockTokenRepo := new(MockTokenRepository)
+	usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
 	ctx := context.Background()
 
 	t.Run("Success", func(t *testing.T) {
@@ -219,6 +639,7 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 			mockBehavior: func(mockRepo *MockUserRepository, ctx context.Context, user *domain.User) {
 				mockRepo.On("GetByID", ctx, user.ID).Return(&domain.User{
 					ID:       user.ID,
+					Email:    user.Email,
 					Password: "existing-hashed-password",
 				}, nil)
 				mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
@@ -236,6 +657,7 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 			mockBehavior: func(mockRepo *MockUserRepository, ctx context.Context, user *domain.User) {
 				mockRepo.On("GetByID", ctx, user.ID).Return(&domain.User{
 					ID:       user.ID,
+					Email:    user.Email,
 					Password: "existing-hashed-password",
 				}, nil)
 				mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
@@ -268,12 +690,16 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Setup
 			mockRepo := new(MockUserRepository)
-			usecase := NewUserUsecase(mockRepo, "test-secret")
+			mockTokenRepo := new(MockTokenRepository)
+			usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
 			ctx := context.Background()
 
 			// Set mock behavior
 			tc.mockBehavior(mockRepo, ctx, tc.user)
 
+			mockTokenRepo.On("RevokeUserRefreshTokens", ctx, tc.user.ID, mock.AnythingOfType("time.Time")).Return(nil).Maybe()
+			passwordChanged := tc.user.Password != ""
+
 			// Execute
 			err := usecase.UpdateProfile(ctx, tc.user)
 
@@ -283,6 +709,11 @@ func TestUserUsecase_UpdateProfile(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 			}
+			if tc.expectedError == nil && passwordChanged {
+				mockTokenRepo.AssertCalled(t, "RevokeUserRefreshTokens", ctx, tc.user.ID, mock.AnythingOfType("time.Time"))
+			} else {
+				mockTokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything, mock.Anything, mock.Anything)
+			}
 			mockRepo.AssertExpectations(t)
 		})
 	}
@@ -324,7 +755,8 @@ func TestUserUsecase_Register_Errors(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockRepo := new(MockUserRepository)
-			usecase := NewUserUsecase(mockRepo, "test-secret")
+			mockTokenRepo := new(MockTokenRepository)
+			usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
 			ctx := context.Background()
 
 			tc.mockBehavior(mockRepo, ctx, tc.user)
@@ -335,3 +767,798 @@ func TestUserUsecase_Register_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_UpdateProfile_KeepsAdminManagedFields(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, "test-id").Return(&domain.User{
+		ID:           "test-id",
+		Name:         "Old Name",
+		Email:        "old@example.com",
+		Password:     "existing-hashed-password",
+		Role:         domain.RoleAdmin,
+		Location:     "Jakarta",
+		DepartmentID: "dept-id",
+		TeamID:       "team-id",
+		ManagerID:    "manager-id",
+	}, nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Name == "New Name" && u.Email == "old@example.com" &&
+			u.Role == domain.RoleAdmin && u.Location == "Jakarta" &&
+			u.DepartmentID == "dept-id" && u.TeamID == "team-id" && u.ManagerID == "manager-id"
+	})).Return(nil)
+
+	err := usecase.UpdateProfile(ctx, &domain.User{ID: "test-id", Name: "New Name"})
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_UpdateProfile_EmailChange(t *testing.T) {
+	existing := func() *domain.User {
+		verifiedAt := time.Now().Add(-24 * time.Hour)
+		return &domain.User{ID: "test-id", Name: "John", Email: "old@example.com", Password: "hash", EmailVerifiedAt: &verifiedAt}
+	}
+
+	t.Run("Keeps Old Email Until Verified", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "test-id").Return(existing(), nil)
+		mockRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == "old@example.com" && u.PendingEmail == "new@example.com" && u.IsEmailVerified()
+		})).Return(nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(email domain.Email) bool {
+			return email.To == "new@example.com" && strings.Contains(email.Body, "/verify-email?token=")
+		})).Return(nil)
+
+		err := usecase.UpdateProfile(ctx, &domain.User{ID: "test-id", Email: "new@example.com"})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Email Taken", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "test-id").Return(existing(), nil)
+		mockRepo.On("GetByEmail", ctx, "taken@example.com").Return(&domain.User{ID: "other-id"}, nil)
+
+		err := usecase.UpdateProfile(ctx, &domain.User{ID: "test-id", Email: "taken@example.com"})
+		assert.ErrorIs(t, err, domain.ErrEmailExists)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	newUsecase := func(mockRepo *MockUserRepository) *userUsecase {
+		return NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig).(*userUsecase)
+	}
+
+	t.Run("New Account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := newUsecase(mockRepo)
+		ctx := context.Background()
+
+		token, err := usecase.signPurposeToken(verificationPurpose, jwt.MapClaims{"user_id": "user-id", "email": "john@example.com"}, time.Hour)
+		assert.NoError(t, err)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Email: "john@example.com"}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == "john@example.com" && u.IsEmailVerified()
+		})).Return(nil)
+
+		user, err := usecase.VerifyEmail(ctx, token)
+		assert.NoError(t, err)
+		assert.True(t, user.IsEmailVerified())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Pending Email Replaces Current", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := newUsecase(mockRepo)
+		ctx := context.Background()
+
+		token, err := usecase.signPurposeToken(verificationPurpose, jwt.MapClaims{"user_id": "user-id", "email": "new@example.com"}, time.Hour)
+		assert.NoError(t, err)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Email: "old@example.com", PendingEmail: "new@example.com"}, nil)
+		mockRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Email == "new@example.com" && u.PendingEmail == "" && u.IsEmailVerified()
+		})).Return(nil)
+
+		user, err := usecase.VerifyEmail(ctx, token)
+		assert.NoError(t, err)
+		assert.Equal(t, "new@example.com", user.Email)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Superseded Address", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := newUsecase(mockRepo)
+		ctx := context.Background()
+
+		token, err := usecase.signPurposeToken(verificationPurpose, jwt.MapClaims{"user_id": "user-id", "email": "first@example.com"}, time.Hour)
+		assert.NoError(t, err)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Email: "old@example.com", PendingEmail: "second@example.com"}, nil)
+
+		_, err = usecase.VerifyEmail(ctx, token)
+		assert.ErrorIs(t, err, domain.ErrInvalidVerification)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := newUsecase(mockRepo)
+		usecase.now = func() time.Time { return time.Now().Add(-72 * time.Hour) }
+
+		token, err := usecase.signPurposeToken(verificationPurpose, jwt.MapClaims{"user_id": "user-id", "email": "john@example.com"}, time.Hour)
+		assert.NoError(t, err)
+		usecase.now = time.Now
+
+		_, err = usecase.VerifyEmail(context.Background(), token)
+		assert.ErrorIs(t, err, domain.ErrInvalidVerification)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Access Token Rejected", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := newUsecase(mockRepo)
+
+		pair, _, err := usecase.newTokenPair(&domain.User{ID: "user-id", Email: "john@example.com"}, "family-id")
+		assert.NoError(t, err)
+
+		_, err = usecase.VerifyEmail(context.Background(), pair.AccessToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidVerification)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ResendVerification(t *testing.T) {
+	verifiedAt := time.Now()
+	tests := []struct {
+		name          string
+		user          *domain.User
+		expectedTo    string
+		expectedError error
+	}{
+		{
+			name:       "Unverified Account",
+			user:       &domain.User{ID: "user-id", Email: "john@example.com"},
+			expectedTo: "john@example.com",
+		},
+		{
+			name:       "Pending Email",
+			user:       &domain.User{ID: "user-id", Email: "john@example.com", PendingEmail: "new@example.com", EmailVerifiedAt: &verifiedAt},
+			expectedTo: "new@example.com",
+		},
+		{
+			name:          "Already Verified",
+			user:          &domain.User{ID: "user-id", Email: "john@example.com", EmailVerifiedAt: &verifiedAt},
+			expectedError: domain.ErrAlreadyVerified,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockMailer := new(MockMailer)
+			usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+			ctx := context.Background()
+
+			mockRepo.On("GetByID", ctx, "user-id").Return(tc.user, nil)
+			mockMailer.On("Send", ctx, mock.AnythingOfType("domain.Email")).Return(nil).Maybe()
+
+			err := usecase.ResendVerification(ctx, "user-id")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				mockMailer.AssertCalled(t, "Send", ctx, mock.MatchedBy(func(email domain.Email) bool {
+					return email.To == tc.expectedTo
+				}))
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ListUsers(t *testing.T) {
+	type testCase struct {
+		name           string
+		filter         domain.UserFilter
+		expectedFilter domain.UserFilter
+		expectedError  error
+	}
+
+	tests := []testCase{
+		{
+			name:           "Defaults",
+			filter:         domain.UserFilter{Search: "ann"},
+			expectedFilter: domain.UserFilter{Search: "ann", Page: 1, PageSize: domain.DefaultPageSize},
+		},
+		{
+			name:           "Page Size Capped",
+			filter:         domain.UserFilter{Role: domain.RoleAdmin, Page: 3, PageSize: 1000},
+			expectedFilter: domain.UserFilter{Role: domain.RoleAdmin, Page: 3, PageSize: domain.MaxPageSize},
+		},
+		{
+			name:          "Invalid Role",
+			filter:        domain.UserFilter{Role: "owner"},
+			expectedError: domain.ErrInvalidRole,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockTokenRepo := new(MockTokenRepository)
+			usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+			ctx := context.Background()
+
+			if tc.expectedError == nil {
+				mockRepo.On("List", ctx, tc.expectedFilter).Return([]domain.User{{ID: "1"}}, 41, nil)
+			}
+
+			users, total, err := usecase.ListUsers(ctx, tc.filter)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, users)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, users, 1)
+				assert.Equal(t, 41, total)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_UpdateRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Role == domain.RoleAdmin
+		})).Return(nil)
+
+		user, err := usecase.UpdateRole(ctx, "admin-id", "user-id", domain.RoleAdmin)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, user.Role)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+
+		user, err := usecase.UpdateRole(context.Background(), "admin-id", "admin-id", domain.RoleUser)
+		assert.ErrorIs(t, err, domain.ErrSelfManagement)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid Role", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+
+		user, err := usecase.UpdateRole(context.Background(), "admin-id", "user-id", "owner")
+		assert.ErrorIs(t, err, domain.ErrInvalidRole)
+		assert.Nil(t, user)
+	})
+	t.Run("Role Above The Actor", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+		mockRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+
+		// hr can neither hand out the admin role nor demote an admin
+		_, err := usecase.UpdateRole(ctx, "hr-id", "user-id", domain.RoleAdmin)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		_, err = usecase.UpdateRole(ctx, "hr-id", "admin-id", domain.RoleUser)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_SetActive(t *testing.T) {
+	t.Run("Deactivate", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig).(*userUsecase)
+		now := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+		usecase.now = func() time.Time { return now }
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.DeactivatedAt != nil && u.DeactivatedAt.Equal(now)
+		})).Return(nil)
+
+		user, err := usecase.SetActive(ctx, "admin-id", "user-id", false)
+		assert.NoError(t, err)
+		assert.False(t, user.IsActive())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reactivate", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		deactivatedAt := time.Now().Add(-time.Hour)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", DeactivatedAt: &deactivatedAt}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.DeactivatedAt == nil
+		})).Return(nil)
+
+		user, err := usecase.SetActive(ctx, "admin-id", "user-id", true)
+		assert.NoError(t, err)
+		assert.True(t, user.IsActive())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+
+		user, err := usecase.SetActive(context.Background(), "admin-id", "admin-id", false)
+		assert.ErrorIs(t, err, domain.ErrSelfManagement)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+	t.Run("Role Above The Actor", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+		mockRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+
+		user, err := usecase.SetActive(ctx, "hr-id", "admin-id", false)
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_SetLocation(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+		mockRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Location == "Jakarta"
+		})).Return(nil)
+
+		user, err := usecase.SetLocation(ctx, "hr-id", "user-id", "Jakarta")
+		assert.NoError(t, err)
+		assert.Equal(t, "Jakarta", user.Location)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Own Account", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+
+		user, err := usecase.SetLocation(context.Background(), "hr-id", "hr-id", "Jakarta")
+		assert.ErrorIs(t, err, domain.ErrSelfManagement)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Role Above The Actor", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+		mockRepo.On("GetByID", ctx, "admin-id").Return(&domain.User{ID: "admin-id", Role: domain.RoleAdmin}, nil)
+
+		user, err := usecase.SetLocation(ctx, "hr-id", "admin-id", "Jakarta")
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+		assert.Nil(t, user)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_DeleteUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockRepo.On("Delete", ctx, "user-id").Return(nil)
+
+		err := usecase.DeleteUser(ctx, "admin-id", "user-id")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByID", ctx, "missing").Return(nil, nil)
+
+		err := usecase.DeleteUser(ctx, "admin-id", "missing")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_Unlock(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockAttemptRepo := new(MockLoginAttemptRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), mockAttemptRepo, newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Email: "User@Example.com"}, nil)
+		mockAttemptRepo.On("Reset", ctx, domain.LoginScopeAccount, "user@example.com").Return(nil)
+
+		err := usecase.Unlock(ctx, "user-id")
+		assert.NoError(t, err)
+		mockAttemptRepo.AssertExpectations(t)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, "missing-id").Return(nil, nil)
+
+		err := usecase.Unlock(ctx, "missing-id")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestUserUsecase_CreateUser_InvalidRole(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+
+	err := usecase.CreateUser(context.Background(), "admin-id", &domain.User{Email: "new@example.com", Password: "password123", Role: "owner"})
+	assert.ErrorIs(t, err, domain.ErrInvalidRole)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserUsecase_CreateUser_RoleAboveTheActor(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	usecase := NewUserUsecase(mockRepo, new(MockTokenRepository), newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, "hr-id").Return(&domain.User{ID: "hr-id", Role: "hr"}, nil)
+
+	err := usecase.CreateUser(ctx, "hr-id", &domain.User{Email: "new@example.com", Password: "password123", Role: domain.RoleAdmin})
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserUsecase_BootstrapAdmin(t *testing.T) {
+	adminFilter := domain.UserFilter{Role: domain.RoleAdmin, Page: 1, PageSize: 1}
+
+	t.Run("Creates First Admin", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("List", ctx, adminFilter).Return([]domain.User{}, 0, nil)
+		mockRepo.On("GetByEmail", ctx, "admin@example.com").Return(nil, nil)
+		mockRepo.On("Create", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Role == domain.RoleAdmin && u.Password != "Secret123!" && u.IsEmailVerified()
+		})).Return(nil)
+
+		created, err := usecase.BootstrapAdmin(ctx, &domain.User{Name: "Admin", Email: "admin@example.com", Password: "Secret123!"})
+		assert.NoError(t, err)
+		assert.True(t, created)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Admin Exists", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("List", ctx, adminFilter).Return([]domain.User{{ID: "admin-id"}}, 1, nil)
+
+		created, err := usecase.BootstrapAdmin(ctx, &domain.User{Name: "Admin", Email: "admin@example.com", Password: "Secret123!"})
+		assert.NoError(t, err)
+		assert.False(t, created)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_Refresh(t *testing.T) {
+	const refreshToken = "refresh-token"
+	anyTime := mock.AnythingOfType("time.Time")
+
+	activeToken := func() *domain.RefreshToken {
+		return &domain.RefreshToken{
+			ID:        "token-id",
+			UserID:    "user-id",
+			FamilyID:  "family-id",
+			TokenHash: hashToken(refreshToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Rotates Token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken(refreshToken)).Return(activeToken(), nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Role: domain.RoleUser}, nil)
+		mockTokenRepo.On("RevokeRefreshToken", ctx, "token-id", mock.AnythingOfType("string"), anyTime).Return(true, nil)
+		mockTokenRepo.On("CreateRefreshToken", ctx, mock.MatchedBy(func(token *domain.RefreshToken) bool {
+			return token.FamilyID == "family-id" && token.UserID == "user-id" && token.TokenHash != hashToken(refreshToken)
+		})).Return(nil)
+
+		tokens, err := usecase.Refresh(ctx, refreshToken)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+		mockRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reuse Revokes Family", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		rotated := activeToken()
+		revokedAt := time.Now().Add(-time.Minute)
+		rotated.RevokedAt = &revokedAt
+		rotated.ReplacedBy = "next-token-id"
+		mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken(refreshToken)).Return(rotated, nil)
+		mockTokenRepo.On("RevokeFamily", ctx, "family-id", anyTime).Return(nil)
+
+		tokens, err := usecase.Refresh(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrTokenReused)
+		assert.Nil(t, tokens)
+		mockTokenRepo.AssertExpectations(t)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Concurrent Rotation Revokes Family", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken(refreshToken)).Return(activeToken(), nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockTokenRepo.On("RevokeRefreshToken", ctx, "token-id", mock.AnythingOfType("string"), anyTime).Return(false, nil)
+		mockTokenRepo.On("RevokeFamily", ctx, "family-id", anyTime).Return(nil)
+
+		tokens, err := usecase.Refresh(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrTokenReused)
+		assert.Nil(t, tokens)
+		mockTokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		expired := activeToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken(refreshToken)).Return(expired, nil)
+
+		tokens, err := usecase.Refresh(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Deactivated User", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		deactivatedAt := time.Now()
+		mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken(refreshToken)).Return(activeToken(), nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", DeactivatedAt: &deactivatedAt}, nil)
+
+		tokens, err := usecase.Refresh(ctx, refreshToken)
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+		assert.Nil(t, tokens)
+		mockTokenRepo.AssertNotCalled(t, "RevokeRefreshToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockTokenRepo := new(MockTokenRepository)
+	usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockTokenRepo.On("RevokeAccessToken", ctx, "access-jti", expiresAt).Return(nil)
+	mockTokenRepo.On("GetRefreshTokenByHash", ctx, hashToken("refresh-token")).Return(&domain.RefreshToken{ID: "token-id", FamilyID: "family-id"}, nil)
+	mockTokenRepo.On("RevokeFamily", ctx, "family-id", mock.AnythingOfType("time.Time")).Return(nil)
+	mockTokenRepo.On("DeleteExpired", ctx, mock.AnythingOfType("time.Time")).Return(nil)
+
+	err := usecase.Logout(ctx, "access-jti", expiresAt, "refresh-token")
+	assert.NoError(t, err)
+	mockTokenRepo.AssertExpectations(t)
+}
+
+func TestUserUsecase_RequestPasswordReset(t *testing.T) {
+	t.Run("Sends Reset Link", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+		ctx := context.Background()
+
+		var stored *domain.OneTimeToken
+		mockRepo.On("GetByEmail", ctx, "john@example.com").Return(&domain.User{ID: "user-id", Name: "John", Email: "john@example.com"}, nil)
+		mockTokenRepo.On("InvalidateOneTimeTokens", ctx, "user-id", domain.TokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(nil)
+		mockTokenRepo.On("CreateOneTimeToken", ctx, mock.AnythingOfType("*domain.OneTimeToken")).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*domain.OneTimeToken)
+		}).Return(nil)
+		mockMailer.On("Send", ctx, mock.MatchedBy(func(email domain.Email) bool {
+			return email.To == "john@example.com" && strings.Contains(email.Body, "https://attendance.example.com/reset-password?token=")
+		})).Return(nil)
+
+		err := usecase.RequestPasswordReset(ctx, "john@example.com")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+		mockMailer.AssertExpectations(t)
+
+		// The email carries the token, the database only its hash
+		body := mockMailer.Calls[0].Arguments.Get(1).(domain.Email).Body
+		token := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+		assert.Equal(t, hashToken(token), stored.TokenHash)
+		assert.NotContains(t, body, stored.TokenHash)
+	})
+
+	t.Run("Mail Failure Is Not Reported", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByEmail", ctx, "john@example.com").Return(&domain.User{ID: "user-id", Name: "John", Email: "john@example.com"}, nil)
+		mockTokenRepo.On("InvalidateOneTimeTokens", ctx, "user-id", domain.TokenPurposePasswordReset, mock.AnythingOfType("time.Time")).Return(nil)
+		mockTokenRepo.On("CreateOneTimeToken", ctx, mock.AnythingOfType("*domain.OneTimeToken")).Return(nil)
+		mockMailer.On("Send", ctx, mock.AnythingOfType("domain.Email")).Return(assert.AnError)
+
+		err := usecase.RequestPasswordReset(ctx, "john@example.com")
+		assert.NoError(t, err)
+		mockMailer.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Email", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		mockMailer := new(MockMailer)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), mockMailer, testAuthConfig)
+		ctx := context.Background()
+
+		mockRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, nil)
+
+		err := usecase.RequestPasswordReset(ctx, "nobody@example.com")
+		assert.NoError(t, err)
+		mockTokenRepo.AssertNotCalled(t, "CreateOneTimeToken", mock.Anything, mock.Anything)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	const resetToken = "reset-token"
+	anyTime := mock.AnythingOfType("time.Time")
+
+	validToken := func() *domain.OneTimeToken {
+		return &domain.OneTimeToken{
+			ID:        "token-id",
+			UserID:    "user-id",
+			Purpose:   domain.TokenPurposePasswordReset,
+			TokenHash: hashToken(resetToken),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockTokenRepo.On("GetOneTimeToken", ctx, domain.TokenPurposePasswordReset, hashToken(resetToken)).Return(validToken(), nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id", Password: "old-hash"}, nil)
+		mockTokenRepo.On("UseOneTimeToken", ctx, "token-id", anyTime).Return(true, nil)
+		mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+			return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte("NewPass123!")) == nil && u.PasswordChangedAt != nil
+		})).Return(nil)
+		mockTokenRepo.On("RevokeUserRefreshTokens", ctx, "user-id", anyTime).Return(nil)
+
+		err := usecase.ResetPassword(ctx, resetToken, "NewPass123!")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Used Token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		used := validToken()
+		usedAt := time.Now().Add(-time.Minute)
+		used.UsedAt = &usedAt
+		mockTokenRepo.On("GetOneTimeToken", ctx, domain.TokenPurposePasswordReset, hashToken(resetToken)).Return(used, nil)
+
+		err := usecase.ResetPassword(ctx, resetToken, "NewPass123!")
+		assert.ErrorIs(t, err, domain.ErrInvalidReset)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		expired := validToken()
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		mockTokenRepo.On("GetOneTimeToken", ctx, domain.TokenPurposePasswordReset, hashToken(resetToken)).Return(expired, nil)
+
+		err := usecase.ResetPassword(ctx, resetToken, "NewPass123!")
+		assert.ErrorIs(t, err, domain.ErrInvalidReset)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Concurrent Use", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockTokenRepo := new(MockTokenRepository)
+		usecase := NewUserUsecase(mockRepo, mockTokenRepo, newNoMFARepository(), newNoLoginAttemptRepository(), newBuiltInRoleRepository(), new(MockMailer), testAuthConfig)
+		ctx := context.Background()
+
+		mockTokenRepo.On("GetOneTimeToken", ctx, domain.TokenPurposePasswordReset, hashToken(resetToken)).Return(validToken(), nil)
+		mockRepo.On("GetByID", ctx, "user-id").Return(&domain.User{ID: "user-id"}, nil)
+		mockTokenRepo.On("UseOneTimeToken", ctx, "token-id", anyTime).Return(false, nil)
+
+		err := usecase.ResetPassword(ctx, resetToken, "NewPass123!")
+		assert.ErrorIs(t, err, domain.ErrInvalidReset)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang-tes/internal/domain"
+	"golang-tes/internal/utils/logger"
+	"golang-tes/pkg/xlsx"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TableExport streams a table to the client as a CSV or XLSX attachment. The
+// response only starts with the first row, so errors found before it can still
+// be sent with ErrorResponse.
+type TableExport struct {
+	c        *gin.Context
+	format   string
+	filename string
+	header   []interface{}
+	writer   tableWriter
+}
+
+type tableWriter interface {
+	WriteRow(values ...interface{}) error
+	Close() error
+}
+
+// NewTableExport prepares an export in the format, csv or xlsx. The filename
+// is given without extension.
+func NewTableExport(c *gin.Context, format, filename string, header ...string) (*TableExport, error) {
+	if format != domain.ExportFormatCSV && format != domain.ExportFormatXLSX {
+		return nil, domain.ErrInvalidExportFormat
+	}
+	export := &TableExport{c: c, format: format, filename: filename}
+	for _, column := range header {
+		export.header = append(export.header, column)
+	}
+	return export, nil
+}
+
+// Started reports whether the response has been started
+func (e *TableExport) Started() bool {
+	return e.writer != nil
+}
+
+// Interrupted reports whether err occurred after the response started. Such
+// errors can no longer be sent to the client and are logged instead; the file
+// is left incomplete.
+func (e *TableExport) Interrupted(err error) bool {
+	if err == nil || !e.Started() {
+		return false
+	}
+	logger.Error("Export interrupted",
+		zap.Error(err),
+		zap.String("file", e.filename),
+		zap.String("path", e.c.Request.URL.Path))
+	return true
+}
+
+// WriteRow writes a row, starting the response with the header if needed
+func (e *TableExport) WriteRow(values ...interface{}) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.writer.WriteRow(values...)
+}
+
+// Close completes the file. An export without rows still has its header.
+func (e *TableExport) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.writer.Close()
+}
+
+func (e *TableExport) start() error {
+	if e.writer != nil {
+		return nil
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if e.format == domain.ExportFormatXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	e.c.Header("Content-Type", contentType)
+	e.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, e.filename, e.format))
+	e.c.Status(http.StatusOK)
+
+	if e.format == domain.ExportFormatXLSX {
+		writer, err := xlsx.NewWriter(e.c.Writer, e.filename)
+		if err != nil {
+			return err
+		}
+		e.writer = writer
+	} else {
+		e.writer = &csvWriter{w: csv.NewWriter(e.c.Writer)}
+	}
+	return e.writer.WriteRow(e.header...)
+}
+
+// csvWriter adapts csv.Writer to rows of values
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = escapeFormula(v)
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return w.w.Write(record)
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// escapeFormula keeps spreadsheet applications from evaluating text that looks
+// like a formula, such as a user name starting with "="
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -8,9 +8,32 @@ type Response struct {
 	Status  int         `json:"status"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+	Meta    *Pagination `json:"meta,omitempty"`
 	Error   string      `json:"error,omitempty"`
 }
 
+// Pagination describes the page of a list returned in Data
+type Pagination struct {
+	Page       int `json:"page"`
+	PageSize   int `json:"page_size"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// NewPagination returns the metadata of a page out of total items
+func NewPagination(page, pageSize, total int) *Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return &Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
 func SuccessResponse(c *gin.Context, status int, message string, data interface{}) {
 	c.JSON(status, Response{
 		Status:  status,
@@ -19,6 +42,16 @@ func SuccessResponse(c *gin.Context, status int, message string, data interface{
 	})
 }
 
+// PaginatedResponse sends a page of a list together with its pagination metadata
+func PaginatedResponse(c *gin.Context, status int, message string, data interface{}, meta *Pagination) {
+	c.JSON(status, Response{
+		Status:  status,
+		Message: message,
+		Data:    data,
+		Meta:    meta,
+	})
+}
+
 func ErrorResponse(c *gin.Context, status int, message string, err string) {
 	c.JSON(status, Response{
 		Status:  status,
@@ -3,7 +3,9 @@ package validator
 import (
 	"golang-tes/internal/domain"
 	"net/mail"
+	"regexp"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -72,10 +74,59 @@ func ValidateAttendanceStatus(status string) error {
 	return nil
 }
 
-// ValidateUserRole checks if the user role is valid
-func ValidateUserRole(role string) error {
-	if !domain.ValidUserRoles[role] {
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// ValidateRoleName checks that the role name is a lowercase slug such as "team-lead"
+func ValidateRoleName(name string) error {
+	if !roleNamePattern.MatchString(name) {
+		return domain.ErrInvalidRoleName
+	}
+	return nil
+}
+
+// ValidateTimeOfDay checks if the value is a HH:MM time of day
+func ValidateTimeOfDay(value string) error {
+	if _, err := time.Parse(domain.TimeOfDayFormat, value); err != nil {
 		return domain.ErrInvalidInput
 	}
 	return nil
 }
+
+// ValidateShift checks the shift times and grace period. The end may be
+// before the start for a shift that runs past midnight.
+func ValidateShift(shift *domain.Shift) error {
+	if err := ValidateName(shift.Name); err != nil {
+		return domain.ErrInvalidShift
+	}
+	if ValidateTimeOfDay(shift.StartTime) != nil || ValidateTimeOfDay(shift.EndTime) != nil {
+		return domain.ErrInvalidShift
+	}
+	if shift.StartTime == shift.EndTime || shift.GracePeriodMinutes < 0 {
+		return domain.ErrInvalidShift
+	}
+	return nil
+}
+
+// ValidateWorkSchedule checks the schedule times, grace period and working weekdays
+func ValidateWorkSchedule(schedule *domain.WorkSchedule) error {
+	if err := ValidateName(schedule.Name); err != nil {
+		return domain.ErrInvalidSchedule
+	}
+	if ValidateTimeOfDay(schedule.StartTime) != nil || ValidateTimeOfDay(schedule.EndTime) != nil {
+		return domain.ErrInvalidSchedule
+	}
+	if schedule.StartTime == schedule.EndTime || schedule.GracePeriodMinutes < 0 {
+		return domain.ErrInvalidSchedule
+	}
+	if len(schedule.WorkDays) == 0 {
+		return domain.ErrInvalidSchedule
+	}
+	seen := map[int]bool{}
+	for _, day := range schedule.WorkDays {
+		if day < int(time.Sunday) || day > int(time.Saturday) || seen[day] {
+			return domain.ErrInvalidSchedule
+		}
+		seen[day] = true
+	}
+	return nil
+}
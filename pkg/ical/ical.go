@@ -0,0 +1,186 @@
+// Package ical reads events from iCalendar (RFC 5545) files. It supports the
+// subset needed for holiday calendars: VEVENT components with a SUMMARY and
+// DTSTART/DTEND given as dates or date-times. Recurrence rules are ignored.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrNoCalendar = errors.New("ical: no VCALENDAR component found")
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// Event is a calendar event. End is exclusive, as in the iCalendar format.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+	AllDay  bool
+}
+
+// Days returns the calendar days covered by the event, at midnight UTC
+func (e Event) Days() []time.Time {
+	start := toDay(e.Start)
+	end := toDay(e.End)
+	// A timed event ending after midnight also covers its last day
+	if !e.AllDay && e.End.After(end) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+
+	var days []time.Time
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Parse reads all events from an iCalendar stream
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		events     []Event
+		current    *Event
+		inCalendar bool
+		hasStart   bool
+	)
+	for i, line := range lines {
+		name, params, value, ok := splitProperty(line)
+		if !ok {
+			return nil, fmt.Errorf("ical: line %d: malformed content line", i+1)
+		}
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			inCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &Event{}
+			hasStart = false
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current == nil {
+				return nil, fmt.Errorf("ical: line %d: END:VEVENT without BEGIN", i+1)
+			}
+			if !hasStart {
+				return nil, fmt.Errorf("ical: line %d: event without DTSTART", i+1)
+			}
+			if current.End.IsZero() {
+				current.End = current.Start
+				if current.AllDay {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			// Properties outside events, e.g. PRODID or VTIMEZONE details
+		case name == "SUMMARY":
+			current.Summary = unescape(value)
+		case name == "DTSTART":
+			start, allDay, err := parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %w", i+1, err)
+			}
+			current.Start, current.AllDay, hasStart = start, allDay, true
+		case name == "DTEND":
+			end, _, err := parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("ical: line %d: %w", i+1, err)
+			}
+			current.End = end
+		}
+	}
+
+	if !inCalendar {
+		return nil, ErrNoCalendar
+	}
+	if current != nil {
+		return nil, errors.New("ical: unterminated VEVENT")
+	}
+	return events, nil
+}
+
+// unfold joins folded content lines, which continue with a leading space or tab
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitProperty splits "NAME;PARAM=VALUE:value" into its parts
+func splitProperty(line string) (name string, params map[string]string, value string, ok bool) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 1 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parseTime parses a DATE or DATE-TIME value, honouring TZID when it is known
+func parseTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		return t, true, err
+	}
+
+	loc := time.UTC
+	if strings.HasSuffix(value, "Z") {
+		value = strings.TrimSuffix(value, "Z")
+	} else if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false, err
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
+
+func toDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -1,3 +1,66 @@
+-- Create roles tables. A role is a named set of permissions; admin and user
+-- are built in. The admin role is granted every permission in code as well.
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_name VARCHAR(50) NOT NULL,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_name, permission),
+    FOREIGN KEY (role_name) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+INSERT IGNORE INTO roles (name, description, is_system) VALUES
+    ('admin', 'Full access', TRUE),
+    ('user', 'Records their own attendance and leave', TRUE);
+
+INSERT IGNORE INTO role_permissions (role_name, permission) VALUES
+    ('admin', 'attendance:read:own'),
+    ('admin', 'attendance:write:own'),
+    ('admin', 'attendance:read:all'),
+    ('admin', 'attendance:write:all'),
+    ('admin', 'corrections:approve'),
+    ('admin', 'leaves:write:own'),
+    ('admin', 'leaves:approve'),
+    ('admin', 'leaves:manage'),
+    ('admin', 'schedules:manage'),
+    ('admin', 'holidays:manage'),
+    ('admin', 'users:read'),
+    ('admin', 'users:manage'),
+    ('admin', 'roles:manage'),
+    ('admin', 'organization:manage'),
+    ('admin', 'reports:read'),
+    ('admin', 'payroll:export'),
+    ('admin', 'overtime:approve'),
+    ('user', 'attendance:read:own'),
+    ('user', 'attendance:write:own'),
+    ('user', 'leaves:write:own');
+
+-- Create departments and teams tables. Every team belongs to one department.
+CREATE TABLE IF NOT EXISTS departments (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id VARCHAR(36) PRIMARY KEY,
+    department_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (department_id) REFERENCES departments(id),
+    UNIQUE KEY unique_department_team (department_id, name)
+);
+
 -- Create users table
 CREATE TABLE IF NOT EXISTS users (
     id VARCHAR(36) PRIMARY KEY,
@@ -5,18 +68,287 @@ CREATE TABLE IF NOT EXISTS users (
     email VARCHAR(255) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
     role VARCHAR(50) NOT NULL DEFAULT 'user',
+    location VARCHAR(100) NOT NULL DEFAULT '',
+    department_id VARCHAR(36) NULL,
+    team_id VARCHAR(36) NULL,
+    manager_id VARCHAR(36) NULL,
+    deactivated_at DATETIME NULL,
+    email_verified_at DATETIME NULL,
+    pending_email VARCHAR(255) NULL,
+    password_changed_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (role) REFERENCES roles(name),
+    FOREIGN KEY (department_id) REFERENCES departments(id) ON DELETE SET NULL,
+    FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE SET NULL,
+    FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_users_manager (manager_id)
+);
+
+-- Create shifts table. A shift ending at or before its start time ends on
+-- the next day.
+CREATE TABLE IF NOT EXISTS shifts (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    start_time CHAR(5) NOT NULL,
+    end_time CHAR(5) NOT NULL,
+    grace_period_minutes INT NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
 );
 
--- Create attendances table
+-- Create roster entries table (one shift per user and date the shift begins)
+CREATE TABLE IF NOT EXISTS roster_entries (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    shift_id VARCHAR(36) NOT NULL,
+    roster_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (shift_id) REFERENCES shifts(id),
+    UNIQUE KEY unique_user_roster_date (user_id, roster_date),
+    INDEX idx_roster_date (roster_date)
+);
+
+-- Create attendances table. Records of a rostered shift carry the date the
+-- shift begins.
 CREATE TABLE IF NOT EXISTS attendances (
     id VARCHAR(36) PRIMARY KEY,
     user_id VARCHAR(36) NOT NULL,
-    date TIMESTAMP NOT NULL,
+    attendance_date DATE NOT NULL,
+    roster_entry_id VARCHAR(36) NULL,
     status VARCHAR(50) NOT NULL DEFAULT 'present',
+    clock_in DATETIME NULL,
+    clock_out DATETIME NULL,
+    -- Worked time excludes the breaks; missing break minutes flag a shortfall
+    -- against the mandatory break rules
+    worked_minutes INT NOT NULL DEFAULT 0,
+    break_minutes INT NOT NULL DEFAULT 0,
+    missing_break_minutes INT NOT NULL DEFAULT 0,
+    -- Overtime is recomputed whenever the week's records change; a status of
+    -- pending, approved or rejected is kept only while there is overtime
+    overtime_minutes INT NOT NULL DEFAULT 0,
+    overtime_status VARCHAR(20) NOT NULL DEFAULT '',
+    overtime_reviewed_by VARCHAR(36) NULL,
+    overtime_reviewed_at DATETIME NULL,
+    overtime_review_note VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (overtime_reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (roster_entry_id) REFERENCES roster_entries(id) ON DELETE SET NULL,
+    UNIQUE KEY unique_user_date (user_id, attendance_date),
+    -- Listings filter by user and date (unique_user_date) or by date and status
+    INDEX idx_attendances_date_status (attendance_date, status)
+);
+
+-- Create attendance breaks table. A break without an end is in progress.
+CREATE TABLE IF NOT EXISTS attendance_breaks (
+    id VARCHAR(36) PRIMARY KEY,
+    attendance_id VARCHAR(36) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    start_time DATETIME NOT NULL,
+    end_time DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (attendance_id) REFERENCES attendances(id) ON DELETE CASCADE,
+    INDEX idx_breaks_attendance_start (attendance_id, start_time)
+);
+
+-- Create work schedules table
+CREATE TABLE IF NOT EXISTS work_schedules (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    start_time CHAR(5) NOT NULL,
+    end_time CHAR(5) NOT NULL,
+    grace_period_minutes INT NOT NULL DEFAULT 0,
+    work_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5',
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- Create work schedule assignments table (one schedule per user)
+CREATE TABLE IF NOT EXISTS work_schedule_assignments (
+    user_id VARCHAR(36) PRIMARY KEY,
+    schedule_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (schedule_id) REFERENCES work_schedules(id) ON DELETE CASCADE
+);
+
+-- Create leave requests table
+CREATE TABLE IF NOT EXISTS leave_requests (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    leave_type VARCHAR(20) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    days INT NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by VARCHAR(36) NULL,
+    reviewed_at DATETIME NULL,
+    review_note TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_leave_user_dates (user_id, start_date, end_date),
+    INDEX idx_leave_status (status)
+);
+
+-- Create leave balances table (one row per user, year and balance-tracked leave type)
+CREATE TABLE IF NOT EXISTS leave_balances (
+    user_id VARCHAR(36) NOT NULL,
+    year INT NOT NULL,
+    leave_type VARCHAR(20) NOT NULL,
+    entitled_days INT NOT NULL DEFAULT 0,
+    used_days INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, year, leave_type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create holidays table (an empty location means a company-wide holiday)
+CREATE TABLE IF NOT EXISTS holidays (
+    id VARCHAR(36) PRIMARY KEY,
+    holiday_date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    location VARCHAR(100) NOT NULL DEFAULT '',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_holiday_date_location (holiday_date, location)
+);
+
+-- Create attendance corrections table
+CREATE TABLE IF NOT EXISTS attendance_corrections (
+    id VARCHAR(36) PRIMARY KEY,
+    attendance_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    proposed_status VARCHAR(50) NOT NULL DEFAULT '',
+    proposed_clock_in DATETIME NULL,
+    proposed_clock_out DATETIME NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by VARCHAR(36) NULL,
+    reviewed_at DATETIME NULL,
+    review_note TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (attendance_id) REFERENCES attendances(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_correction_attendance_status (attendance_id, status),
+    INDEX idx_correction_status (status)
+);
+
+-- Create attendance history table. Rows keep the values a record had before each
+-- change and deliberately have no foreign keys so the audit trail outlives them.
+CREATE TABLE IF NOT EXISTS attendance_history (
+    id VARCHAR(36) PRIMARY KEY,
+    attendance_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    clock_in DATETIME NULL,
+    clock_out DATETIME NULL,
+    worked_minutes INT NOT NULL DEFAULT 0,
+    action VARCHAR(20) NOT NULL,
+    correction_id VARCHAR(36) NULL,
+    changed_by VARCHAR(36) NULL,
+    changed_at DATETIME NOT NULL,
+    INDEX idx_history_attendance (attendance_id, changed_at)
+);
+
+-- Create payroll exports table. Every export of a pay period is recorded so
+-- overlapping periods are not exported twice by accident.
+CREATE TABLE IF NOT EXISTS payroll_exports (
+    id VARCHAR(36) PRIMARY KEY,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    format VARCHAR(20) NOT NULL,
+    line_count INT NOT NULL DEFAULT 0,
+    forced BOOLEAN NOT NULL DEFAULT FALSE,
+    exported_by VARCHAR(36) NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (exported_by) REFERENCES users(id) ON DELETE SET NULL,
+    INDEX idx_payroll_exports_period (period_start, period_end)
+);
+
+-- Create refresh tokens table. Only a SHA-256 hash of each token is stored;
+-- tokens rotated from the same login share a family_id.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    family_id VARCHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    revoked_at DATETIME NULL,
+    replaced_by VARCHAR(36) NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_refresh_family (family_id),
+    INDEX idx_refresh_user (user_id),
+    INDEX idx_refresh_expires (expires_at)
+);
+
+-- Create revoked access tokens table (jti denylist). Entries are only needed
+-- until the access token would have expired anyway.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(36) PRIMARY KEY,
+    expires_at DATETIME NOT NULL,
+    INDEX idx_revoked_expires (expires_at)
+);
+
+-- Create one-time tokens table for emailed links such as password resets.
+-- Only a SHA-256 hash of each token is stored.
+CREATE TABLE IF NOT EXISTS one_time_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    purpose VARCHAR(30) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
-    UNIQUE KEY unique_user_date (user_id, DATE(date))
-); 
\ No newline at end of file
+    INDEX idx_one_time_user_purpose (user_id, purpose),
+    INDEX idx_one_time_expires (expires_at)
+);
+
+-- Create user MFA table (TOTP enrollment, pending until enabled_at is set)
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id VARCHAR(36) PRIMARY KEY,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at DATETIME NULL,
+    last_step BIGINT NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create MFA recovery codes table. Only a SHA-256 hash of each code is stored.
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    used_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    INDEX idx_recovery_user (user_id)
+);
+
+-- Create MFA policy table (roles that must use two-factor authentication)
+CREATE TABLE IF NOT EXISTS mfa_required_roles (
+    role VARCHAR(50) PRIMARY KEY,
+    FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+);
+
+-- Create login attempts table. Failed logins are counted per account (by
+-- normalized email) and per client IP; locked_until is set after too many.
+CREATE TABLE IF NOT EXISTS login_attempts (
+    scope VARCHAR(10) NOT NULL,
+    attempt_key VARCHAR(255) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    locked_until DATETIME NULL,
+    last_failure_at DATETIME NOT NULL,
+    PRIMARY KEY (scope, attempt_key),
+    INDEX idx_login_attempts_last_failure (last_failure_at)
+);